@@ -0,0 +1,487 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/database"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/notify"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
+	"github.com/joho/godotenv"
+	_ "github.com/tursodatabase/libsql-client-go/libsql"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// command describes a single admin subcommand
+type command struct {
+	name        string
+	description string
+	needsDB     bool
+	run         func(args []string) error
+}
+
+var commands = []command{
+	{name: "migrate", description: "Run all pending database migrations", run: runMigrate},
+	{name: "create-user", description: "Create an admin user (-email, -password or -password-stdin)", needsDB: true, run: runCreateUser},
+	{name: "reset-password", description: "Reset a user's password and revoke their sessions and login challenges", needsDB: true, run: runResetPassword},
+	{name: "list-users", description: "List all admin users", needsDB: true, run: runListUsers},
+	{name: "revoke-sessions", description: "Revoke every session and login challenge of a user (-email)", needsDB: true, run: runRevokeSessions},
+	{name: "disable-2fa", description: "Turn two-factor authentication off for a user (-email)", needsDB: true, run: runDisableTwoFactor},
+	{name: "list-lockouts", description: "List the accounts and IP addresses with failed logins, locked out or not", needsDB: true, run: runListLockouts},
+	{name: "unlock-login", description: "Clear the failed logins of an account or IP address (-email, -ip or -all)", needsDB: true, run: runUnlockLogin},
+	{name: "dedupe-certificates", description: "Delete certifications whose file duplicates an older one (-dry-run)", needsDB: true, run: runDedupeCertificates},
+	{name: "notify-expiring", description: "Notify the certifications about to expire now, without waiting for the API", needsDB: true, run: runNotifyExpiring},
+	{name: "reconcile-storage", description: "Report certification files without a row and rows without files (-purge, -restore)", needsDB: true, run: runReconcileStorage},
+}
+
+var dbConfig database.Config
+
+func main() {
+	logger.Init(logger.Config{
+		Level:    logger.WARN,
+		Output:   os.Stderr,
+		UseColor: true,
+	})
+
+	if err := godotenv.Load("../.env"); err != nil {
+		logger.Debug("No .env file found, using environment variables")
+	}
+
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(2)
+	}
+
+	cmd, ok := findCommand(os.Args[1])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "unknown command: %s\n\n", os.Args[1])
+		usage()
+		os.Exit(2)
+	}
+
+	var err error
+	dbConfig, err = database.ConfigFromEnv()
+	if err != nil {
+		fail(err)
+	}
+	dbConfig.LogLevel = gormlogger.Silent
+
+	if cmd.needsDB {
+		if err := database.InitDB(dbConfig); err != nil {
+			fail(fmt.Errorf("failed to initialize database: %w", err))
+		}
+		defer func() {
+			if err := database.CloseDB(); err != nil {
+				logger.Error("Error closing database: %v", err)
+			}
+		}()
+	}
+
+	if err := cmd.run(os.Args[2:]); err != nil {
+		fail(err)
+	}
+}
+
+// findCommand returns the subcommand registered under the given name
+func findCommand(name string) (command, bool) {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd, true
+		}
+	}
+	return command{}, false
+}
+
+// usage prints the list of available subcommands
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: portfolio-admin <command> [flags]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "Commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-21s %s\n", cmd.name, cmd.description)
+	}
+}
+
+// fail prints the error and exits with a non-zero status
+func fail(err error) {
+	fmt.Fprintf(os.Stderr, "error: %v\n", err)
+	os.Exit(1)
+}
+
+func runMigrate(args []string) error {
+	fs := flag.NewFlagSet("migrate", flag.ExitOnError)
+	dir := fs.String("dir", dbConfig.MigrationsDir, "migrations directory")
+	_ = fs.Parse(args)
+
+	dbConfig.MigrationsDir = *dir
+	if err := database.RunMigrations(dbConfig); err != nil {
+		return err
+	}
+
+	fmt.Println("Migrations applied")
+	return nil
+}
+
+func runCreateUser(args []string) error {
+	fs := flag.NewFlagSet("create-user", flag.ExitOnError)
+	email := fs.String("email", "", "user email (required)")
+	password := fs.String("password", "", "user password")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	_ = fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	pwd, err := resolvePassword(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	user, err := userService().CreateUser(*email, pwd)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Created user %s (id %d)\n", user.Email, user.ID)
+	return nil
+}
+
+func runResetPassword(args []string) error {
+	fs := flag.NewFlagSet("reset-password", flag.ExitOnError)
+	email := fs.String("email", "", "user email (required)")
+	password := fs.String("password", "", "new password")
+	passwordStdin := fs.Bool("password-stdin", false, "read the new password from stdin")
+	_ = fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	pwd, err := resolvePassword(*password, *passwordStdin)
+	if err != nil {
+		return err
+	}
+
+	if err := userService().ResetPassword(*email, pwd); err != nil {
+		return err
+	}
+
+	fmt.Printf("Password reset for %s, all sessions revoked\n", *email)
+	return nil
+}
+
+func runListUsers(args []string) error {
+	fs := flag.NewFlagSet("list-users", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	users, err := userService().ListUsers()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tEMAIL\t2FA\tCREATED AT")
+	for _, user := range users {
+		fmt.Fprintf(w, "%d\t%s\t%t\t%s\n", user.ID, user.Email, user.TwoFactorEnabled(), user.CreatedAt.Format("2006-01-02 15:04:05"))
+	}
+	return w.Flush()
+}
+
+func runRevokeSessions(args []string) error {
+	fs := flag.NewFlagSet("revoke-sessions", flag.ExitOnError)
+	email := fs.String("email", "", "user email (required)")
+	_ = fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	count, err := userService().RevokeSessions(*email)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Revoked %d session(s) for %s\n", count, *email)
+	return nil
+}
+
+func runDisableTwoFactor(args []string) error {
+	fs := flag.NewFlagSet("disable-2fa", flag.ExitOnError)
+	email := fs.String("email", "", "user email (required)")
+	_ = fs.Parse(args)
+
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	if err := userService().DisableTwoFactor(*email); err != nil {
+		return err
+	}
+
+	fmt.Printf("Two-factor authentication disabled for %s\n", *email)
+	return nil
+}
+
+func runListLockouts(args []string) error {
+	fs := flag.NewFlagSet("list-lockouts", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	throttles, err := loginGuard().List()
+	if err != nil {
+		return err
+	}
+
+	if len(throttles) == 0 {
+		fmt.Println("No failed logins")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SCOPE\tKEY\tFAILURES\tLAST FAILURE\tLOCKED UNTIL")
+	for _, throttle := range throttles {
+		lockedUntil := "-"
+		if throttle.LockedUntil != nil && throttle.LockedUntil.After(time.Now()) {
+			lockedUntil = throttle.LockedUntil.Format("2006-01-02 15:04:05")
+		}
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", throttle.Scope, throttle.Key, throttle.Failures,
+			throttle.LastFailureAt.Format("2006-01-02 15:04:05"), lockedUntil)
+	}
+	return w.Flush()
+}
+
+func runUnlockLogin(args []string) error {
+	fs := flag.NewFlagSet("unlock-login", flag.ExitOnError)
+	email := fs.String("email", "", "account email")
+	ip := fs.String("ip", "", "IP address")
+	all := fs.Bool("all", false, "clear every failed login")
+	_ = fs.Parse(args)
+
+	if *all {
+		count, err := loginGuard().UnlockAll()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Cleared %d failed login counter(s)\n", count)
+		return nil
+	}
+
+	if *email == "" && *ip == "" {
+		return errors.New("-email, -ip or -all is required")
+	}
+
+	count, err := loginGuard().Unlock(*email, *ip)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Cleared %d failed login counter(s)\n", count)
+	return nil
+}
+
+func runDedupeCertificates(args []string) error {
+	fs := flag.NewFlagSet("dedupe-certificates", flag.ExitOnError)
+	dryRun := fs.Bool("dry-run", false, "only report the duplicates, without deleting anything")
+	_ = fs.Parse(args)
+
+	blob, err := certificationStorage()
+	if err != nil {
+		return err
+	}
+	staging, err := certificationStagingStorage()
+	if err != nil {
+		return err
+	}
+
+	repo := repository.NewCareerCertificationRepository(database.GetDB())
+	service := services.NewCareerCertificationService(repo, blob, staging, constants.GetCertificationExpiryNoticeDays())
+	report, err := service.Deduplicate(context.Background(), *dryRun)
+	if err != nil {
+		return err
+	}
+
+	if len(report.Duplicates) > 0 {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tTITLE\tFILE\tDUPLICATE OF")
+		for _, duplicate := range report.Duplicates {
+			fmt.Fprintf(w, "%d\t%s\t%s\t%d\n", duplicate.Certification.ID, duplicate.Certification.Title, duplicate.Certification.OriginalName, duplicate.DuplicateOf)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	for _, certification := range report.Missing {
+		fmt.Fprintf(os.Stderr, "warning: the file of certification %d (%s) could not be read, skipped\n", certification.ID, certification.FileName)
+	}
+
+	action := "Deleted"
+	if *dryRun {
+		action = "Would delete"
+	}
+	fmt.Printf("Scanned %d certification(s), %d hash(es) computed\n", report.Scanned, report.Hashed)
+	fmt.Printf("%s %d duplicate(s), freeing %.1f MB\n", action, len(report.Duplicates), float64(report.FreedBytes)/(1<<20))
+	return nil
+}
+
+func runNotifyExpiring(args []string) error {
+	fs := flag.NewFlagSet("notify-expiring", flag.ExitOnError)
+	_ = fs.Parse(args)
+
+	notifyConfig, err := notify.ConfigFromEnv()
+	if err != nil {
+		return err
+	}
+	sender := notify.New(notifyConfig)
+	if sender == nil {
+		return errors.New("no notification destination configured (NOTIFY_EMAIL_TO or NOTIFY_WEBHOOK_URL)")
+	}
+
+	notifier := services.NewCertificationExpiryNotifier(
+		repository.NewCareerCertificationRepository(database.GetDB()),
+		sender,
+		constants.GetCertificationExpiryNoticeDays(),
+		constants.GetCertificationExpiryCheckInterval(),
+	)
+	count, err := notifier.Check(context.Background())
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Notified %d expiring certification(s)\n", count)
+	return nil
+}
+
+func runReconcileStorage(args []string) error {
+	fs := flag.NewFlagSet("reconcile-storage", flag.ExitOnError)
+	purge := fs.Bool("purge", false, "delete the orphaned files")
+	restore := fs.Bool("restore", false, "restore the deleted certifications whose files are all still stored")
+	_ = fs.Parse(args)
+
+	blob, err := certificationStorage()
+	if err != nil {
+		return err
+	}
+
+	reconciler := services.NewStorageReconciler(
+		repository.NewCareerCertificationRepository(database.GetDB()),
+		blob,
+		constants.GetStorageReconcileInterval(),
+		*purge,
+	)
+	report, err := reconciler.Reconcile(context.Background(), services.ReconcileOptions{Purge: *purge, Restore: *restore})
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	if len(report.Orphaned) > 0 {
+		fmt.Fprintln(w, "ORPHANED FILE\tSIZE\tMODIFIED")
+		for _, orphan := range report.Orphaned {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", orphan.Key, orphan.Size, orphan.LastModified.Format(time.DateTime))
+		}
+		fmt.Fprintln(w)
+	}
+	for _, section := range []struct {
+		header string
+		files  []services.CertificationFiles
+	}{
+		{"RESTORED ID\tTITLE\tFILES", report.Restored},
+		{"TRASHED ID\tTITLE\tFILES", report.Trashed},
+		{"ID\tTITLE\tMISSING FILES", report.Missing},
+	} {
+		if len(section.files) == 0 {
+			continue
+		}
+		fmt.Fprintln(w, section.header)
+		for _, files := range section.files {
+			fmt.Fprintf(w, "%d\t%s\t%s\n", files.Certification.ID, files.Certification.Title, strings.Join(files.Keys, ", "))
+		}
+		fmt.Fprintln(w)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Printf("Scanned %d file(s) and %d certification(s)\n", report.Files, report.Certifications)
+	fmt.Printf("%d orphaned file(s), %d certification(s) in the trash with files, %d certification(s) with missing files\n",
+		len(report.Orphaned), len(report.Trashed), len(report.Missing))
+	if report.Recent > 0 {
+		fmt.Printf("%d unreferenced file(s) written in the last hour were left alone\n", report.Recent)
+	}
+	if *restore {
+		fmt.Printf("Restored %d certification(s)\n", len(report.Restored))
+	}
+	if *purge {
+		fmt.Printf("Purged %d file(s), freeing %.1f MB\n", report.Purged, float64(report.PurgedBytes)/(1<<20))
+	}
+	if len(report.Missing) > 0 {
+		fmt.Println("Replace the file of the certifications with missing files (PUT /api/v1/upload-certificates/:id/file) or delete them")
+	}
+	return nil
+}
+
+// certificationStorage builds the Blob the certification files are stored in
+func certificationStorage() (storage.Blob, error) {
+	storageConfig, err := storage.ConfigFromEnv(constants.CareerCertificationsDir, "/certifications")
+	if err != nil {
+		return nil, err
+	}
+	blob, err := storage.New(storageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize file storage: %w", err)
+	}
+	return blob, nil
+}
+
+// certificationStagingStorage builds the Blob the atomic certification batches stage their files in
+func certificationStagingStorage() (storage.Blob, error) {
+	storageConfig, err := storage.ConfigFromEnv(constants.CertificationStagingDir, "/certification-staging")
+	if err != nil {
+		return nil, err
+	}
+	blob, err := storage.New(storageConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize staging storage: %w", err)
+	}
+	return blob, nil
+}
+
+// userService builds a UserService backed by the initialized database
+func userService() services.UserService {
+	return services.NewUserService(repository.NewAuthRepository(database.GetDB()))
+}
+
+// loginGuard builds a LoginGuard backed by the initialized database
+func loginGuard() services.LoginGuard {
+	return services.NewLoginGuard(repository.NewLoginThrottleRepository(database.GetDB()),
+		constants.GetLoginMaxFailures(), constants.GetLoginMaxFailuresPerIP(), constants.GetLoginLockoutDuration())
+}
+
+// resolvePassword returns the password from the flag or, when requested, from the first line of stdin
+func resolvePassword(password string, fromStdin bool) (string, error) {
+	if fromStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return "", fmt.Errorf("failed to read password from stdin: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	if password == "" {
+		return "", errors.New("a password is required (-password or -password-stdin)")
+	}
+	return password, nil
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"strings"
 	"time"
@@ -14,6 +15,8 @@ import (
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/database"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/notify"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	"github.com/joho/godotenv"
@@ -71,7 +74,24 @@ func main() {
 
 	db := database.GetDB()
 
-	projectHandler, experienceHandler, experienceClientHandler, careerCertificationHandler, authHandler, authService := registerDependencies(db)
+	storageConfig := configStorage("certification", constants.CareerCertificationsDir, "/certifications")
+	certificationStorage, err := storage.New(storageConfig)
+	if err != nil {
+		logger.Fatal("Failed to initialize file storage: %v", err)
+	}
+
+	// Never served: the files of the atomic batches are only published once their certifications are saved
+	stagingStorage, err := storage.New(configStorage("certification staging", constants.CertificationStagingDir, "/certification-staging"))
+	if err != nil {
+		logger.Fatal("Failed to initialize certification staging storage: %v", err)
+	}
+
+	avatarStorage, err := storage.New(configStorage("avatar", constants.AvatarsDir, "/avatars"))
+	if err != nil {
+		logger.Fatal("Failed to initialize avatar storage: %v", err)
+	}
+
+	routeHandlers, authService := registerDependencies(db, certificationStorage, stagingStorage, avatarStorage)
 
 	cleanExpiredSessions(authService)
 
@@ -84,8 +104,15 @@ func main() {
 
 	r.MaxMultipartMemory = 10 << 20 // 10 MB
 
+	// The client IP, which failed logins are counted by, is only read from X-Forwarded-For behind a trusted proxy
+	trustedProxies := constants.GetTrustedProxies()
+	if err := r.SetTrustedProxies(trustedProxies); err != nil {
+		logger.Fatal("Invalid TRUSTED_PROXIES: %v", err)
+	}
+
 	// Add custom middleware
 	r.Use(middleware.RecoveryMiddleware())
+	r.Use(middleware.WarnUntrustedProxy(trustedProxies))
 	r.Use(middleware.LoggerMiddleware())
 	r.Use(middleware.Throttler(middleware.MaxRequestsPerSecond))
 
@@ -94,7 +121,7 @@ func main() {
 	config := cors.DefaultConfig()
 	config.AllowOrigins = strings.Split(origins, ",")
 	config.AllowCredentials = true
-	config.AllowHeaders = append(config.AllowHeaders, "Authorization", "Cookie")
+	config.AllowHeaders = append(config.AllowHeaders, "Authorization", "Cookie", constants.CSRFHeaderName)
 
 	for _, origin := range config.AllowOrigins {
 		logger.Debug("Allowed origin: %s", origin)
@@ -102,20 +129,16 @@ func main() {
 
 	r.Use(cors.New(config))
 
-	r.Static("/certifications", constants.CareerCertificationsDir)
-	logger.Info("Serving static files from: %s", constants.CareerCertificationsDir)
+	if storageConfig.Driver == "local" {
+		r.Static("/certifications", constants.CareerCertificationsDir)
+		logger.Info("Serving static files from: %s", constants.CareerCertificationsDir)
+		r.Static("/avatars", constants.AvatarsDir)
+		logger.Info("Serving static files from: %s", constants.AvatarsDir)
+	}
 
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	routes.SetupRoutes(
-		r,
-		experienceHandler,
-		experienceClientHandler,
-		projectHandler,
-		careerCertificationHandler,
-		authHandler,
-		authService,
-	)
+	routes.SetupRoutes(r, routeHandlers, authService)
 
 	port := os.Getenv("SERVER_PORT")
 	if port == "" {
@@ -131,97 +154,192 @@ func main() {
 	}
 }
 
-// cleanExpiredSessions periodically removes expired sessions using the provided AuthService at a 30-day interval.
+// cleanExpiredSessions removes the expired sessions, login challenges and stale failed login counters right away
+// and then every services.SessionCleanupInterval
 func cleanExpiredSessions(authService services.AuthService) {
 	go func() {
-		ticker := time.NewTicker(30 * 24 * time.Hour)
+		ticker := time.NewTicker(services.SessionCleanupInterval)
 		defer ticker.Stop()
 
-		for range ticker.C {
+		for {
 			if err := authService.CleanUpExpiredSessions(); err != nil {
 				logger.Error("Failed to cleanup expired sessions: %s", err.Error())
 			} else {
-				logger.Info("Cleaned up expired sessions")
+				logger.Debug("Cleaned up expired sessions")
 			}
+			<-ticker.C
 		}
 	}()
 }
 
 // configDatabaseDriver initializes and returns a database.Config based on environment variables or defaults.
 func configDatabaseDriver() database.Config {
-	dbDriver := os.Getenv("DB_DRIVER")
-	if dbDriver == "" {
-		dbDriver = "sqlite"
-	}
-
-	dbConfig := database.Config{
-		Driver:        dbDriver,
-		MigrationsDir: "migrations",
+	dbConfig, err := database.ConfigFromEnv()
+	if err != nil {
+		logger.Fatal("Invalid database configuration: %v", err)
 	}
 
-	switch dbDriver {
+	switch dbConfig.Driver {
 	case "turso":
-		tursoURL := os.Getenv("TURSO_DATABASE_URL")
-		tursoToken := os.Getenv("TURSO_AUTH_TOKEN")
+		logger.Info("Using Turso database (production)")
+	case "sqlite":
+		logger.Info("Using SQLite database (development): %s", dbConfig.SQLitePath)
+	}
 
-		if tursoURL == "" || tursoToken == "" {
-			logger.Fatal("TURSO_DATABASE_URL and TURSO_AUTH_TOKEN must be set when using turso driver")
-		}
+	return dbConfig
+}
 
-		dbConfig.TursoURL = tursoURL
-		dbConfig.TursoToken = tursoToken
-		logger.Info("Using Turso database (production)")
+// configStorage builds the storage configuration of the given kind of files from environment variables.
+func configStorage(kind, localDir, publicPath string) storage.Config {
+	storageConfig, err := storage.ConfigFromEnv(localDir, publicPath)
+	if err != nil {
+		logger.Fatal("Invalid storage configuration: %v", err)
+	}
 
-	case "sqlite":
-		dbPath := os.Getenv("DATABASE_PATH")
-		if dbPath == "" {
-			dbPath = "portfolio.db"
+	switch storageConfig.Driver {
+	case "s3":
+		logger.Info("Storing %s files in S3 bucket %s at %s%s", kind, storageConfig.S3Bucket, storageConfig.S3Endpoint, publicPath)
+	case "local":
+		logger.Info("Storing %s files on disk: %s", kind, storageConfig.LocalDir)
+		if os.Getenv("PUBLIC_BASE_URL") == "" {
+			logger.Warn("PUBLIC_BASE_URL not set, %s URLs will use: %s", kind, storageConfig.PublicURL)
 		}
+	}
 
-		dbConfig.SQLitePath = dbPath
-		logger.Info("Using SQLite database (development): %s", dbPath)
+	return storageConfig
+}
 
-	default:
-		logger.Fatal("Invalid DB_DRIVER: %s (use 'sqlite' or 'turso')", dbDriver)
+// configNotifications builds the sender of the admin notifications from environment variables, nil when none is set.
+func configNotifications() notify.Sender {
+	notifyConfig, err := notify.ConfigFromEnv()
+	if err != nil {
+		logger.Fatal("Invalid notification configuration: %v", err)
 	}
 
-	return dbConfig
+	if len(notifyConfig.EmailTo) > 0 {
+		logger.Info("Sending admin notifications by email via %s:%d", notifyConfig.SMTPHost, notifyConfig.SMTPPort)
+	}
+	if notifyConfig.WebhookURL != "" {
+		logger.Info("Sending admin notifications to webhook")
+	}
+
+	return notify.New(notifyConfig)
 }
 
 // registerDependencies initializes and registers all necessary dependencies for handlers.
 // It returns the initialized handlers and auth service.
-func registerDependencies(db *gorm.DB) (
-	*handlers.ProjectHandler,
-	*handlers.ExperienceHandler,
-	*handlers.ExperienceClientHandler,
-	*handlers.CareerCertificationHandler,
-	*handlers.AuthHandler,
-	services.AuthService,
-) {
+func registerDependencies(db *gorm.DB, certificationStorage, stagingStorage, avatarStorage storage.Blob) (routes.Handlers, services.AuthService) {
+	// Link health dependencies (created first for injection into the handlers of the records with links)
+	linkHealthRepo := repository.NewLinkHealthRepository(db)
+	linkHealthService := services.NewLinkHealthService(
+		linkHealthRepo,
+		constants.GetLinkCheckInterval(),
+		constants.GetLinkHealthHideBroken(),
+	)
+	linkHealthService.Start()
+	linkHealthHandler := handlers.NewLinkHealthHandler(linkHealthService)
+
 	// Experience Client dependencies (created first for injection into ExperienceHandler)
 	experienceClientRepo := repository.NewExperienceClientRepository(db)
 	experienceClientService := services.NewExperienceClientService(experienceClientRepo)
-	experienceClientHandler := handlers.NewExperienceClientHandler(experienceClientService)
+	experienceClientHandler := handlers.NewExperienceClientHandler(experienceClientService, linkHealthService)
 
 	// Experience dependencies
 	experienceRepo := repository.NewExperienceRepository(db)
 	experienceService := services.NewExperienceService(experienceRepo)
-	experienceHandler := handlers.NewExperienceHandler(experienceService, experienceClientService)
+	experienceHandler := handlers.NewExperienceHandler(experienceService, experienceClientService, linkHealthService)
 
 	// Project dependencies
 	projectRepo := repository.NewProjectRepository(db)
 	projectService := services.NewProjectService(projectRepo)
-	projectHandler := handlers.NewProjectHandler(projectService)
+	projectHandler := handlers.NewProjectHandler(projectService, linkHealthService)
 
 	// Career certification dependencies
 	careerCertificationRepo := repository.NewCareerCertificationRepository(db)
-	careerCertificationService := services.NewCareerCertificationService(careerCertificationRepo)
-	careerCertificationHandler := handlers.NewCareerCertificationHandler(careerCertificationService)
+	careerCertificationService := services.NewCareerCertificationService(careerCertificationRepo, certificationStorage, stagingStorage, constants.GetCertificationExpiryNoticeDays())
+	// No batch is in progress yet, whatever is staged was left by an interrupted one
+	if err := careerCertificationService.ClearStaging(context.Background()); err != nil {
+		logger.Warn("Failed to clear the certification staging storage: %v", err)
+	}
+	expiryNotifier := services.NewCertificationExpiryNotifier(
+		careerCertificationRepo,
+		configNotifications(),
+		constants.GetCertificationExpiryNoticeDays(),
+		constants.GetCertificationExpiryCheckInterval(),
+	)
+	expiryNotifier.Start()
+	storageReconciler := services.NewStorageReconciler(
+		careerCertificationRepo,
+		certificationStorage,
+		constants.GetStorageReconcileInterval(),
+		constants.GetStorageReconcilePurge(),
+	)
+	storageReconciler.Start()
+
+	// Trash dependencies
+	trashRepo := repository.NewTrashRepository(db)
+	trashService := services.NewTrashService(trashRepo, careerCertificationRepo, certificationStorage, constants.GetTrashRetentionDays())
+	trashService.Start()
+	trashHandler := handlers.NewTrashHandler(trashService)
+
+	// Upload job dependencies
+	uploadJobRepo := repository.NewUploadJobRepository(db)
+	uploadJobService := services.NewUploadJobService(uploadJobRepo, careerCertificationService, constants.UploadJobsDir)
+	uploadJobService.Start()
+	uploadJobHandler := handlers.NewUploadJobHandler(uploadJobService)
+	careerCertificationHandler := handlers.NewCareerCertificationHandler(careerCertificationService, uploadJobService, linkHealthService)
+
+	// Education dependencies
+	educationRepo := repository.NewEducationRepository(db)
+	educationService := services.NewEducationService(educationRepo)
+	educationHandler := handlers.NewEducationHandler(educationService)
+
+	// Skill dependencies
+	skillRepo := repository.NewSkillRepository(db)
+	skillService := services.NewSkillService(skillRepo)
+	skillHandler := handlers.NewSkillHandler(skillService)
+
+	// Language dependencies
+	languageRepo := repository.NewLanguageRepository(db)
+	languageService := services.NewLanguageService(languageRepo)
+	languageHandler := handlers.NewLanguageHandler(languageService)
+
+	// Profile dependencies
+	profileRepo := repository.NewProfileRepository(db)
+	profileService := services.NewProfileService(profileRepo, avatarStorage)
+	profileHandler := handlers.NewProfileHandler(profileService)
+
+	// Search dependencies
+	searchRepo := repository.NewSearchRepository(db)
+	searchService := services.NewSearchService(searchRepo)
+	searchHandler := handlers.NewSearchHandler(searchService)
+
+	// Resume dependencies
+	resumeRepo := repository.NewResumeRepository(db)
+	resumeService := services.NewResumeService(resumeRepo)
+	resumeHandler := handlers.NewResumeHandler(resumeService, linkHealthService)
 
 	// Auth dependencies
 	authRepo := repository.NewAuthRepository(db)
-	authService := services.NewAuthService(authRepo)
+	loginGuard := services.NewLoginGuard(repository.NewLoginThrottleRepository(db),
+		constants.GetLoginMaxFailures(), constants.GetLoginMaxFailuresPerIP(), constants.GetLoginLockoutDuration())
+	authService := services.NewAuthService(authRepo, loginGuard, constants.GetTOTPEncryptionKey())
 	authHandler := handlers.NewAuthHandler(authService)
 
-	return projectHandler, experienceHandler, experienceClientHandler, careerCertificationHandler, authHandler, authService
+	return routes.Handlers{
+		Experience:          experienceHandler,
+		ExperienceClient:    experienceClientHandler,
+		Project:             projectHandler,
+		CareerCertification: careerCertificationHandler,
+		Education:           educationHandler,
+		Skill:               skillHandler,
+		Language:            languageHandler,
+		Profile:             profileHandler,
+		Search:              searchHandler,
+		Resume:              resumeHandler,
+		UploadJob:           uploadJobHandler,
+		LinkHealth:          linkHealthHandler,
+		Trash:               trashHandler,
+		Auth:                authHandler,
+	}, authService
 }
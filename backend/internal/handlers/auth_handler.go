@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"errors"
+	"math"
 	"net/http"
-	"os"
+	"strconv"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/middleware"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
@@ -13,9 +16,6 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-// CookieMaxAge Cookie max age in seconds (2 hours)
-const CookieMaxAge = 2 * 60 * 60
-
 // AuthHandler handles authentication HTTP requests
 type AuthHandler struct {
 	service services.AuthService
@@ -28,14 +28,17 @@ func NewAuthHandler(service services.AuthService) *AuthHandler {
 
 // Login godoc
 // @Summary Login user
-// @Description Authenticates a user with email and password, sets session cookie
+// @Description Authenticates a user with email and password, sets session cookie. When the user has two-factor
+// @Description authentication enabled, answers 202 with a challenge to complete at /auth/login/verify instead.
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param credentials body dto.LoginRequest true "Login credentials"
 // @Success 200 {object} utils.SuccessResponse{data=models.AuthResponse} "Login successful"
+// @Success 202 {object} utils.SuccessResponse{data=models.AuthResponse} "Two-factor code required"
 // @Failure 400 {object} utils.ErrorResponse "Invalid request body"
 // @Failure 401 {object} utils.ErrorResponse "Invalid credentials"
+// @Failure 429 {object} utils.ErrorResponse "Too many failed logins, retry after the Retry-After header"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /auth/login [post]
 func (h *AuthHandler) Login(c *gin.Context) {
@@ -45,17 +48,66 @@ func (h *AuthHandler) Login(c *gin.Context) {
 		return
 	}
 
-	session, authResponse, err := h.service.Login(req.Email, req.Password)
+	session, authResponse, err := h.service.Login(req.Email, req.Password, sessionClient(c))
 	if err != nil {
-		if errors.Is(err, services.ErrInvalidCredentials) {
+		switch {
+		case errors.Is(err, services.ErrInvalidCredentials):
 			utils.RespondWithError(c, http.StatusUnauthorized, "Invalid email or password", err)
-			return
+		case errors.Is(err, services.ErrLoginThrottled):
+			respondWithLoginThrottled(c, err)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Login failed", err)
+		}
+		return
+	}
+
+	if session == nil {
+		utils.RespondWithSuccess(c, http.StatusAccepted, authResponse, authResponse.Message)
+		return
+	}
+
+	utils.SetSessionCookie(c, session.Token, session.ExpiresAt)
+
+	utils.RespondWithSuccess(c, http.StatusOK, authResponse, "Login successful")
+}
+
+// VerifyLogin godoc
+// @Summary Complete a login with a two-factor code
+// @Description Verifies the TOTP code, or a recovery code, of the challenge returned by /auth/login and sets the
+// @Description session cookie. A challenge expires after 5 minutes or 5 wrong codes.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param verification body dto.VerifyLoginRequest true "Challenge and code"
+// @Success 200 {object} utils.SuccessResponse{data=models.AuthResponse} "Login successful"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body"
+// @Failure 401 {object} utils.ErrorResponse "Invalid code or expired challenge"
+// @Failure 429 {object} utils.ErrorResponse "Too many failed logins, retry after the Retry-After header"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /auth/login/verify [post]
+func (h *AuthHandler) VerifyLogin(c *gin.Context) {
+	var req dto.VerifyLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	session, authResponse, err := h.service.VerifyLogin(req.Challenge, req.Code, sessionClient(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrInvalidChallenge):
+			utils.RespondWithError(c, http.StatusUnauthorized, "Login expired, sign in again", err)
+		case errors.Is(err, services.ErrInvalidTwoFactorCode):
+			utils.RespondWithError(c, http.StatusUnauthorized, "Invalid two-factor code", err)
+		case errors.Is(err, services.ErrLoginThrottled):
+			respondWithLoginThrottled(c, err)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Login failed", err)
 		}
-		utils.RespondWithError(c, http.StatusInternalServerError, "Login failed", err)
 		return
 	}
 
-	h.setSessionCookie(c, session.ID)
+	utils.SetSessionCookie(c, session.Token, session.ExpiresAt)
 
 	utils.RespondWithSuccess(c, http.StatusOK, authResponse, "Login successful")
 }
@@ -69,16 +121,16 @@ func (h *AuthHandler) Login(c *gin.Context) {
 // @Failure 401 {object} utils.ErrorResponse "Not authenticated"
 // @Router /auth/me [get]
 func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
-	sessionID, err := c.Cookie(constants.GetSessionCookieName())
+	token, err := c.Cookie(constants.GetSessionCookieName())
 	if err != nil {
 		utils.RespondWithError(c, http.StatusUnauthorized, "Not authenticated", err)
 		return
 	}
 
-	user, err := h.service.GetUserBySessionID(sessionID)
+	user, err := h.service.GetUserBySessionID(token)
 	if err != nil {
 		if errors.Is(err, services.ErrSessionNotFound) || errors.Is(err, services.ErrSessionExpired) {
-			h.clearSessionCookie(c)
+			utils.ClearSessionCookie(c)
 			utils.RespondWithError(c, http.StatusUnauthorized, "Session expired or invalid", err)
 			return
 		}
@@ -96,69 +148,232 @@ func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 // @Produce json
 // @Success 200 {object} utils.SuccessResponse "Logout successful"
 // @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 403 {object} utils.ErrorResponse "Missing or invalid CSRF token, or cross-origin request"
 // @Router /auth/logout [post]
 func (h *AuthHandler) Logout(c *gin.Context) {
-	sessionID, err := c.Cookie(constants.GetSessionCookieName())
+	token, err := c.Cookie(constants.GetSessionCookieName())
 	if err != nil {
 		utils.RespondWithError(c, http.StatusUnauthorized, "Not authenticated", err)
 		return
 	}
 
-	if err := h.service.Logout(sessionID); err != nil {
+	if err := h.service.Logout(token); err != nil {
 		logger.Warn("Failed to delete session during logout: %s", err.Error())
 	}
 
-	h.clearSessionCookie(c)
+	utils.ClearSessionCookie(c)
 
 	utils.RespondWithSuccess(c, http.StatusOK, nil, "Logout successful")
 }
 
-// setSessionCookie sets the session cookie with appropriate security settings
-func (h *AuthHandler) setSessionCookie(c *gin.Context, sessionID string) {
-	secure := os.Getenv("COOKIE_SECURE") == "true"
-	domain := os.Getenv("COOKIE_DOMAIN")
-	sameSite := http.SameSiteLaxMode
-
-	switch os.Getenv("COOKIE_SAME_SITE") {
-	case "strict":
-		sameSite = http.SameSiteStrictMode
-	case "none":
-		sameSite = http.SameSiteNoneMode
-	}
-
-	c.SetSameSite(sameSite)
-	c.SetCookie(
-		constants.GetSessionCookieName(),
-		sessionID,
-		CookieMaxAge,
-		"/",
-		domain,
-		secure,
-		true,
-	)
-}
-
-// clearSessionCookie clears the session cookie
-func (h *AuthHandler) clearSessionCookie(c *gin.Context) {
-	secure := os.Getenv("COOKIE_SECURE") == "true"
-	domain := os.Getenv("COOKIE_DOMAIN")
-	sameSite := http.SameSiteLaxMode
-
-	switch os.Getenv("COOKIE_SAME_SITE") {
-	case "strict":
-		sameSite = http.SameSiteStrictMode
-	case "none":
-		sameSite = http.SameSiteNoneMode
-	}
-
-	c.SetSameSite(sameSite)
-	c.SetCookie(
-		constants.GetSessionCookieName(),
-		"",
-		-1,
-		"/",
-		domain,
-		secure,
-		true,
-	)
+// ListSessions godoc
+// @Summary List the sessions of the current user
+// @Description Lists the devices signed in as the current user, the most recently used first, marking the session
+// @Description of the request as current
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=[]models.SessionResponse} "Sessions"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Router /auth/sessions [get]
+func (h *AuthHandler) ListSessions(c *gin.Context) {
+	sessions, err := h.service.ListSessions(currentUser(c), currentSession(c).ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to fetch sessions", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, sessions, "")
+}
+
+// RevokeSession godoc
+// @Summary Sign out a session
+// @Description Signs out one of the sessions of the current user; revoking the current session logs out
+// @Tags auth
+// @Produce json
+// @Param id path string true "Session ID, as listed"
+// @Success 200 {object} utils.SuccessResponse "Session revoked"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 404 {object} utils.ErrorResponse "Session not found"
+// @Router /auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c *gin.Context) {
+	current, err := h.service.RevokeSession(currentUser(c), currentSession(c).ID, c.Param("id"))
+	if err != nil {
+		if errors.Is(err, services.ErrSessionNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Session not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to revoke session", err)
+		return
+	}
+
+	if current {
+		utils.ClearSessionCookie(c)
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Session revoked")
+}
+
+// RevokeOtherSessions godoc
+// @Summary Log out other devices
+// @Description Signs out every session of the current user but the one of the request
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=models.RevokedSessionsResponse} "Number of revoked sessions"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Router /auth/sessions [delete]
+func (h *AuthHandler) RevokeOtherSessions(c *gin.Context) {
+	count, err := h.service.RevokeOtherSessions(currentUser(c), currentSession(c).ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to revoke sessions", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, models.RevokedSessionsResponse{Revoked: count}, "Other devices logged out")
+}
+
+// SetupTwoFactor godoc
+// @Summary Start the two-factor enrollment
+// @Description Generates a TOTP secret for the current user and returns it with its otpauth:// provisioning URI,
+// @Description to scan as a QR code. Two-factor authentication is only enabled once confirmed with a code.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=models.TwoFactorSetup} "Two-factor secret"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication is already enabled"
+// @Failure 503 {object} utils.ErrorResponse "Two-factor authentication is not configured"
+// @Router /auth/2fa/setup [post]
+func (h *AuthHandler) SetupTwoFactor(c *gin.Context) {
+	setup, err := h.service.SetupTwoFactor(currentUser(c))
+	if err != nil {
+		respondWithTwoFactorError(c, err, "Failed to set up two-factor authentication")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, setup, "Scan the QR code and confirm with a code")
+}
+
+// EnableTwoFactor godoc
+// @Summary Enable two-factor authentication
+// @Description Confirms the enrollment with a code from the authenticator app and returns the recovery codes,
+// @Description which are shown only once
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} utils.SuccessResponse{data=models.RecoveryCodesResponse} "Two-factor authentication enabled"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or code"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 409 {object} utils.ErrorResponse "Already enabled or not set up"
+// @Router /auth/2fa/enable [post]
+func (h *AuthHandler) EnableTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	codes, err := h.service.EnableTwoFactor(currentUser(c), req.Code)
+	if err != nil {
+		respondWithTwoFactorError(c, err, "Failed to enable two-factor authentication")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes}, "Two-factor authentication enabled")
+}
+
+// DisableTwoFactor godoc
+// @Summary Disable two-factor authentication
+// @Description Turns two-factor authentication off after checking a TOTP code or a recovery code
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body dto.TwoFactorCodeRequest true "TOTP code or recovery code"
+// @Success 200 {object} utils.SuccessResponse "Two-factor authentication disabled"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or code"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication is not enabled"
+// @Router /auth/2fa/disable [post]
+func (h *AuthHandler) DisableTwoFactor(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	if err := h.service.DisableTwoFactor(currentUser(c), req.Code); err != nil {
+		respondWithTwoFactorError(c, err, "Failed to disable two-factor authentication")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Two-factor authentication disabled")
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate the recovery codes
+// @Description Replaces the recovery codes after checking a TOTP code; the previous codes stop working
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param code body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} utils.SuccessResponse{data=models.RecoveryCodesResponse} "New recovery codes"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or code"
+// @Failure 401 {object} utils.ErrorResponse "Not authenticated"
+// @Failure 409 {object} utils.ErrorResponse "Two-factor authentication is not enabled"
+// @Router /auth/2fa/recovery-codes [post]
+func (h *AuthHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid request body", err)
+		return
+	}
+
+	codes, err := h.service.RegenerateRecoveryCodes(currentUser(c), req.Code)
+	if err != nil {
+		respondWithTwoFactorError(c, err, "Failed to regenerate recovery codes")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, models.RecoveryCodesResponse{RecoveryCodes: codes}, "Recovery codes regenerated")
+}
+
+// currentUser returns the user authenticated by AuthMiddleware
+func currentUser(c *gin.Context) *models.User {
+	return c.MustGet(middleware.UserContextKey).(*models.User)
+}
+
+// currentSession returns the session validated by AuthMiddleware
+func currentSession(c *gin.Context) *models.Session {
+	return c.MustGet(middleware.SessionContextKey).(*models.Session)
+}
+
+// sessionClient returns the device a request comes from
+func sessionClient(c *gin.Context) models.SessionClient {
+	return models.SessionClient{IPAddress: c.ClientIP(), UserAgent: c.Request.UserAgent()}
+}
+
+// respondWithLoginThrottled refuses a login attempted too soon after failed ones, telling in Retry-After how many
+// seconds to wait
+func respondWithLoginThrottled(c *gin.Context, err error) {
+	var throttled *services.LoginThrottledError
+	if errors.As(err, &throttled) {
+		seconds := int(math.Ceil(throttled.RetryAfter.Seconds()))
+		c.Header("Retry-After", strconv.Itoa(seconds))
+	}
+	utils.RespondWithError(c, http.StatusTooManyRequests, "Too many failed login attempts, try again later", err)
+}
+
+// respondWithTwoFactorError maps the errors of the two-factor enrollment to their status codes
+func respondWithTwoFactorError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, services.ErrInvalidTwoFactorCode):
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid two-factor code", err)
+	case errors.Is(err, services.ErrTwoFactorAlreadyEnabled),
+		errors.Is(err, services.ErrTwoFactorNotEnabled),
+		errors.Is(err, services.ErrTwoFactorNotSetUp):
+		utils.RespondWithError(c, http.StatusConflict, "", err)
+	case errors.Is(err, services.ErrTwoFactorNotConfigured):
+		utils.RespondWithError(c, http.StatusServiceUnavailable, "Two-factor authentication is not configured", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, message, err)
+	}
 }
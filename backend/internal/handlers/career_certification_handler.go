@@ -2,35 +2,44 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
-	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/imaging"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
 
 type CareerCertificationHandler struct {
 	service services.CareerCertificationService
+	jobs    services.UploadJobService
+	links   services.LinkHealthService
 }
 
-func NewCareerCertificationHandler(service services.CareerCertificationService) *CareerCertificationHandler {
-	return &CareerCertificationHandler{service: service}
+func NewCareerCertificationHandler(service services.CareerCertificationService, jobs services.UploadJobService, links services.LinkHealthService) *CareerCertificationHandler {
+	return &CareerCertificationHandler{service: service, jobs: jobs, links: links}
 }
 
 // UploadAcademicCertificates handles both single and multiple file uploads with optional metadata
 // @Summary Upload certification files
-// @Description Upload one or multiple certification files with optional metadata
+// @Description Queues an upload job for one or multiple certification files with optional metadata and returns it
+// @Description right away; follow it with GET /jobs/{id} or the /jobs/{id}/events stream. The metadata fields apply
+// @Description to every file and the metadata entries override them per file. Accepts JPG, JPEG, PNG and WEBP images
+// @Description and PDF documents (max 20MB each, 100MB per request). The content must match the extension; PDFs get
+// @Description a rasterised preview of their first page. A file identical to one already stored is not stored again,
+// @Description the job reports it as a duplicate linked to the existing certification.
 // @Tags certifications
 // @Accept multipart/form-data
 // @Produce json
 // @Param files formData file true "Certification file(s)"
 // @Param workers query int false "Number of concurrent workers (default: 3, 0 = use default, max: 20)"
+// @Param atomic query bool false "Store every file or none: the first failure discards the whole batch"
 // @Param title formData string false "Certification title"
 // @Param issuer formData string false "Issuer/Institution name"
 // @Param issue_date formData string false "Issue date (DD/MM/YYYY)"
@@ -38,9 +47,12 @@ func NewCareerCertificationHandler(service services.CareerCertificationService)
 // @Param credential_id formData string false "Credential ID"
 // @Param credential_url formData string false "Credential verification URL"
 // @Param description formData string false "Description"
-// @Success 200 {object} map[string]interface{}
-// @Failure 400 {object} map[string]interface{}
-// @Failure 500 {object} map[string]interface{}
+// @Param metadata formData string false "Per-file metadata: a JSON array of objects with the fields above plus file (original name) or index; entries without either apply to the file at the same position. metadata[i].title style fields are accepted too"
+// @Success 202 {object} utils.SuccessResponse{data=models.UploadJob} "Upload queued"
+// @Failure 400 {object} utils.ErrorResponse "Missing files or invalid metadata"
+// @Failure 413 {object} utils.ErrorResponse "Request body too large"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Failure 503 {object} utils.ErrorResponse "Too many uploads in progress"
 // @Router /upload-certificates [post]
 func (h *CareerCertificationHandler) UploadAcademicCertificates(c *gin.Context) {
 	queryParams, _ := c.Get("validatedQuery")
@@ -48,6 +60,10 @@ func (h *CareerCertificationHandler) UploadAcademicCertificates(c *gin.Context)
 
 	form, err := c.MultipartForm()
 	if err != nil {
+		if tooLarge := new(http.MaxBytesError); errors.As(err, &tooLarge) {
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %dMB", tooLarge.Limit>>20), err)
+			return
+		}
 		utils.RespondWithError(c, http.StatusBadRequest, "Failed to parse form data", err)
 		return
 	}
@@ -58,95 +74,66 @@ func (h *CareerCertificationHandler) UploadAcademicCertificates(c *gin.Context)
 		return
 	}
 
-	var metadata dto.CertificationMetadata
-	if err := c.ShouldBind(&metadata); err != nil {
-		logger.Warn("Failed to parse metadata: %v", err)
-	}
-
-	filesWithMetadata := make([]services.FileWithMetadata, len(files))
-	for i, file := range files {
-		filesWithMetadata[i] = services.FileWithMetadata{
-			File:     file,
-			Metadata: &metadata,
-		}
-	}
-
-	timeout := 30 * time.Second
-	if len(files) > 5 {
-		timeout = 5 * time.Minute
-	}
-
-	ctx, cancel := context.WithTimeout(context.Background(), timeout)
-	defer cancel()
+	metadata := c.MustGet("validatedMetadata").([]dto.CertificationMetadata)
 
-	baseURL := os.Getenv("PUBLIC_BASE_URL")
-	if baseURL == "" {
-		scheme := "http"
-		if c.Request.TLS != nil {
-			scheme = "https"
-		}
-		baseURL = fmt.Sprintf("%s://%s", scheme, c.Request.Host)
-		logger.Warn("PUBLIC_BASE_URL not set, falling back to: %s", baseURL)
-	}
-	baseURL = fmt.Sprintf("%s/certifications", baseURL)
-
-	results := h.service.StoreBatch(ctx, filesWithMetadata, params.Workers, baseURL)
-
-	var successful []map[string]interface{}
-	var failed []map[string]string
-
-	for _, result := range results {
-		if result.Success {
-			successful = append(successful, map[string]interface{}{
-				"id":            result.Certification.ID,
-				"title":         result.Certification.Title,
-				"issuer":        result.Certification.Issuer,
-				"issue_date":    result.Certification.IssueDate,
-				"file_url":      result.Certification.FileURL,
-				"file_name":     result.Certification.FileName,
-				"original_name": result.Certification.OriginalName,
-			})
-		} else {
-			failed = append(failed, map[string]string{
-				"original": result.OriginalName,
-				"error":    result.Error.Error(),
-			})
+	job, err := h.jobs.Enqueue(files, metadata, services.UploadJobOptions{
+		Workers: params.Workers,
+		Atomic:  params.Atomic,
+	})
+	if err != nil {
+		if errors.Is(err, services.ErrUploadQueueFull) {
+			utils.RespondWithError(c, http.StatusServiceUnavailable, "", err)
+			return
 		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to queue upload", err)
+		return
 	}
 
-	statusCode := http.StatusOK
-	if len(successful) == 0 {
-		statusCode = http.StatusInternalServerError
-	} else if len(failed) > 0 {
-		statusCode = http.StatusMultiStatus
-	}
-
-	utils.RespondWithSuccess(c, statusCode, map[string]interface{}{
-		"total":      len(files),
-		"successful": len(successful),
-		"failed":     len(failed),
-		"files":      successful,
-		"errors":     failed,
-	}, "Files upload completed")
+	c.Header("Location", fmt.Sprintf("/api/v1/jobs/%d", job.ID))
+	utils.RespondWithSuccess(c, http.StatusAccepted, job, "Upload queued")
 }
 
 // GetAllCertifications godoc
 // @Summary Get all certifications
-// @Description Retrieves all uploaded career certifications with metadata
+// @Description Retrieves uploaded career certifications with metadata, with optional pagination, sorting and filtering.
+// @Description Each certification has a status computed from its expiry date: valid, expiring (within the notice
+// @Description period, 30 days by default) or expired.
 // @Tags certifications
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. issue_date:desc (fields: title, issuer, issue_date, expiry_date, created_at)"
+// @Param issuer query string false "Issuer name contains"
+// @Param from query string false "Only certifications issued on or after this date"
+// @Param to query string false "Only certifications issued on or before this date"
+// @Param status query string false "Only certifications with this expiry status" Enums(valid, expiring, expired)
 // @Success 200 {object} utils.SuccessResponse{data=[]models.CareerCertification} "List of certifications"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /upload-certificates [get]
 func (h *CareerCertificationHandler) GetAllCertifications(c *gin.Context) {
-	certifications, err := h.service.GetAll()
+	query := c.MustGet("validatedQuery").(dto.CertificationListQuery)
+
+	filter, err := query.ToFilter()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid date filter", err)
+		return
+	}
+
+	opts := query.ToListOptions(query.Sort)
+	certifications, total, err := h.service.GetAll(filter, opts)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve certifications", err)
 		return
 	}
 
-	utils.RespondWithSuccess(c, http.StatusOK, certifications, "")
+	broken := publicLinks(c, h.links)
+	for i := range certifications {
+		broken.RedactCertification(&certifications[i])
+	}
+
+	utils.RespondWithPage(c, certifications, utils.NewPagination(c, opts.Page, opts.Limit, total))
 }
 
 // GetCertificationByID godoc
@@ -170,16 +157,124 @@ func (h *CareerCertificationHandler) GetCertificationByID(c *gin.Context) {
 
 	certification, err := h.service.GetByID(uint(id))
 	if err != nil {
-		utils.RespondWithError(c, http.StatusNotFound, "Certification not found", err)
+		if errors.Is(err, constants.ErrCertificationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Certification not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve certification", err)
 		return
 	}
 
+	publicLinks(c, h.links).RedactCertification(certification)
 	utils.RespondWithSuccess(c, http.StatusOK, certification, "")
 }
 
+// UpdateCertification godoc
+// @Summary Update a certification
+// @Description Edits the metadata of a certification (partial update supported - send only fields to update).
+// @Description Send an empty expiry_date, credential_id or credential_url to clear it. The file is left untouched.
+// @Tags certifications
+// @Accept json
+// @Produce json
+// @Param id path int true "Certification ID"
+// @Param certification body dto.UpdateCertificationRequest true "Fields to update"
+// @Success 200 {object} utils.SuccessResponse "Certification updated successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or request body"
+// @Failure 404 {object} utils.ErrorResponse "Certification not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /upload-certificates/{id} [patch]
+func (h *CareerCertificationHandler) UpdateCertification(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	updateReq := req.(dto.UpdateCertificationRequest)
+
+	updates, err := updateReq.ToUpdateMap()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid update data", err)
+		return
+	}
+
+	if err := h.service.Update(uint(id), updates); err != nil {
+		if errors.Is(err, constants.ErrCertificationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Certification not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update certification", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Certification updated successfully")
+}
+
+// ReplaceCertificationFile godoc
+// @Summary Replace the file of a certification
+// @Description Replaces the certificate image or PDF, keeping its metadata. The new file goes through the same checks
+// @Description and processing as an upload; the old file and its renditions are removed once the replacement is stored.
+// @Description A file identical to the one of another certification is rejected.
+// @Tags certifications
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path int true "Certification ID"
+// @Param file formData file true "New certification file"
+// @Success 200 {object} utils.SuccessResponse{data=models.CareerCertification} "Certification file replaced successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID, missing or invalid file"
+// @Failure 404 {object} utils.ErrorResponse "Certification not found"
+// @Failure 409 {object} utils.ErrorResponse "The file is already stored by another certification"
+// @Failure 413 {object} utils.ErrorResponse "Request body too large"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /upload-certificates/{id}/file [put]
+func (h *CareerCertificationHandler) ReplaceCertificationFile(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		if tooLarge := new(http.MaxBytesError); errors.As(err, &tooLarge) {
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %dMB", tooLarge.Limit>>20), err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusBadRequest, "No file uploaded", err)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 30*time.Second)
+	defer cancel()
+
+	certification, err := h.service.ReplaceFile(ctx, uint(id), file)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrCertificationNotFound):
+			utils.RespondWithError(c, http.StatusNotFound, "Certification not found", err)
+		case errors.Is(err, services.ErrDuplicateCertificate):
+			utils.RespondWithError(c, http.StatusConflict, "", err)
+		case isRejectedUpload(err):
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to replace certification file", err)
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, certification, "Certification file replaced successfully")
+}
+
 // DeleteCertification godoc
 // @Summary Delete a certification
-// @Description Soft deletes a certification and removes its associated file from storage
+// @Description Moves a certification to the trash, its files are kept until it is purged (see /admin/trash)
 // @Tags certifications
 // @Accept json
 // @Produce json
@@ -196,10 +291,24 @@ func (h *CareerCertificationHandler) DeleteCertification(c *gin.Context) {
 		return
 	}
 
-	if err := h.service.Delete(uint(id)); err != nil {
+	if err := h.service.Delete(c.Request.Context(), uint(id)); err != nil {
+		if errors.Is(err, constants.ErrCertificationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Certification not found", err)
+			return
+		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete certification", err)
 		return
 	}
 
 	utils.RespondWithSuccess(c, http.StatusOK, nil, "Certification deleted successfully")
 }
+
+// isRejectedUpload reports whether an upload failed because of the file itself rather than a server error
+func isRejectedUpload(err error) bool {
+	return errors.Is(err, services.ErrInvalidCertificateType) ||
+		errors.Is(err, services.ErrContentMismatch) ||
+		errors.Is(err, services.ErrFileTooLarge) ||
+		errors.Is(err, imaging.ErrUnsupportedImage) ||
+		errors.Is(err, imaging.ErrImageTooLarge) ||
+		errors.Is(err, imaging.ErrInvalidPDF)
+}
@@ -5,3 +5,14 @@ type LoginRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required"`
 }
+
+// VerifyLoginRequest represents the second step of a login with two-factor authentication
+type VerifyLoginRequest struct {
+	Challenge string `json:"challenge" binding:"required"`
+	Code      string `json:"code" binding:"required"`
+}
+
+// TwoFactorCodeRequest carries a TOTP code, or a recovery code where accepted
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
@@ -0,0 +1,178 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+)
+
+// EducationResponse represents the API response for an education entry
+type EducationResponse struct {
+	ID          uint       `json:"id"`
+	Institution string     `json:"institution"`
+	URL         *string    `json:"url,omitempty"`
+	Area        string     `json:"area"`
+	StudyType   string     `json:"studyType"`
+	StartDate   time.Time  `json:"startDate"`
+	EndDate     *time.Time `json:"endDate,omitempty"`
+	Score       string     `json:"score,omitempty"`
+	Courses     []string   `json:"courses"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+}
+
+// EducationRequest represents the API request for creating an education entry
+type EducationRequest struct {
+	Institution string   `json:"institution" binding:"required" validate:"required,min=1,max=255"`
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	Area        string   `json:"area" validate:"omitempty,max=255"`
+	StudyType   string   `json:"study_type" validate:"omitempty,max=255"`
+	StartDate   string   `json:"start_date" binding:"required" validate:"required,date_format"`
+	EndDate     string   `json:"end_date,omitempty" validate:"omitempty,date_format,after_start_date_str"`
+	Score       string   `json:"score,omitempty" validate:"omitempty,max=50"`
+	Courses     []string `json:"courses" validate:"omitempty,dive,min=1,max=255"`
+}
+
+// UpdateEducationRequest represents the API request for updating an education entry (all fields optional)
+type UpdateEducationRequest struct {
+	Institution *string  `json:"institution,omitempty" validate:"omitempty,min=1,max=255"`
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url,max=500"`
+	Area        *string  `json:"area,omitempty" validate:"omitempty,max=255"`
+	StudyType   *string  `json:"study_type,omitempty" validate:"omitempty,max=255"`
+	StartDate   *string  `json:"start_date,omitempty" validate:"omitempty,date_format"`
+	EndDate     *string  `json:"end_date,omitempty" validate:"omitempty,date_format"`
+	Score       *string  `json:"score,omitempty" validate:"omitempty,max=50"`
+	Courses     []string `json:"courses,omitempty" validate:"omitempty,dive,min=1,max=255"`
+}
+
+// ToEducationResponse converts a models.Education to EducationResponse
+func ToEducationResponse(education *models.Education) EducationResponse {
+	var endDate *time.Time
+	if education.EndDate != nil {
+		endDate = &education.EndDate.Time
+	}
+
+	return EducationResponse{
+		ID:          education.ID,
+		Institution: education.Institution,
+		URL:         education.URL,
+		Area:        education.Area,
+		StudyType:   education.StudyType,
+		StartDate:   education.StartDate.Time,
+		EndDate:     endDate,
+		Score:       education.Score,
+		Courses:     []string(education.Courses),
+		CreatedAt:   education.CreatedAt,
+		UpdatedAt:   education.UpdatedAt,
+	}
+}
+
+// ToEducationResponseList converts a slice of models.Education to EducationResponse
+func ToEducationResponseList(education []models.Education) []EducationResponse {
+	responses := make([]EducationResponse, len(education))
+	for i, entry := range education {
+		responses[i] = ToEducationResponse(&entry)
+	}
+	return responses
+}
+
+// ToEducation converts EducationRequest to models.Education
+func (req *EducationRequest) ToEducation() (*models.Education, error) {
+	startDate, err := utils.ParseToDate(req.StartDate)
+	if err != nil {
+		return nil, err
+	}
+
+	endDate, err := utils.ParseToDatePtr(req.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Education{
+		Institution: req.Institution,
+		URL:         req.URL,
+		Area:        req.Area,
+		StudyType:   req.StudyType,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Score:       req.Score,
+		Courses:     models.JSONStrings(req.Courses),
+	}, nil
+}
+
+// ToUpdateMap converts UpdateEducationRequest to a map for partial updates
+func (req *UpdateEducationRequest) ToUpdateMap() (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if req.Institution != nil {
+		updates["institution"] = *req.Institution
+	}
+
+	if req.URL != nil {
+		updates["url"] = req.URL
+	}
+
+	if req.Area != nil {
+		updates["area"] = *req.Area
+	}
+
+	if req.StudyType != nil {
+		updates["study_type"] = *req.StudyType
+	}
+
+	if req.StartDate != nil {
+		startDate, err := utils.ParseToDate(*req.StartDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["start_date"] = startDate
+	}
+
+	if req.EndDate != nil {
+		if *req.EndDate == "" {
+			// Allow clearing the end date
+			updates["end_date"] = nil
+		} else {
+			endDate, err := utils.ParseToDatePtr(*req.EndDate)
+			if err != nil {
+				return nil, err
+			}
+			updates["end_date"] = endDate
+		}
+	}
+
+	if req.Score != nil {
+		updates["score"] = *req.Score
+	}
+
+	if req.Courses != nil {
+		updates["courses"] = models.JSONStrings(req.Courses)
+	}
+
+	return updates, nil
+}
+
+// EducationListQuery represents the query parameters accepted by the education listing
+type EducationListQuery struct {
+	ListQuery
+	Sort      string `form:"sort" validate:"omitempty,sort_fields=institution study_type start_date end_date created_at"`
+	StudyType string `form:"study_type" validate:"omitempty,max=255"`
+	From      string `form:"from" validate:"omitempty,date_format"`
+	To        string `form:"to" validate:"omitempty,date_format"`
+}
+
+// ToFilter converts EducationListQuery to a repository.EducationFilter
+func (q *EducationListQuery) ToFilter() (repository.EducationFilter, error) {
+	from, to, err := parseDateRange(q.From, q.To)
+	if err != nil {
+		return repository.EducationFilter{}, err
+	}
+
+	return repository.EducationFilter{
+		StudyType: q.StudyType,
+		StartFrom: from,
+		StartTo:   to,
+	}, nil
+}
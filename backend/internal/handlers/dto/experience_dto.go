@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
 )
 
@@ -159,3 +160,30 @@ func (req *UpdateExperienceRequest) ToUpdateMap() (map[string]interface{}, error
 
 	return updates, nil
 }
+
+// ExperienceListQuery represents the query parameters accepted by the experience listing
+type ExperienceListQuery struct {
+	ListQuery
+	Sort    string `form:"sort" validate:"omitempty,sort_fields=title company start_date end_date created_at"`
+	Type    string `form:"type" validate:"omitempty,oneof=Remote 'On Site' Hybrid"`
+	Company string `form:"company" validate:"omitempty,max=255"`
+	Current *bool  `form:"current"`
+	From    string `form:"from" validate:"omitempty,date_format"`
+	To      string `form:"to" validate:"omitempty,date_format"`
+}
+
+// ToFilter converts ExperienceListQuery to a repository.ExperienceFilter
+func (q *ExperienceListQuery) ToFilter() (repository.ExperienceFilter, error) {
+	from, to, err := parseDateRange(q.From, q.To)
+	if err != nil {
+		return repository.ExperienceFilter{}, err
+	}
+
+	return repository.ExperienceFilter{
+		Type:      q.Type,
+		Company:   q.Company,
+		Current:   q.Current,
+		StartFrom: from,
+		StartTo:   to,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+)
+
+// LanguageResponse represents the API response for a spoken language
+type LanguageResponse struct {
+	ID        uint      `json:"id"`
+	Language  string    `json:"language"`
+	Fluency   string    `json:"fluency"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// LanguageRequest represents the API request for creating a language
+type LanguageRequest struct {
+	Language string `json:"language" binding:"required" validate:"required,min=1,max=100"`
+	Fluency  string `json:"fluency" binding:"required" validate:"required,min=1,max=100"`
+}
+
+// UpdateLanguageRequest represents the API request for updating a language (all fields optional)
+type UpdateLanguageRequest struct {
+	Language *string `json:"language,omitempty" validate:"omitempty,min=1,max=100"`
+	Fluency  *string `json:"fluency,omitempty" validate:"omitempty,min=1,max=100"`
+}
+
+// ToLanguageResponse converts a models.Language to LanguageResponse
+func ToLanguageResponse(language *models.Language) LanguageResponse {
+	return LanguageResponse{
+		ID:        language.ID,
+		Language:  language.Name,
+		Fluency:   language.Fluency,
+		CreatedAt: language.CreatedAt,
+		UpdatedAt: language.UpdatedAt,
+	}
+}
+
+// ToLanguageResponseList converts a slice of models.Language to LanguageResponse
+func ToLanguageResponseList(languages []models.Language) []LanguageResponse {
+	responses := make([]LanguageResponse, len(languages))
+	for i, language := range languages {
+		responses[i] = ToLanguageResponse(&language)
+	}
+	return responses
+}
+
+// ToLanguage converts LanguageRequest to models.Language
+func (req *LanguageRequest) ToLanguage() *models.Language {
+	return &models.Language{
+		Name:    req.Language,
+		Fluency: req.Fluency,
+	}
+}
+
+// ToUpdateMap converts UpdateLanguageRequest to a map for partial updates
+func (req *UpdateLanguageRequest) ToUpdateMap() map[string]interface{} {
+	updates := make(map[string]interface{})
+
+	if req.Language != nil {
+		updates["name"] = *req.Language
+	}
+
+	if req.Fluency != nil {
+		updates["fluency"] = *req.Fluency
+	}
+
+	return updates
+}
+
+// LanguageListQuery represents the query parameters accepted by the language listing
+type LanguageListQuery struct {
+	ListQuery
+	Sort string `form:"sort" validate:"omitempty,sort_fields=name fluency created_at"`
+}
@@ -0,0 +1,6 @@
+package dto
+
+// LinkHealthQuery represents the query parameters of the link health report
+type LinkHealthQuery struct {
+	Status string `form:"status" validate:"omitempty,oneof=ok broken unverified pending"`
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+)
+
+// DefaultPageLimit is the page size used when a page is requested without an explicit limit
+const DefaultPageLimit = 20
+
+// ListQuery holds the pagination query parameters shared by every list endpoint.
+// When neither page nor limit is given the whole collection is returned.
+type ListQuery struct {
+	Page  int `form:"page" validate:"omitempty,min=1"`
+	Limit int `form:"limit" validate:"omitempty,min=1,max=100"`
+}
+
+// ToListOptions converts the pagination parameters and an already validated sort expression
+// (e.g. "start_date:desc,name:asc") to repository.ListOptions
+func (q ListQuery) ToListOptions(sort string) repository.ListOptions {
+	opts := repository.ListOptions{
+		Page:  q.Page,
+		Limit: q.Limit,
+		Sort:  parseSort(sort),
+	}
+
+	if opts.Page > 0 && opts.Limit == 0 {
+		opts.Limit = DefaultPageLimit
+	}
+	if opts.Page == 0 {
+		opts.Page = 1
+	}
+
+	return opts
+}
+
+// parseSort splits a sort expression into sort fields. Direction defaults to ascending.
+func parseSort(sort string) []repository.SortField {
+	if strings.TrimSpace(sort) == "" {
+		return nil
+	}
+
+	var fields []repository.SortField
+	for _, part := range strings.Split(sort, ",") {
+		column, direction, _ := strings.Cut(strings.TrimSpace(part), ":")
+		fields = append(fields, repository.SortField{
+			Column: column,
+			Desc:   strings.EqualFold(direction, "desc"),
+		})
+	}
+	return fields
+}
+
+// parseDateRange parses the optional from/to date filters, both bounds are inclusive
+func parseDateRange(from, to string) (*time.Time, *time.Time, error) {
+	fromDate, err := utils.ParseDateToPtr(from)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	toDate, err := utils.ParseDateToPtr(to)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return fromDate, toDate, nil
+}
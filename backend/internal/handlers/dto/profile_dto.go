@@ -0,0 +1,118 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+)
+
+// ProfileResponse represents the API response for the profile, shaped like the JSON Resume "basics" section
+type ProfileResponse struct {
+	Name      string                  `json:"name"`
+	Label     string                  `json:"label"`
+	AvatarURL *string                 `json:"avatarUrl,omitempty"`
+	Email     string                  `json:"email"`
+	Phone     string                  `json:"phone"`
+	URL       string                  `json:"url"`
+	Summary   string                  `json:"summary"`
+	Location  ProfileLocation         `json:"location"`
+	Profiles  []SocialProfileResponse `json:"profiles"`
+	UpdatedAt time.Time               `json:"updatedAt"`
+}
+
+// ProfileLocation represents where the portfolio owner is based
+type ProfileLocation struct {
+	City        string `json:"city"`
+	Region      string `json:"region"`
+	CountryCode string `json:"countryCode"`
+}
+
+// SocialProfileResponse represents the API response for a social profile
+type SocialProfileResponse struct {
+	ID       uint   `json:"id"`
+	Network  string `json:"network"`
+	Username string `json:"username"`
+	URL      string `json:"url"`
+}
+
+// ProfileRequest represents the API request for creating or replacing the profile
+type ProfileRequest struct {
+	Name     string                 `json:"name" binding:"required" validate:"required,min=1,max=255"`
+	Label    string                 `json:"label" validate:"omitempty,max=255"`
+	Email    string                 `json:"email" validate:"omitempty,email,max=255"`
+	Phone    string                 `json:"phone" validate:"omitempty,max=50"`
+	URL      string                 `json:"url" validate:"omitempty,url,max=500"`
+	Summary  string                 `json:"summary" validate:"omitempty,max=5000"`
+	Location ProfileLocationRequest `json:"location"`
+	Profiles []SocialProfileRequest `json:"profiles" validate:"omitempty,max=20,dive"`
+}
+
+// ProfileLocationRequest represents the location part of a ProfileRequest
+type ProfileLocationRequest struct {
+	City        string `json:"city" validate:"omitempty,max=100"`
+	Region      string `json:"region" validate:"omitempty,max=100"`
+	CountryCode string `json:"country_code" validate:"omitempty,len=2,alpha"`
+}
+
+// SocialProfileRequest represents a social profile in a ProfileRequest
+type SocialProfileRequest struct {
+	Network  string `json:"network" validate:"required,min=1,max=100"`
+	Username string `json:"username" validate:"omitempty,max=255"`
+	URL      string `json:"url" validate:"required,url,max=500"`
+}
+
+// ToProfileResponse converts a models.Profile to ProfileResponse
+func ToProfileResponse(profile *models.Profile) ProfileResponse {
+	profiles := make([]SocialProfileResponse, len(profile.SocialProfiles))
+	for i, social := range profile.SocialProfiles {
+		profiles[i] = SocialProfileResponse{
+			ID:       social.ID,
+			Network:  social.Network,
+			Username: social.Username,
+			URL:      social.URL,
+		}
+	}
+
+	return ProfileResponse{
+		Name:      profile.Name,
+		Label:     profile.Label,
+		AvatarURL: profile.AvatarURL,
+		Email:     profile.Email,
+		Phone:     profile.Phone,
+		URL:       profile.URL,
+		Summary:   profile.Summary,
+		Location: ProfileLocation{
+			City:        profile.City,
+			Region:      profile.Region,
+			CountryCode: profile.CountryCode,
+		},
+		Profiles:  profiles,
+		UpdatedAt: profile.UpdatedAt,
+	}
+}
+
+// ToProfile converts ProfileRequest to models.Profile
+func (req *ProfileRequest) ToProfile() *models.Profile {
+	socialProfiles := make([]models.SocialProfile, len(req.Profiles))
+	for i, social := range req.Profiles {
+		socialProfiles[i] = models.SocialProfile{
+			Network:  social.Network,
+			Username: social.Username,
+			URL:      social.URL,
+		}
+	}
+
+	return &models.Profile{
+		Name:           req.Name,
+		Label:          req.Label,
+		Email:          req.Email,
+		Phone:          req.Phone,
+		URL:            req.URL,
+		Summary:        req.Summary,
+		City:           req.Location.City,
+		Region:         req.Location.Region,
+		CountryCode:    strings.ToUpper(req.Location.CountryCode),
+		SocialProfiles: socialProfiles,
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
 )
 
@@ -128,3 +129,26 @@ func (req *UpdateProjectRequest) ToUpdateMap() (map[string]interface{}, error) {
 
 	return updates, nil
 }
+
+// ProjectListQuery represents the query parameters accepted by the project listing
+type ProjectListQuery struct {
+	ListQuery
+	Sort       string `form:"sort" validate:"omitempty,sort_fields=name start_date end_date created_at"`
+	Technology string `form:"technology" validate:"omitempty,max=100"`
+	From       string `form:"from" validate:"omitempty,date_format"`
+	To         string `form:"to" validate:"omitempty,date_format"`
+}
+
+// ToFilter converts ProjectListQuery to a repository.ProjectFilter
+func (q *ProjectListQuery) ToFilter() (repository.ProjectFilter, error) {
+	from, to, err := parseDateRange(q.From, q.To)
+	if err != nil {
+		return repository.ProjectFilter{}, err
+	}
+
+	return repository.ProjectFilter{
+		Technology: q.Technology,
+		StartFrom:  from,
+		StartTo:    to,
+	}, nil
+}
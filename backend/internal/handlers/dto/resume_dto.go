@@ -0,0 +1,11 @@
+package dto
+
+// ResumeImportQuery represents the query parameters of the résumé import endpoint
+type ResumeImportQuery struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// ResumePDFQuery represents the query parameters of the PDF résumé endpoint
+type ResumePDFQuery struct {
+	Template string `form:"template" validate:"omitempty,oneof=compact detailed"`
+}
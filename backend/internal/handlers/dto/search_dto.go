@@ -0,0 +1,46 @@
+package dto
+
+import "github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+
+// SearchQuery represents the query parameters of the search endpoint
+type SearchQuery struct {
+	Q     string `form:"q" validate:"required,min=2,max=200"`
+	Type  string `form:"type" validate:"omitempty,oneof=project experience client certification"`
+	Limit int    `form:"limit" validate:"omitempty,min=1,max=50"`
+}
+
+// SearchHitResponse represents a single search result
+type SearchHitResponse struct {
+	Type     string  `json:"type"`
+	ID       uint    `json:"id"`
+	ParentID *uint   `json:"parentId,omitempty"`
+	Title    string  `json:"title"`
+	Subtitle string  `json:"subtitle,omitempty"`
+	Snippet  string  `json:"snippet"`
+	Score    float64 `json:"score"`
+}
+
+// Kinds returns the hit kinds requested by the query, or nil for all of them
+func (q *SearchQuery) Kinds() []string {
+	if q.Type == "" {
+		return nil
+	}
+	return []string{q.Type}
+}
+
+// ToSearchHitResponseList converts search hits to their API representation
+func ToSearchHitResponseList(hits []models.SearchHit) []SearchHitResponse {
+	responses := make([]SearchHitResponse, len(hits))
+	for i, hit := range hits {
+		responses[i] = SearchHitResponse{
+			Type:     hit.Kind,
+			ID:       hit.RefID,
+			ParentID: hit.ParentID,
+			Title:    hit.Title,
+			Subtitle: hit.Subtitle,
+			Snippet:  hit.Snippet,
+			Score:    hit.Score,
+		}
+	}
+	return responses
+}
@@ -0,0 +1,155 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+)
+
+// SkillResponse represents the API response for a skill
+type SkillResponse struct {
+	ID        uint               `json:"id"`
+	Name      string             `json:"name"`
+	Category  string             `json:"category,omitempty"`
+	Level     string             `json:"level,omitempty"`
+	Keywords  []string           `json:"keywords"`
+	Projects  []SkillProjectLink `json:"projects"`
+	Clients   []SkillClientLink  `json:"clients"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+// SkillProjectLink is a project that uses a skill
+type SkillProjectLink struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// SkillClientLink is an experience client where a skill was used
+type SkillClientLink struct {
+	ID           uint   `json:"id"`
+	ExperienceID uint   `json:"experienceId"`
+	Name         string `json:"name"`
+}
+
+// SkillRequest represents the API request for creating a skill
+type SkillRequest struct {
+	Name       string   `json:"name" binding:"required" validate:"required,min=1,max=100"`
+	Category   string   `json:"category" validate:"omitempty,max=100"`
+	Level      string   `json:"level,omitempty" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	Keywords   []string `json:"keywords" validate:"omitempty,dive,min=1,max=100"`
+	ProjectIDs []uint   `json:"project_ids" validate:"omitempty,unique,dive,min=1"`
+	ClientIDs  []uint   `json:"client_ids" validate:"omitempty,unique,dive,min=1"`
+}
+
+// UpdateSkillRequest represents the API request for updating a skill (all fields optional).
+// project_ids and client_ids replace the current links when present; an empty array removes them.
+type UpdateSkillRequest struct {
+	Name       *string  `json:"name,omitempty" validate:"omitempty,min=1,max=100"`
+	Category   *string  `json:"category,omitempty" validate:"omitempty,max=100"`
+	Level      *string  `json:"level,omitempty" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	Keywords   []string `json:"keywords,omitempty" validate:"omitempty,dive,min=1,max=100"`
+	ProjectIDs *[]uint  `json:"project_ids,omitempty" validate:"omitempty,unique,dive,min=1"`
+	ClientIDs  *[]uint  `json:"client_ids,omitempty" validate:"omitempty,unique,dive,min=1"`
+}
+
+// ToSkillResponse converts a models.Skill to SkillResponse
+func ToSkillResponse(skill *models.Skill) SkillResponse {
+	projects := make([]SkillProjectLink, len(skill.Projects))
+	for i, project := range skill.Projects {
+		projects[i] = SkillProjectLink{ID: project.ID, Name: project.Name}
+	}
+
+	clients := make([]SkillClientLink, len(skill.ExperienceClients))
+	for i, client := range skill.ExperienceClients {
+		clients[i] = SkillClientLink{ID: client.ID, ExperienceID: client.ExperienceID, Name: client.Name}
+	}
+
+	return SkillResponse{
+		ID:        skill.ID,
+		Name:      skill.Name,
+		Category:  skill.Category,
+		Level:     string(skill.Level),
+		Keywords:  []string(skill.Keywords),
+		Projects:  projects,
+		Clients:   clients,
+		CreatedAt: skill.CreatedAt,
+		UpdatedAt: skill.UpdatedAt,
+	}
+}
+
+// ToSkillResponseList converts a slice of models.Skill to SkillResponse
+func ToSkillResponseList(skills []models.Skill) []SkillResponse {
+	responses := make([]SkillResponse, len(skills))
+	for i, skill := range skills {
+		responses[i] = ToSkillResponse(&skill)
+	}
+	return responses
+}
+
+// ToSkill converts SkillRequest to models.Skill and the links to create with it
+func (req *SkillRequest) ToSkill() (*models.Skill, repository.SkillLinks) {
+	skill := &models.Skill{
+		Name:     req.Name,
+		Category: req.Category,
+		Level:    models.SkillLevel(req.Level),
+		Keywords: models.JSONStrings(req.Keywords),
+	}
+
+	return skill, repository.SkillLinks{
+		ProjectIDs: req.ProjectIDs,
+		ClientIDs:  req.ClientIDs,
+	}
+}
+
+// ToUpdateMap converts UpdateSkillRequest to a map for partial updates and the links to replace
+func (req *UpdateSkillRequest) ToUpdateMap() (map[string]interface{}, repository.SkillLinks) {
+	updates := make(map[string]interface{})
+
+	if req.Name != nil {
+		updates["name"] = *req.Name
+	}
+
+	if req.Category != nil {
+		updates["category"] = *req.Category
+	}
+
+	if req.Level != nil {
+		updates["level"] = models.SkillLevel(*req.Level)
+	}
+
+	if req.Keywords != nil {
+		updates["keywords"] = models.JSONStrings(req.Keywords)
+	}
+
+	var links repository.SkillLinks
+	if req.ProjectIDs != nil {
+		links.ProjectIDs = append([]uint{}, *req.ProjectIDs...)
+	}
+	if req.ClientIDs != nil {
+		links.ClientIDs = append([]uint{}, *req.ClientIDs...)
+	}
+
+	return updates, links
+}
+
+// SkillListQuery represents the query parameters accepted by the skill listing
+type SkillListQuery struct {
+	ListQuery
+	Sort      string `form:"sort" validate:"omitempty,sort_fields=name category level created_at"`
+	Category  string `form:"category" validate:"omitempty,max=100"`
+	Level     string `form:"level" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	ProjectID uint   `form:"project_id" validate:"omitempty,min=1"`
+	ClientID  uint   `form:"client_id" validate:"omitempty,min=1"`
+}
+
+// ToFilter converts SkillListQuery to a repository.SkillFilter
+func (q *SkillListQuery) ToFilter() repository.SkillFilter {
+	return repository.SkillFilter{
+		Category:  q.Category,
+		Level:     q.Level,
+		ProjectID: q.ProjectID,
+		ClientID:  q.ClientID,
+	}
+}
@@ -0,0 +1,6 @@
+package dto
+
+// TrashQuery represents the query parameters of the trash listing
+type TrashQuery struct {
+	Type string `form:"type" validate:"omitempty,oneof=experience experience_client project certification"`
+}
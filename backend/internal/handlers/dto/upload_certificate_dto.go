@@ -1,17 +1,259 @@
 package dto
 
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+)
+
 // UploadCertificatesRequest represents the query parameters for file upload
 type UploadCertificatesRequest struct {
-	Workers int `form:"workers" validate:"omitempty,min=0,max=20"`
+	Workers int  `form:"workers" validate:"omitempty,min=0,max=20"`
+	Atomic  bool `form:"atomic"`
 }
 
 // CertificationMetadata represents optional metadata for each certification file
 type CertificationMetadata struct {
-	Title         string `form:"title" validate:"omitempty,max=255"`
-	Issuer        string `form:"issuer" validate:"omitempty,max=255"`
-	IssueDate     string `form:"issue_date" validate:"omitempty,date_format"`
-	ExpiryDate    string `form:"expiry_date" validate:"omitempty,date_format"`
-	CredentialID  string `form:"credential_id" validate:"omitempty,max=255"`
-	CredentialURL string `form:"credential_url" validate:"omitempty,url,max=500"`
-	Description   string `form:"description" validate:"omitempty"`
+	Title         string `form:"title" json:"title" validate:"omitempty,max=255"`
+	Issuer        string `form:"issuer" json:"issuer" validate:"omitempty,max=255"`
+	IssueDate     string `form:"issue_date" json:"issue_date" validate:"omitempty,date_format"`
+	ExpiryDate    string `form:"expiry_date" json:"expiry_date" validate:"omitempty,date_format"`
+	CredentialID  string `form:"credential_id" json:"credential_id" validate:"omitempty,max=255"`
+	CredentialURL string `form:"credential_url" json:"credential_url" validate:"omitempty,url,max=500"`
+	Description   string `form:"description" json:"description" validate:"omitempty"`
+}
+
+// CertificationFileMetadata is the metadata of one file of a batch upload. It targets the file with the given
+// original name, or the file at the given index, or else the file at the same position as the entry.
+type CertificationFileMetadata struct {
+	File  string `json:"file,omitempty" validate:"omitempty,max=255"`
+	Index *int   `json:"index,omitempty" validate:"omitempty,min=0"`
+	CertificationMetadata
+}
+
+// metadataFieldPattern matches the metadata[<index>].<field> form fields
+var metadataFieldPattern = regexp.MustCompile(`^metadata\[(\d+)\]\.(\w+)$`)
+
+// ParseCertificationFileMetadata reads the per-file metadata of a multipart upload, given either as a JSON array in
+// the metadata field or as metadata[<index>].<field> form fields. Unknown fields are rejected.
+func ParseCertificationFileMetadata(values map[string][]string) ([]CertificationFileMetadata, error) {
+	var entries []CertificationFileMetadata
+
+	if raw := values["metadata"]; len(raw) > 0 && raw[0] != "" {
+		decoder := json.NewDecoder(bytes.NewReader([]byte(raw[0])))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&entries); err != nil {
+			return nil, fmt.Errorf("metadata must be a JSON array of objects: %w", err)
+		}
+	}
+
+	fields := make(map[int]map[string]string)
+	for key, value := range values {
+		match := metadataFieldPattern.FindStringSubmatch(key)
+		if match == nil || len(value) == 0 {
+			continue
+		}
+		index, err := strconv.Atoi(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid metadata index in %s", key)
+		}
+		if fields[index] == nil {
+			fields[index] = make(map[string]string)
+		}
+		fields[index][match[2]] = value[0]
+	}
+
+	indexes := make([]int, 0, len(fields))
+	for index := range fields {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+
+	for _, index := range indexes {
+		if _, ok := fields[index]["index"]; ok {
+			return nil, fmt.Errorf("metadata[%d].index is not allowed, the index is part of the field name", index)
+		}
+
+		// Reuse the JSON decoding so both forms accept exactly the same fields
+		raw, err := json.Marshal(fields[index])
+		if err != nil {
+			return nil, err
+		}
+		decoder := json.NewDecoder(bytes.NewReader(raw))
+		decoder.DisallowUnknownFields()
+
+		var entry CertificationFileMetadata
+		if err := decoder.Decode(&entry); err != nil {
+			return nil, fmt.Errorf("invalid metadata[%d]: %w", index, err)
+		}
+		entry.Index = &index
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// ResolveCertificationMetadata returns the metadata of every uploaded file, in the order of fileNames.
+// Each file starts from the shared metadata and the non-empty fields of its entry override it.
+func ResolveCertificationMetadata(shared CertificationMetadata, entries []CertificationFileMetadata, fileNames []string) ([]CertificationMetadata, error) {
+	matched := make([]bool, len(fileNames))
+	resolved := make([]CertificationMetadata, len(fileNames))
+	for i := range resolved {
+		resolved[i] = shared
+	}
+
+	for position, entry := range entries {
+		target := position
+		switch {
+		case entry.File != "":
+			target = -1
+			for i, name := range fileNames {
+				if name != entry.File {
+					continue
+				}
+				if target != -1 {
+					return nil, fmt.Errorf("metadata for %q matches several files, use index instead", entry.File)
+				}
+				target = i
+			}
+			if target == -1 {
+				return nil, fmt.Errorf("metadata refers to %q, which was not uploaded", entry.File)
+			}
+		case entry.Index != nil:
+			target = *entry.Index
+		}
+
+		if target >= len(fileNames) {
+			return nil, fmt.Errorf("metadata refers to file %d, but only %d files were uploaded", target, len(fileNames))
+		}
+		if matched[target] {
+			return nil, fmt.Errorf("more than one metadata entry for %s", fileNames[target])
+		}
+		matched[target] = true
+
+		resolved[target] = mergeMetadata(shared, entry.CertificationMetadata)
+	}
+
+	return resolved, nil
+}
+
+// mergeMetadata overrides the shared metadata with the non-empty fields of a file's own metadata
+func mergeMetadata(shared, own CertificationMetadata) CertificationMetadata {
+	merged := shared
+	for _, field := range []struct {
+		dst *string
+		src string
+	}{
+		{&merged.Title, own.Title},
+		{&merged.Issuer, own.Issuer},
+		{&merged.IssueDate, own.IssueDate},
+		{&merged.ExpiryDate, own.ExpiryDate},
+		{&merged.CredentialID, own.CredentialID},
+		{&merged.CredentialURL, own.CredentialURL},
+		{&merged.Description, own.Description},
+	} {
+		if field.src != "" {
+			*field.dst = field.src
+		}
+	}
+	return merged
+}
+
+// UpdateCertificationRequest represents the request body for editing the metadata of a certification.
+// Empty expiry_date, credential_id and credential_url clear the field.
+type UpdateCertificationRequest struct {
+	Title         *string `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
+	Issuer        *string `json:"issuer,omitempty" validate:"omitempty,min=1,max=255"`
+	IssueDate     *string `json:"issue_date,omitempty" validate:"omitempty,date_format"`
+	ExpiryDate    *string `json:"expiry_date,omitempty" validate:"omitempty,date_format"`
+	CredentialID  *string `json:"credential_id,omitempty" validate:"omitempty,max=255"`
+	CredentialURL *string `json:"credential_url,omitempty" validate:"omitempty,max=500,eq=|url"`
+	Description   *string `json:"description,omitempty"`
+}
+
+// ToUpdateMap converts UpdateCertificationRequest to a map for partial updates
+func (req *UpdateCertificationRequest) ToUpdateMap() (map[string]interface{}, error) {
+	updates := make(map[string]interface{})
+
+	if req.Title != nil {
+		updates["title"] = *req.Title
+	}
+
+	if req.Issuer != nil {
+		updates["issuer"] = *req.Issuer
+	}
+
+	if req.IssueDate != nil {
+		issueDate, err := utils.ParseDate(*req.IssueDate)
+		if err != nil {
+			return nil, err
+		}
+		updates["issue_date"] = issueDate
+	}
+
+	if req.ExpiryDate != nil {
+		if *req.ExpiryDate == "" {
+			// Allow clearing the expiry date
+			updates["expiry_date"] = nil
+		} else {
+			expiryDate, err := utils.ParseDateToPtr(*req.ExpiryDate)
+			if err != nil {
+				return nil, err
+			}
+			updates["expiry_date"] = expiryDate
+		}
+	}
+
+	if req.CredentialID != nil {
+		updates["credential_id"] = emptyToNil(*req.CredentialID)
+	}
+
+	if req.CredentialURL != nil {
+		updates["credential_url"] = emptyToNil(*req.CredentialURL)
+	}
+
+	if req.Description != nil {
+		updates["description"] = *req.Description
+	}
+
+	return updates, nil
+}
+
+// emptyToNil maps an empty string to nil so nullable columns can be cleared
+func emptyToNil(value string) interface{} {
+	if value == "" {
+		return nil
+	}
+	return value
+}
+
+// CertificationListQuery represents the query parameters accepted by the certification listing
+type CertificationListQuery struct {
+	ListQuery
+	Sort   string `form:"sort" validate:"omitempty,sort_fields=title issuer issue_date expiry_date created_at"`
+	Issuer string `form:"issuer" validate:"omitempty,max=255"`
+	From   string `form:"from" validate:"omitempty,date_format"`
+	To     string `form:"to" validate:"omitempty,date_format"`
+	Status string `form:"status" validate:"omitempty,oneof=valid expiring expired"`
+}
+
+// ToFilter converts CertificationListQuery to a repository.CareerCertificationFilter
+func (q *CertificationListQuery) ToFilter() (repository.CareerCertificationFilter, error) {
+	from, to, err := parseDateRange(q.From, q.To)
+	if err != nil {
+		return repository.CareerCertificationFilter{}, err
+	}
+
+	return repository.CareerCertificationFilter{
+		Issuer:     q.Issuer,
+		IssuedFrom: from,
+		IssuedTo:   to,
+		Status:     q.Status,
+	}, nil
 }
@@ -0,0 +1,204 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// EducationHandler handles HTTP requests for education entries
+type EducationHandler struct {
+	service services.EducationService
+}
+
+// NewEducationHandler creates a new instance of EducationHandler
+func NewEducationHandler(service services.EducationService) *EducationHandler {
+	return &EducationHandler{service: service}
+}
+
+// GetAllEducation godoc
+// @Summary Get all education entries
+// @Description Retrieves education entries ordered by start date, with optional pagination, sorting and filtering
+// @Tags education
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. start_date:desc (fields: institution, study_type, start_date, end_date, created_at)"
+// @Param study_type query string false "Study type, e.g. Bootcamp"
+// @Param from query string false "Only entries started on or after this date"
+// @Param to query string false "Only entries started on or before this date"
+// @Success 200 {object} utils.SuccessResponse{data=[]dto.EducationResponse} "List of education entries"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /education [get]
+func (h *EducationHandler) GetAllEducation(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.EducationListQuery)
+
+	filter, err := query.ToFilter()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid date filter", err)
+		return
+	}
+
+	opts := query.ToListOptions(query.Sort)
+	education, total, err := h.service.GetAllEducation(filter, opts)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve education", err)
+		return
+	}
+
+	response := dto.ToEducationResponseList(education)
+	utils.RespondWithPage(c, response, utils.NewPagination(c, opts.Page, opts.Limit, total))
+}
+
+// GetEducationByID godoc
+// @Summary Get education entry by ID
+// @Description Retrieves a single education entry by its ID
+// @Tags education
+// @Accept json
+// @Produce json
+// @Param id path int true "Education ID"
+// @Success 200 {object} utils.SuccessResponse{data=dto.EducationResponse} "Education details"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Education not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /education/{id} [get]
+func (h *EducationHandler) GetEducationByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid education ID", err)
+		return
+	}
+
+	education, err := h.service.GetEducationByID(uint(id))
+	if err != nil {
+		if errors.Is(err, constants.ErrEducationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Education not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve education", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToEducationResponse(education), "")
+}
+
+// CreateEducation godoc
+// @Summary Create a new education entry
+// @Description Creates a new education entry
+// @Tags education
+// @Accept json
+// @Produce json
+// @Param education body dto.EducationRequest true "Education data"
+// @Success 201 {object} utils.SuccessResponse{data=dto.EducationResponse} "Education created successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or validation error"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /education [post]
+func (h *EducationHandler) CreateEducation(c *gin.Context) {
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	educationReq := req.(dto.EducationRequest)
+	education, err := educationReq.ToEducation()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid date format", err)
+		return
+	}
+
+	if err := h.service.CreateEducation(education); err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create education", err)
+		return
+	}
+
+	response := dto.ToEducationResponse(education)
+	utils.RespondWithSuccess(c, http.StatusCreated, response, "Education created successfully")
+}
+
+// UpdateEducation godoc
+// @Summary Update an education entry
+// @Description Updates an existing education entry (partial update supported - send only fields to update)
+// @Tags education
+// @Accept json
+// @Produce json
+// @Param id path int true "Education ID"
+// @Param education body dto.UpdateEducationRequest true "Fields to update"
+// @Success 200 {object} utils.SuccessResponse "Education updated successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or request body"
+// @Failure 404 {object} utils.ErrorResponse "Education not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /education/{id} [patch]
+func (h *EducationHandler) UpdateEducation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid education ID", err)
+		return
+	}
+
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	updateReq := req.(dto.UpdateEducationRequest)
+
+	updates, err := updateReq.ToUpdateMap()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid update data", err)
+		return
+	}
+
+	if err := h.service.UpdateEducation(uint(id), updates); err != nil {
+		if errors.Is(err, constants.ErrEducationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Education not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update education", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Education updated successfully")
+}
+
+// DeleteEducation godoc
+// @Summary Delete an education entry
+// @Description Soft deletes an education entry (sets deleted_at timestamp)
+// @Tags education
+// @Accept json
+// @Produce json
+// @Param id path int true "Education ID"
+// @Success 200 {object} utils.SuccessResponse "Education deleted successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Education not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /education/{id} [delete]
+func (h *EducationHandler) DeleteEducation(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid education ID", err)
+		return
+	}
+
+	if err := h.service.DeleteEducation(uint(id)); err != nil {
+		if errors.Is(err, constants.ErrEducationNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Education not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete education", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Education deleted successfully")
+}
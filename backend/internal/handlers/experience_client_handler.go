@@ -13,10 +13,11 @@ import (
 
 type ExperienceClientHandler struct {
 	service services.ExperienceClientService
+	links   services.LinkHealthService
 }
 
-func NewExperienceClientHandler(service services.ExperienceClientService) *ExperienceClientHandler {
-	return &ExperienceClientHandler{service: service}
+func NewExperienceClientHandler(service services.ExperienceClientService, links services.LinkHealthService) *ExperienceClientHandler {
+	return &ExperienceClientHandler{service: service, links: links}
 }
 
 func (h *ExperienceClientHandler) GetClientsByExperienceID(c *gin.Context) {
@@ -32,6 +33,11 @@ func (h *ExperienceClientHandler) GetClientsByExperienceID(c *gin.Context) {
 		return
 	}
 
+	broken := publicLinks(c, h.links)
+	for i := range clients {
+		broken.RedactExperienceClient(&clients[i])
+	}
+
 	utils.RespondWithSuccess(c, http.StatusOK, dto.ToExperienceClientResponseList(clients), "")
 }
 
@@ -52,6 +58,7 @@ func (h *ExperienceClientHandler) GetClientByID(c *gin.Context) {
 		return
 	}
 
+	publicLinks(c, h.links).RedactExperienceClient(client)
 	utils.RespondWithSuccess(c, http.StatusOK, dto.ToExperienceClientResponse(client), "")
 }
 
@@ -81,6 +88,10 @@ func (h *ExperienceClientHandler) CreateClient(c *gin.Context) {
 	}
 
 	if err := h.service.CreateClient(uint(experienceID), client); err != nil {
+		if err == constants.ErrExperienceNotFound {
+			utils.RespondWithError(c, http.StatusNotFound, "Experience not found", err)
+			return
+		}
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create client", err)
 		return
 	}
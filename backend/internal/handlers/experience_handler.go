@@ -16,31 +16,55 @@ import (
 type ExperienceHandler struct {
 	service       services.ExperienceService
 	clientService services.ExperienceClientService
+	links         services.LinkHealthService
 }
 
 // NewExperienceHandler creates a new instance of ExperienceHandler
-func NewExperienceHandler(service services.ExperienceService, clientService services.ExperienceClientService) *ExperienceHandler {
-	return &ExperienceHandler{service: service, clientService: clientService}
+func NewExperienceHandler(service services.ExperienceService, clientService services.ExperienceClientService, links services.LinkHealthService) *ExperienceHandler {
+	return &ExperienceHandler{service: service, clientService: clientService, links: links}
 }
 
 // GetAllExperiences godoc
 // @Summary Get all experiences
-// @Description Retrieves all work experiences ordered by start date
+// @Description Retrieves work experiences ordered by start date, with optional pagination, sorting and filtering
 // @Tags experiences
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. start_date:desc (fields: title, company, start_date, end_date, created_at)"
+// @Param type query string false "Work type" Enums(Remote, On Site, Hybrid)
+// @Param company query string false "Company name contains"
+// @Param current query bool false "Only current (true) or past (false) experiences"
+// @Param from query string false "Only experiences started on or after this date"
+// @Param to query string false "Only experiences started on or before this date"
 // @Success 200 {object} utils.SuccessResponse{data=[]dto.ExperienceResponse} "List of experiences"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /experiences [get]
 func (h *ExperienceHandler) GetAllExperiences(c *gin.Context) {
-	experiences, err := h.service.GetAllExperiences()
+	query := c.MustGet("validatedQuery").(dto.ExperienceListQuery)
+
+	filter, err := query.ToFilter()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid date filter", err)
+		return
+	}
+
+	opts := query.ToListOptions(query.Sort)
+	experiences, total, err := h.service.GetAllExperiences(filter, opts)
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve experiences", err)
 		return
 	}
 
+	broken := publicLinks(c, h.links)
+	for i := range experiences {
+		broken.RedactExperience(&experiences[i])
+	}
+
 	response := dto.ToExperienceResponseList(experiences)
-	utils.RespondWithSuccess(c, http.StatusOK, response, "")
+	utils.RespondWithPage(c, response, utils.NewPagination(c, opts.Page, opts.Limit, total))
 }
 
 // GetExperienceByID godoc
@@ -78,6 +102,12 @@ func (h *ExperienceHandler) GetExperienceByID(c *gin.Context) {
 		return
 	}
 
+	broken := publicLinks(c, h.links)
+	broken.RedactExperience(experience)
+	for i := range clients {
+		broken.RedactExperienceClient(&clients[i])
+	}
+
 	response := dto.ToExperienceDetailResponse(experience, clients)
 	utils.RespondWithSuccess(c, http.StatusOK, response, "")
 }
@@ -166,7 +196,7 @@ func (h *ExperienceHandler) UpdateExperience(c *gin.Context) {
 
 // DeleteExperience godoc
 // @Summary Delete an experience
-// @Description Soft deletes a work experience (sets deleted_at timestamp)
+// @Description Moves a work experience and its clients to the trash (see /admin/trash); restoring the experience restores them too
 // @Tags experiences
 // @Accept json
 // @Produce json
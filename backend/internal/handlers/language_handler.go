@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// LanguageHandler handles HTTP requests for spoken languages
+type LanguageHandler struct {
+	service services.LanguageService
+}
+
+// NewLanguageHandler creates a new instance of LanguageHandler
+func NewLanguageHandler(service services.LanguageService) *LanguageHandler {
+	return &LanguageHandler{service: service}
+}
+
+// GetAllLanguages godoc
+// @Summary Get all languages
+// @Description Retrieves the spoken languages in creation order, with optional pagination and sorting
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. name:asc (fields: name, fluency, created_at)"
+// @Success 200 {object} utils.SuccessResponse{data=[]dto.LanguageResponse} "List of languages"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /languages [get]
+func (h *LanguageHandler) GetAllLanguages(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.LanguageListQuery)
+
+	opts := query.ToListOptions(query.Sort)
+	languages, total, err := h.service.GetAllLanguages(opts)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve languages", err)
+		return
+	}
+
+	response := dto.ToLanguageResponseList(languages)
+	utils.RespondWithPage(c, response, utils.NewPagination(c, opts.Page, opts.Limit, total))
+}
+
+// GetLanguageByID godoc
+// @Summary Get language by ID
+// @Description Retrieves a single spoken language by its ID
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param id path int true "Language ID"
+// @Success 200 {object} utils.SuccessResponse{data=dto.LanguageResponse} "Language details"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Language not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /languages/{id} [get]
+func (h *LanguageHandler) GetLanguageByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid language ID", err)
+		return
+	}
+
+	language, err := h.service.GetLanguageByID(uint(id))
+	if err != nil {
+		if errors.Is(err, constants.ErrLanguageNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Language not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve language", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToLanguageResponse(language), "")
+}
+
+// CreateLanguage godoc
+// @Summary Create a new language
+// @Description Creates a new spoken language, names must be unique
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param language body dto.LanguageRequest true "Language data"
+// @Success 201 {object} utils.SuccessResponse{data=dto.LanguageResponse} "Language created successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or validation error"
+// @Failure 409 {object} utils.ErrorResponse "Language already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /languages [post]
+func (h *LanguageHandler) CreateLanguage(c *gin.Context) {
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	languageReq := req.(dto.LanguageRequest)
+	language := languageReq.ToLanguage()
+
+	if err := h.service.CreateLanguage(language); err != nil {
+		if errors.Is(err, constants.ErrLanguageAlreadyExists) {
+			utils.RespondWithError(c, http.StatusConflict, "Language already exists", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to create language", err)
+		return
+	}
+
+	response := dto.ToLanguageResponse(language)
+	utils.RespondWithSuccess(c, http.StatusCreated, response, "Language created successfully")
+}
+
+// UpdateLanguage godoc
+// @Summary Update a language
+// @Description Updates an existing spoken language (partial update supported - send only fields to update)
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param id path int true "Language ID"
+// @Param language body dto.UpdateLanguageRequest true "Fields to update"
+// @Success 200 {object} utils.SuccessResponse "Language updated successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID or request body"
+// @Failure 404 {object} utils.ErrorResponse "Language not found"
+// @Failure 409 {object} utils.ErrorResponse "Language already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /languages/{id} [patch]
+func (h *LanguageHandler) UpdateLanguage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid language ID", err)
+		return
+	}
+
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	updateReq := req.(dto.UpdateLanguageRequest)
+
+	if err := h.service.UpdateLanguage(uint(id), updateReq.ToUpdateMap()); err != nil {
+		switch {
+		case errors.Is(err, constants.ErrLanguageNotFound):
+			utils.RespondWithError(c, http.StatusNotFound, "Language not found", err)
+		case errors.Is(err, constants.ErrLanguageAlreadyExists):
+			utils.RespondWithError(c, http.StatusConflict, "Language already exists", err)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update language", err)
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Language updated successfully")
+}
+
+// DeleteLanguage godoc
+// @Summary Delete a language
+// @Description Soft deletes a spoken language (sets deleted_at timestamp)
+// @Tags languages
+// @Accept json
+// @Produce json
+// @Param id path int true "Language ID"
+// @Success 200 {object} utils.SuccessResponse "Language deleted successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Language not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /languages/{id} [delete]
+func (h *LanguageHandler) DeleteLanguage(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid language ID", err)
+		return
+	}
+
+	if err := h.service.DeleteLanguage(uint(id)); err != nil {
+		if errors.Is(err, constants.ErrLanguageNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Language not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete language", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Language deleted successfully")
+}
@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/middleware"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// LinkHealthHandler handles HTTP requests for the link health report
+type LinkHealthHandler struct {
+	service services.LinkHealthService
+}
+
+// NewLinkHealthHandler creates a new instance of LinkHealthHandler
+func NewLinkHealthHandler(service services.LinkHealthService) *LinkHealthHandler {
+	return &LinkHealthHandler{service: service}
+}
+
+// GetLinkHealth godoc
+// @Summary Get the link health report
+// @Description Reports the last check of every credential, experience, experience client and project URL, broken
+// @Description links first. Unverified links belong to sites that refuse automated requests, pending links were not
+// @Description checked since they were set. Hidden links are left out of the public responses.
+// @Tags admin
+// @Produce json
+// @Param status query string false "Only links with this status" Enums(ok, broken, unverified, pending)
+// @Success 200 {object} utils.SuccessResponse{data=models.LinkHealthReport} "Link health report"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} utils.ErrorResponse "Authentication required"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /admin/link-health [get]
+func (h *LinkHealthHandler) GetLinkHealth(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.LinkHealthQuery)
+
+	report, err := h.service.Report(query.Status)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve link health", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, report, "")
+}
+
+// publicLinks returns the broken links to leave out of the response, none when the admin is signed in
+func publicLinks(c *gin.Context, links services.LinkHealthService) services.BrokenLinks {
+	if _, signedIn := c.Get(middleware.UserContextKey); signedIn {
+		return nil
+	}
+	return links.BrokenLinks()
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxAvatarSize is the largest profile picture accepted, in bytes
+const maxAvatarSize = 5 << 20
+
+// ProfileHandler handles HTTP requests for the profile ("basics")
+type ProfileHandler struct {
+	service services.ProfileService
+}
+
+// NewProfileHandler creates a new instance of ProfileHandler
+func NewProfileHandler(service services.ProfileService) *ProfileHandler {
+	return &ProfileHandler{service: service}
+}
+
+// GetProfile godoc
+// @Summary Get the profile
+// @Description Retrieves the name, label, contact details, location, avatar and social profiles of the portfolio owner
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Success 200 {object} utils.SuccessResponse{data=dto.ProfileResponse} "Profile details"
+// @Failure 404 {object} utils.ErrorResponse "Profile not created yet"
+// @Failure 413 {object} utils.ErrorResponse "Request body too large"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /profile [get]
+func (h *ProfileHandler) GetProfile(c *gin.Context) {
+	profile, err := h.service.GetProfile()
+	if err != nil {
+		if errors.Is(err, constants.ErrProfileNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Profile not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve profile", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToProfileResponse(profile), "")
+}
+
+// UpdateProfile godoc
+// @Summary Create or replace the profile
+// @Description Creates the profile on first use and replaces it afterwards. Omitted fields are cleared and the social profiles are replaced by the given list; the avatar is kept.
+// @Tags profile
+// @Accept json
+// @Produce json
+// @Param profile body dto.ProfileRequest true "Profile data"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ProfileResponse} "Profile saved successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body or validation error"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /profile [put]
+func (h *ProfileHandler) UpdateProfile(c *gin.Context) {
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	profileReq := req.(dto.ProfileRequest)
+
+	profile, err := h.service.SaveProfile(profileReq.ToProfile())
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to save profile", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToProfileResponse(profile), "Profile saved successfully")
+}
+
+// UploadAvatar godoc
+// @Summary Upload the profile picture
+// @Description Replaces the avatar with the uploaded image (JPG, JPEG, PNG or WEBP, max 5MB). The content must match the extension and the profile must exist.
+// @Tags profile
+// @Accept multipart/form-data
+// @Produce json
+// @Param avatar formData file true "Avatar image"
+// @Success 200 {object} utils.SuccessResponse{data=dto.ProfileResponse} "Avatar updated successfully"
+// @Failure 400 {object} utils.ErrorResponse "Missing, too large or invalid file"
+// @Failure 404 {object} utils.ErrorResponse "Profile not created yet"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /profile/avatar [put]
+func (h *ProfileHandler) UploadAvatar(c *gin.Context) {
+	file, err := c.FormFile("avatar")
+	if err != nil {
+		if tooLarge := new(http.MaxBytesError); errors.As(err, &tooLarge) {
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Avatar must not exceed %dMB", maxAvatarSize>>20), err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusBadRequest, "No avatar uploaded", err)
+		return
+	}
+
+	if file.Size > maxAvatarSize {
+		utils.RespondWithError(c, http.StatusBadRequest, fmt.Sprintf("Avatar must not exceed %dMB", maxAvatarSize>>20), nil)
+		return
+	}
+
+	profile, err := h.service.UpdateAvatar(c.Request.Context(), file)
+	if err != nil {
+		switch {
+		case errors.Is(err, constants.ErrProfileNotFound):
+			utils.RespondWithError(c, http.StatusNotFound, "Profile not found", err)
+		case errors.Is(err, services.ErrInvalidImageType), errors.Is(err, services.ErrContentMismatch):
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+		default:
+			utils.RespondWithError(c, http.StatusInternalServerError, "Failed to update avatar", err)
+		}
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToProfileResponse(profile), "Avatar updated successfully")
+}
@@ -15,32 +15,54 @@ import (
 // ProjectHandler handles HTTP requests for projects
 type ProjectHandler struct {
 	service services.ProjectService
+	links   services.LinkHealthService
 }
 
 // NewProjectHandler creates a new instance of ProjectHandler
-func NewProjectHandler(service services.ProjectService) *ProjectHandler {
-	return &ProjectHandler{service: service}
+func NewProjectHandler(service services.ProjectService, links services.LinkHealthService) *ProjectHandler {
+	return &ProjectHandler{service: service, links: links}
 }
 
 // GetAllProjects godoc
 // @Summary Get all projects
-// @Description Retrieves all projects
+// @Description Retrieves projects with optional pagination, sorting and filtering. Without page/limit the whole list is returned.
 // @Tags projects
 // @Accept json
 // @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. start_date:desc,name:asc (fields: name, start_date, end_date, created_at)"
+// @Param technology query string false "Only projects using this technology"
+// @Param from query string false "Only projects started on or after this date"
+// @Param to query string false "Only projects started on or before this date"
 // @Success 200 {object} utils.SuccessResponse{data=[]dto.ProjectResponse} "List of projects"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
 // @Failure 500 {object} utils.ErrorResponse "Internal server error"
 // @Router /projects [get]
 func (p *ProjectHandler) GetAllProjects(c *gin.Context) {
-	projects, err := p.service.GetAllProjects()
+	query := c.MustGet("validatedQuery").(dto.ProjectListQuery)
+
+	filter, err := query.ToFilter()
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid date filter", err)
+		return
+	}
+
+	opts := query.ToListOptions(query.Sort)
+	projects, total, err := p.service.GetAllProjects(filter, opts)
 
 	if err != nil {
 		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve projects", err)
 		return
 	}
 
+	broken := publicLinks(c, p.links)
+	for i := range projects {
+		broken.RedactProject(&projects[i])
+	}
+
 	response := dto.ToProjectResponseList(projects)
-	utils.RespondWithSuccess(c, http.StatusOK, response, "")
+	utils.RespondWithPage(c, response, utils.NewPagination(c, opts.Page, opts.Limit, total))
 }
 
 // GetProjectById godoc
@@ -73,6 +95,7 @@ func (p *ProjectHandler) GetProjectById(c *gin.Context) {
 		return
 	}
 
+	publicLinks(c, p.links).RedactProject(project)
 	response := dto.ToProjectResponse(project)
 	utils.RespondWithSuccess(c, http.StatusOK, response, "")
 }
@@ -162,7 +185,7 @@ func (p *ProjectHandler) UpdateProject(c *gin.Context) {
 
 // DeleteProject godoc
 // @Summary Delete a project
-// @Description Soft deletes a project (sets deleted_at timestamp)
+// @Description Moves a project to the trash (sets deleted_at timestamp, see /admin/trash)
 // @Tags projects
 // @Accept json
 // @Produce json
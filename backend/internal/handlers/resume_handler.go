@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// maxResumeSize is the largest JSON Resume document accepted by the import endpoint
+const maxResumeSize = 2 << 20
+
+// ResumeHandler handles JSON Resume export and import HTTP requests
+type ResumeHandler struct {
+	service services.ResumeService
+	links   services.LinkHealthService
+}
+
+// NewResumeHandler creates a new instance of ResumeHandler
+func NewResumeHandler(service services.ResumeService, links services.LinkHealthService) *ResumeHandler {
+	return &ResumeHandler{service: service, links: links}
+}
+
+// ExportResume godoc
+// @Summary Export JSON Resume
+// @Description Assembles a JSON Resume (https://jsonresume.org/schema) document from the experiences, clients, projects and certifications. The document is returned as is, without the usual response envelope. Broken links are left out unless the admin is signed in.
+// @Tags resume
+// @Produce json
+// @Success 200 {object} models.Resume "JSON Resume document"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /resume.json [get]
+func (h *ResumeHandler) ExportResume(c *gin.Context) {
+	resume, err := h.service.Export(publicLinks(c, h.links))
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to export resume", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resume)
+}
+
+// ExportResumePDF godoc
+// @Summary Download PDF résumé
+// @Description Renders the experiences (with client achievements and technologies), projects and certifications as a paginated PDF. Rendered files are cached until any of that data changes. Broken links are left out unless the admin is signed in.
+// @Tags resume
+// @Produce application/pdf
+// @Param template query string false "Layout (default detailed)" Enums(compact, detailed)
+// @Success 200 {file} file "PDF résumé"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /resume.pdf [get]
+func (h *ResumeHandler) ExportResumePDF(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.ResumePDFQuery)
+
+	path, err := h.service.RenderPDF(query.Template, publicLinks(c, h.links))
+	if err != nil {
+		if errors.Is(err, services.ErrUnknownResumeTemplate) {
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to generate resume", err)
+		return
+	}
+
+	c.Header("Content-Disposition", `inline; filename="resume.pdf"`)
+	c.Header("Cache-Control", "no-cache")
+	c.File(path)
+}
+
+// ImportResume godoc
+// @Summary Import JSON Resume
+// @Description Upserts experiences (work), projects and certificates from a JSON Resume document, sent either as the "file" field of a multipart form or as the raw JSON body. Entries are matched on company+position+startDate, project name and certificate name+issuer. With dry_run=true the diff is returned without writing anything.
+// @Tags resume
+// @Accept multipart/form-data,json
+// @Produce json
+// @Param file formData file false "JSON Resume file"
+// @Param dry_run query bool false "Only compute the diff"
+// @Success 200 {object} utils.SuccessResponse{data=models.ResumeImportResult} "Import diff"
+// @Failure 400 {object} utils.ErrorResponse "Invalid resume"
+// @Failure 401 {object} utils.ErrorResponse "Unauthorized"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /resume/import [post]
+func (h *ResumeHandler) ImportResume(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.ResumeImportQuery)
+
+	resume, err := readResume(c)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "", err)
+		return
+	}
+
+	result, err := h.service.Import(resume, query.DryRun)
+	if err != nil {
+		if errors.Is(err, services.ErrInvalidResume) {
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to import resume", err)
+		return
+	}
+
+	message := "Resume imported successfully"
+	if query.DryRun {
+		message = "Resume import dry run completed"
+	}
+	utils.RespondWithSuccess(c, http.StatusOK, result, message)
+}
+
+// readResume decodes the JSON Resume document from the uploaded file or the request body
+func readResume(c *gin.Context) (*models.Resume, error) {
+	var reader io.Reader = c.Request.Body
+
+	if strings.HasPrefix(c.ContentType(), "multipart/") {
+		fileHeader, err := c.FormFile("file")
+		if err != nil {
+			return nil, fmt.Errorf("missing resume file: %w", err)
+		}
+		if fileHeader.Size > maxResumeSize {
+			return nil, fmt.Errorf("resume file exceeds %d bytes", maxResumeSize)
+		}
+
+		file, err := fileHeader.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open resume file: %w", err)
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxResumeSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume: %w", err)
+	}
+	if len(data) > maxResumeSize {
+		return nil, fmt.Errorf("resume exceeds %d bytes", maxResumeSize)
+	}
+
+	var resume models.Resume
+	if err := json.Unmarshal(data, &resume); err != nil {
+		return nil, fmt.Errorf("resume is not valid JSON: %w", err)
+	}
+	return &resume, nil
+}
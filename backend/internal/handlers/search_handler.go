@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SearchHandler handles full-text search HTTP requests
+type SearchHandler struct {
+	service services.SearchService
+}
+
+// NewSearchHandler creates a new instance of SearchHandler
+func NewSearchHandler(service services.SearchService) *SearchHandler {
+	return &SearchHandler{service: service}
+}
+
+// Search godoc
+// @Summary Full-text search
+// @Description Searches projects, experiences, experience clients and certifications. Results are ranked by relevance and matched terms are wrapped in <mark> tags in otherwise HTML-escaped text.
+// @Tags search
+// @Produce json
+// @Param q query string true "Search text (the last word is matched as a prefix)"
+// @Param type query string false "Restrict results to one type" Enums(project, experience, client, certification)
+// @Param limit query int false "Maximum number of hits (default 20, max 50)"
+// @Success 200 {object} utils.SuccessResponse{data=[]dto.SearchHitResponse} "Ranked search hits"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.SearchQuery)
+
+	hits, err := h.service.Search(query.Q, query.Kinds(), query.Limit)
+	if err != nil {
+		if errors.Is(err, services.ErrEmptySearchQuery) {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid search query", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Search failed", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToSearchHitResponseList(hits), "")
+}
@@ -0,0 +1,208 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// SkillHandler handles HTTP requests for skills
+type SkillHandler struct {
+	service services.SkillService
+}
+
+// NewSkillHandler creates a new instance of SkillHandler
+func NewSkillHandler(service services.SkillService) *SkillHandler {
+	return &SkillHandler{service: service}
+}
+
+// GetAllSkills godoc
+// @Summary Get all skills
+// @Description Retrieves skills ordered by category and name, with the projects and experience clients they are linked to
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param page query int false "Page number (starts at 1)"
+// @Param limit query int false "Page size (max 100, default 20 when page is set)"
+// @Param sort query string false "Sort expression, e.g. name:asc (fields: name, category, level, created_at)"
+// @Param category query string false "Skill category, e.g. Backend"
+// @Param level query string false "Skill level" Enums(Beginner, Intermediate, Advanced, Expert)
+// @Param project_id query int false "Only skills linked to this project"
+// @Param client_id query int false "Only skills linked to this experience client"
+// @Success 200 {object} utils.SuccessResponse{data=[]dto.SkillResponse} "List of skills"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /skills [get]
+func (h *SkillHandler) GetAllSkills(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.SkillListQuery)
+
+	opts := query.ToListOptions(query.Sort)
+	skills, total, err := h.service.GetAllSkills(query.ToFilter(), opts)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve skills", err)
+		return
+	}
+
+	response := dto.ToSkillResponseList(skills)
+	utils.RespondWithPage(c, response, utils.NewPagination(c, opts.Page, opts.Limit, total))
+}
+
+// GetSkillByID godoc
+// @Summary Get skill by ID
+// @Description Retrieves a single skill by its ID, with its linked projects and experience clients
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Success 200 {object} utils.SuccessResponse{data=dto.SkillResponse} "Skill details"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Skill not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /skills/{id} [get]
+func (h *SkillHandler) GetSkillByID(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	skill, err := h.service.GetSkillByID(uint(id))
+	if err != nil {
+		if errors.Is(err, constants.ErrSkillNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve skill", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, dto.ToSkillResponse(skill), "")
+}
+
+// CreateSkill godoc
+// @Summary Create a new skill
+// @Description Creates a new skill, optionally linked to projects and experience clients. Names must be unique.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param skill body dto.SkillRequest true "Skill data"
+// @Success 201 {object} utils.SuccessResponse{data=dto.SkillResponse} "Skill created successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid request body, validation error or unknown linked record"
+// @Failure 409 {object} utils.ErrorResponse "Skill already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /skills [post]
+func (h *SkillHandler) CreateSkill(c *gin.Context) {
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	skillReq := req.(dto.SkillRequest)
+	skill, links := skillReq.ToSkill()
+
+	if err := h.service.CreateSkill(skill, links); err != nil {
+		h.respondWithWriteError(c, err, "Failed to create skill")
+		return
+	}
+
+	created, err := h.service.GetSkillByID(skill.ID)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve skill", err)
+		return
+	}
+
+	response := dto.ToSkillResponse(created)
+	utils.RespondWithSuccess(c, http.StatusCreated, response, "Skill created successfully")
+}
+
+// UpdateSkill godoc
+// @Summary Update a skill
+// @Description Updates an existing skill (partial update supported). project_ids and client_ids replace the current links when present.
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Param skill body dto.UpdateSkillRequest true "Fields to update"
+// @Success 200 {object} utils.SuccessResponse "Skill updated successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID, request body or unknown linked record"
+// @Failure 404 {object} utils.ErrorResponse "Skill not found"
+// @Failure 409 {object} utils.ErrorResponse "Skill already exists"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /skills/{id} [patch]
+func (h *SkillHandler) UpdateSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	// Get a validated request from context (set by validation middleware)
+	req, exists := c.Get("validatedRequest")
+	if !exists {
+		utils.RespondWithError(c, http.StatusBadRequest, "Validation failed", nil)
+		return
+	}
+
+	updateReq := req.(dto.UpdateSkillRequest)
+	updates, links := updateReq.ToUpdateMap()
+
+	if err := h.service.UpdateSkill(uint(id), updates, links); err != nil {
+		h.respondWithWriteError(c, err, "Failed to update skill")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Skill updated successfully")
+}
+
+// DeleteSkill godoc
+// @Summary Delete a skill
+// @Description Soft deletes a skill (sets deleted_at timestamp)
+// @Tags skills
+// @Accept json
+// @Produce json
+// @Param id path int true "Skill ID"
+// @Success 200 {object} utils.SuccessResponse "Skill deleted successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Skill not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /skills/{id} [delete]
+func (h *SkillHandler) DeleteSkill(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid skill ID", err)
+		return
+	}
+
+	if err := h.service.DeleteSkill(uint(id)); err != nil {
+		if errors.Is(err, constants.ErrSkillNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Skill not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to delete skill", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Skill deleted successfully")
+}
+
+// respondWithWriteError maps the errors of skill creation and updates to HTTP responses
+func (h *SkillHandler) respondWithWriteError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, constants.ErrSkillNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Skill not found", err)
+	case errors.Is(err, constants.ErrSkillAlreadyExists):
+		utils.RespondWithError(c, http.StatusConflict, "Skill already exists", err)
+	case errors.Is(err, constants.ErrSkillLinkNotFound):
+		utils.RespondWithError(c, http.StatusBadRequest, "", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// TrashHandler handles HTTP requests for the deleted records
+type TrashHandler struct {
+	service services.TrashService
+}
+
+// NewTrashHandler creates a new instance of TrashHandler
+func NewTrashHandler(service services.TrashService) *TrashHandler {
+	return &TrashHandler{service: service}
+}
+
+// ListTrash godoc
+// @Summary List the deleted records
+// @Description Lists the deleted experiences, experience clients, projects and certifications, most recently
+// @Description deleted first. Each item tells when it will be purged, unless the retention is disabled.
+// @Tags admin
+// @Produce json
+// @Param type query string false "Only records of this type" Enums(experience, experience_client, project, certification)
+// @Success 200 {object} utils.SuccessResponse{data=[]models.TrashItem} "Deleted records"
+// @Failure 400 {object} utils.ErrorResponse "Invalid query parameters"
+// @Failure 401 {object} utils.ErrorResponse "Authentication required"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /admin/trash [get]
+func (h *TrashHandler) ListTrash(c *gin.Context) {
+	query := c.MustGet("validatedQuery").(dto.TrashQuery)
+
+	items, err := h.service.List(query.Type)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve the trash", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, items, "")
+}
+
+// RestoreTrashItem godoc
+// @Summary Restore a deleted record
+// @Description Brings a deleted record back. A client cannot be restored while its experience is deleted, nor a
+// @Description certification whose file is no longer stored or is stored by another certification.
+// @Tags admin
+// @Produce json
+// @Param type path string true "Record type" Enums(experience, experience_client, project, certification)
+// @Param id path int true "Record ID"
+// @Success 200 {object} utils.SuccessResponse "Item restored successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid type or ID"
+// @Failure 401 {object} utils.ErrorResponse "Authentication required"
+// @Failure 404 {object} utils.ErrorResponse "Item not found in the trash"
+// @Failure 409 {object} utils.ErrorResponse "The item cannot be restored"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /admin/trash/{type}/{id}/restore [post]
+func (h *TrashHandler) RestoreTrashItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+
+	if err := h.service.Restore(c.Request.Context(), c.Param("type"), uint(id)); err != nil {
+		respondWithTrashError(c, err, "Failed to restore item")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Item restored successfully")
+}
+
+// PurgeTrashItem godoc
+// @Summary Permanently delete a deleted record
+// @Description Deletes a record from the trash for good, along with the clients of an experience and the files
+// @Description of a certification
+// @Tags admin
+// @Produce json
+// @Param type path string true "Record type" Enums(experience, experience_client, project, certification)
+// @Param id path int true "Record ID"
+// @Success 200 {object} utils.SuccessResponse "Item purged successfully"
+// @Failure 400 {object} utils.ErrorResponse "Invalid type or ID"
+// @Failure 401 {object} utils.ErrorResponse "Authentication required"
+// @Failure 404 {object} utils.ErrorResponse "Item not found in the trash"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /admin/trash/{type}/{id}/purge [delete]
+func (h *TrashHandler) PurgeTrashItem(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid ID", err)
+		return
+	}
+
+	if err := h.service.Purge(c.Request.Context(), c.Param("type"), uint(id)); err != nil {
+		respondWithTrashError(c, err, "Failed to purge item")
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, nil, "Item purged successfully")
+}
+
+// respondWithTrashError maps the errors of the trash service to their status codes
+func respondWithTrashError(c *gin.Context, err error, message string) {
+	switch {
+	case errors.Is(err, constants.ErrInvalidTrashType):
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid type", err)
+	case errors.Is(err, constants.ErrTrashItemNotFound):
+		utils.RespondWithError(c, http.StatusNotFound, "Item not found in the trash", err)
+	case errors.Is(err, constants.ErrTrashParentDeleted),
+		errors.Is(err, constants.ErrTrashFilesMissing),
+		errors.Is(err, services.ErrDuplicateCertificate):
+		utils.RespondWithError(c, http.StatusConflict, "", err)
+	default:
+		utils.RespondWithError(c, http.StatusInternalServerError, message, err)
+	}
+}
@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// jobEventsHeartbeat is how often a comment is sent on idle event streams so proxies keep them open
+const jobEventsHeartbeat = 15 * time.Second
+
+// UploadJobHandler handles HTTP requests for background upload jobs
+type UploadJobHandler struct {
+	service services.UploadJobService
+}
+
+// NewUploadJobHandler creates a new instance of UploadJobHandler
+func NewUploadJobHandler(service services.UploadJobService) *UploadJobHandler {
+	return &UploadJobHandler{service: service}
+}
+
+// GetJob godoc
+// @Summary Get an upload job
+// @Description Retrieves the status of a certification upload job and the outcome of each of its files
+// @Tags jobs
+// @Produce json
+// @Param id path int true "Job ID"
+// @Success 200 {object} utils.SuccessResponse{data=models.UploadJob} "Upload job"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Upload job not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /jobs/{id} [get]
+func (h *UploadJobHandler) GetJob(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	job, err := h.service.GetByID(uint(id))
+	if err != nil {
+		if errors.Is(err, constants.ErrUploadJobNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Upload job not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve upload job", err)
+		return
+	}
+
+	utils.RespondWithSuccess(c, http.StatusOK, job, "")
+}
+
+// StreamJobEvents godoc
+// @Summary Stream the progress of an upload job
+// @Description Server-Sent Events stream of an upload job. A "job" event with the current job comes first, then a
+// @Description "file" event each time a file is stored or fails, and a final "done" event with the finished job.
+// @Tags jobs
+// @Produce text/event-stream
+// @Param id path int true "Job ID"
+// @Success 200 {string} string "Event stream"
+// @Failure 400 {object} utils.ErrorResponse "Invalid ID format"
+// @Failure 404 {object} utils.ErrorResponse "Upload job not found"
+// @Failure 500 {object} utils.ErrorResponse "Internal server error"
+// @Router /jobs/{id}/events [get]
+func (h *UploadJobHandler) StreamJobEvents(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.RespondWithError(c, http.StatusBadRequest, "Invalid job ID", err)
+		return
+	}
+
+	// Subscribe before reading the job so no update falls in between
+	events, unsubscribe := h.service.Subscribe(uint(id))
+	defer unsubscribe()
+
+	job, err := h.service.GetByID(uint(id))
+	if err != nil {
+		if errors.Is(err, constants.ErrUploadJobNotFound) {
+			utils.RespondWithError(c, http.StatusNotFound, "Upload job not found", err)
+			return
+		}
+		utils.RespondWithError(c, http.StatusInternalServerError, "Failed to retrieve upload job", err)
+		return
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+
+	c.SSEvent("job", job)
+	if job.Finished() {
+		c.SSEvent("done", job)
+		return
+	}
+	c.Writer.Flush()
+
+	heartbeat := time.NewTicker(jobEventsHeartbeat)
+	defer heartbeat.Stop()
+
+	c.Stream(func(w io.Writer) bool {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				// The job is over, send it as stored
+				if finished, err := h.service.GetByID(uint(id)); err == nil {
+					c.SSEvent("done", finished)
+				}
+				return false
+			}
+			c.SSEvent(event.Type, event.Data)
+			return true
+		case <-heartbeat.C:
+			_, _ = fmt.Fprint(w, ": ping\n\n")
+			return true
+		case <-c.Request.Context().Done():
+			return false
+		}
+	})
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -14,27 +15,57 @@ const (
 	SessionContextKey = "session"
 )
 
-// AuthMiddleware creates a middleware that validates session cookies
-// and sets the user in the Gin context
+// AuthMiddleware creates a middleware that validates session cookies, refuses the cross-site write
+// requests, renews the sessions in use and sets the user in the Gin context
 func AuthMiddleware(authService services.AuthService) gin.HandlerFunc {
+	allowedOrigins := constants.GetAllowedOrigins()
+
 	return func(c *gin.Context) {
-		sessionID, err := c.Cookie(constants.GetSessionCookieName())
+		token, err := c.Cookie(constants.GetSessionCookieName())
 		if err != nil {
 			utils.RespondWithError(c, http.StatusUnauthorized, "Authentication required", nil)
 			c.Abort()
 			return
 		}
 
-		session, err := authService.ValidateSession(sessionID)
+		session, err := authService.ValidateSession(token)
 		if err != nil {
 			utils.RespondWithError(c, http.StatusUnauthorized, "Invalid or expired session", err)
 			c.Abort()
 			return
 		}
 
+		if err := checkCSRF(c, token, allowedOrigins); err != nil {
+			utils.RespondWithError(c, http.StatusForbidden, "", err)
+			c.Abort()
+			return
+		}
+
+		// Sliding expiry: the session and its cookie last longer while the admin is active
+		if renewed, err := authService.RenewSession(session); err != nil {
+			logger.Warn("Failed to renew session of user %d: %v", session.UserID, err)
+		} else if renewed {
+			utils.SetSessionCookie(c, token, session.ExpiresAt)
+		}
+
 		c.Set(SessionContextKey, session)
 		c.Set(UserContextKey, &session.User)
 
 		c.Next()
 	}
 }
+
+// OptionalAuthMiddleware sets the user in the Gin context when the request carries a valid session, and lets
+// anonymous requests through, for public routes that show more to the admin
+func OptionalAuthMiddleware(authService services.AuthService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if token, err := c.Cookie(constants.GetSessionCookieName()); err == nil {
+			if session, err := authService.ValidateSession(token); err == nil {
+				c.Set(SessionContextKey, session)
+				c.Set(UserContextKey, &session.User)
+			}
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// MaxBodySize caps the request body at limit bytes. Requests that announce a larger body are rejected upfront,
+// the others fail with *http.MaxBytesError once the handler reads past the limit.
+func MaxBodySize(limit int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.Request.ContentLength > limit {
+			utils.RespondWithError(c, http.StatusRequestEntityTooLarge, fmt.Sprintf("Request body must not exceed %dMB", limit>>20), nil)
+			c.Abort()
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+		c.Next()
+	}
+}
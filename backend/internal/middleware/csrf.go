@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+var (
+	errCrossOriginRequest = errors.New("cross-origin request refused")
+	errInvalidCSRFToken   = errors.New("missing or invalid CSRF token")
+)
+
+// CSRFMiddleware checks the write requests carrying a session cookie like AuthMiddleware does, without requiring
+// the session to be valid, for the routes that act on it either way such as the logout. Requests without a session
+// cookie are left to the handler.
+func CSRFMiddleware() gin.HandlerFunc {
+	allowedOrigins := constants.GetAllowedOrigins()
+
+	return func(c *gin.Context) {
+		if token, err := c.Cookie(constants.GetSessionCookieName()); err == nil {
+			if err := checkCSRF(c, token, allowedOrigins); err != nil {
+				utils.RespondWithError(c, http.StatusForbidden, "", err)
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}
+}
+
+// checkCSRF refuses the write requests of a session that come from another site. The request has to carry the
+// CSRF token of the session in the CSRF header and, when the browser tells where it comes from, come from the API
+// itself or one of the allowed origins. Reads are not checked.
+func checkCSRF(c *gin.Context, sessionToken string, allowedOrigins []string) error {
+	switch c.Request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return nil
+	}
+
+	if !sameOrigin(c.Request, allowedOrigins) {
+		return errCrossOriginRequest
+	}
+
+	if !utils.ValidCSRFToken(sessionToken, c.GetHeader(constants.CSRFHeaderName)) {
+		return errInvalidCSRFToken
+	}
+	return nil
+}
+
+// sameOrigin checks the Origin header, or the Referer header when there is no Origin, against the host of the
+// request and the allowed origins. A request with neither, such as one from the server-side frontend, passes.
+func sameOrigin(r *http.Request, allowedOrigins []string) bool {
+	source := r.Header.Get("Origin")
+	if source == "" {
+		source = r.Referer()
+	}
+	if source == "" {
+		return true
+	}
+
+	// An opaque origin ("null") does not parse to a host and is refused
+	parsed, err := url.Parse(source)
+	if err != nil || parsed.Host == "" {
+		return false
+	}
+
+	origin := parsed.Scheme + "://" + parsed.Host
+	return parsed.Host == r.Host || slices.Contains(allowedOrigins, origin)
+}
@@ -0,0 +1,135 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/gin-gonic/gin"
+)
+
+var testAllowedOrigins = []string{"https://portfolio.example.com", "http://localhost:4321"}
+
+func TestSameOrigin(t *testing.T) {
+	tests := []struct {
+		name    string
+		origin  string
+		referer string
+		want    bool
+	}{
+		{"no origin nor referer", "", "", true},
+		{"allowed origin", "https://portfolio.example.com", "", true},
+		{"allowed origin with a port", "http://localhost:4321", "", true},
+		{"API host", "https://api.example.com", "", true},
+		{"foreign origin", "https://evil.example.com", "", false},
+		{"allowed host with another scheme", "http://portfolio.example.com", "", false},
+		{"allowed host with another port", "http://localhost:4322", "", false},
+		{"null origin", "null", "", false},
+		{"unparsable origin", "://", "", false},
+		{"allowed referer only", "", "https://portfolio.example.com/admin/projects", true},
+		{"foreign referer only", "", "https://evil.example.com/page", false},
+		{"origin wins over referer", "https://evil.example.com", "https://portfolio.example.com/admin", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "https://api.example.com/api/v1/projects", nil)
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+			if tt.referer != "" {
+				r.Header.Set("Referer", tt.referer)
+			}
+
+			if got := sameOrigin(r, testAllowedOrigins); got != tt.want {
+				t.Errorf("sameOrigin(Origin %q, Referer %q) = %t, want %t", tt.origin, tt.referer, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const sessionToken = "session-token"
+
+	tests := []struct {
+		name    string
+		method  string
+		origin  string
+		token   string
+		wantErr error
+	}{
+		{"read without token", http.MethodGet, "", "", nil},
+		{"head without token", http.MethodHead, "https://evil.example.com", "", nil},
+		{"write with token", http.MethodPost, "https://portfolio.example.com", utils.CSRFToken(sessionToken), nil},
+		{"server-side write with token", http.MethodDelete, "", utils.CSRFToken(sessionToken), nil},
+		{"write without token", http.MethodPost, "https://portfolio.example.com", "", errInvalidCSRFToken},
+		{"write with another session token", http.MethodPatch, "", utils.CSRFToken("other-session-token"), errInvalidCSRFToken},
+		{"cross-origin write with token", http.MethodPut, "https://evil.example.com", utils.CSRFToken(sessionToken), errCrossOriginRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, _ := gin.CreateTestContext(httptest.NewRecorder())
+			c.Request = httptest.NewRequest(tt.method, "https://api.example.com/api/v1/projects", nil)
+			if tt.origin != "" {
+				c.Request.Header.Set("Origin", tt.origin)
+			}
+			if tt.token != "" {
+				c.Request.Header.Set(constants.CSRFHeaderName, tt.token)
+			}
+
+			if err := checkCSRF(c, sessionToken, testAllowedOrigins); !errors.Is(err, tt.wantErr) {
+				t.Errorf("checkCSRF = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCSRFMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	const sessionToken = "session-token"
+
+	tests := []struct {
+		name       string
+		cookie     string
+		token      string
+		origin     string
+		wantStatus int
+	}{
+		{"no session cookie", "", "", "", http.StatusOK},
+		{"session cookie with token", sessionToken, utils.CSRFToken(sessionToken), "", http.StatusOK},
+		{"session cookie without token", sessionToken, "", "", http.StatusForbidden},
+		{"session cookie with another session token", sessionToken, utils.CSRFToken("other-session-token"), "", http.StatusForbidden},
+		{"cross-origin with token", sessionToken, utils.CSRFToken(sessionToken), "https://evil.example.com", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.POST("/api/v1/auth/logout", CSRFMiddleware(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			r := httptest.NewRequest(http.MethodPost, "https://api.example.com/api/v1/auth/logout", nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: constants.GetSessionCookieName(), Value: tt.cookie})
+			}
+			if tt.token != "" {
+				r.Header.Set(constants.CSRFHeaderName, tt.token)
+			}
+			if tt.origin != "" {
+				r.Header.Set("Origin", tt.origin)
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, r)
+			if w.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -49,13 +49,13 @@ func LoggerMiddleware() gin.HandlerFunc {
 		switch {
 		case statusCode >= 500:
 			if errorMsg != "" {
-				log.Error(errorMsg)
+				log.Error("%s", errorMsg)
 			} else {
 				log.Error("Server error")
 			}
 		case statusCode >= 400:
 			if errorMsg != "" {
-				log.Error(errorMsg)
+				log.Error("%s", errorMsg)
 			} else {
 				log.Error("Client error")
 			}
@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/gin-gonic/gin"
+)
+
+// WarnUntrustedProxy logs a warning, once, when a request arrives with X-Forwarded-For while no proxy is trusted:
+// the API then runs behind a reverse proxy whose address is the client IP of every request, so the throttler and
+// the per-IP login failures are shared by every visitor.
+func WarnUntrustedProxy(trustedProxies []string) gin.HandlerFunc {
+	var once sync.Once
+
+	return func(c *gin.Context) {
+		if len(trustedProxies) == 0 && c.GetHeader("X-Forwarded-For") != "" {
+			once.Do(func() {
+				logger.Warn("Request from %s forwarded by a proxy, but TRUSTED_PROXIES is empty: every client shares "+
+					"the proxy's IP address. Set TRUSTED_PROXIES to the address or range of the reverse proxy.", c.RemoteIP())
+			})
+		}
+
+		c.Next()
+	}
+}
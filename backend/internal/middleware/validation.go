@@ -5,12 +5,15 @@ import (
 	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
 	"strings"
 	"time"
 
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
 	"github.com/bytedance/gopkg/util/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -36,6 +39,7 @@ func registerCustomValidators() {
 	validate.RegisterValidation("after_start_date", validateAfterStartDate)
 	validate.RegisterValidation("date_format", validateDateFormat)
 	validate.RegisterValidation("after_start_date_str", validateAfterStartDateString)
+	validate.RegisterValidation("sort_fields", validateSortFields)
 }
 
 // validateAfterStartDate validates that EndDate is after StartDate (for time.Time fields)
@@ -130,6 +134,28 @@ func validateAfterStartDateString(fl validator.FieldLevel) bool {
 	return endDate.After(startDate)
 }
 
+// validateSortFields validates a sort expression such as "start_date:desc,name" against the
+// space-separated list of sortable fields given as the tag parameter
+func validateSortFields(fl validator.FieldLevel) bool {
+	sort := fl.Field().String()
+	if sort == "" {
+		return true
+	}
+
+	allowed := strings.Fields(fl.Param())
+	for _, part := range strings.Split(sort, ",") {
+		field, direction, hasDirection := strings.Cut(strings.TrimSpace(part), ":")
+		if !slices.Contains(allowed, field) {
+			return false
+		}
+		if hasDirection && !strings.EqualFold(direction, "asc") && !strings.EqualFold(direction, "desc") {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ValidateRequest is a generic middleware that validates request body against validation tags
 func ValidateRequest[T any]() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -186,6 +212,78 @@ func ValidateQuery[T any]() gin.HandlerFunc {
 	}
 }
 
+// ValidateCertificationMetadata validates the metadata sent along with a certification batch upload: the shared
+// form fields applied to every file and the per-file entries (see dto.ParseCertificationFileMetadata). The metadata of
+// each file, in the order of the "files" field, is stored in the context as validatedMetadata.
+func ValidateCertificationMetadata() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		form, err := c.MultipartForm()
+		if err != nil || len(form.File["files"]) == 0 {
+			// Let the handler report the form error or the missing files
+			c.Next()
+			return
+		}
+
+		var shared dto.CertificationMetadata
+		if err := c.ShouldBindWith(&shared, binding.FormMultipart); err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "Invalid metadata", err)
+			c.Abort()
+			return
+		}
+
+		entries, err := dto.ParseCertificationFileMetadata(form.Value)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+			c.Abort()
+			return
+		}
+
+		var errorMessages []string
+		if err := validate.Struct(shared); err != nil {
+			var validationErrors validator.ValidationErrors
+			errors.As(err, &validationErrors)
+			errorMessages = append(errorMessages, formatValidationErrors(validationErrors)...)
+		}
+		for i, entry := range entries {
+			if err := validate.Struct(entry); err != nil {
+				var validationErrors validator.ValidationErrors
+				errors.As(err, &validationErrors)
+				for _, message := range formatValidationErrors(validationErrors) {
+					errorMessages = append(errorMessages, fmt.Sprintf("metadata[%d]: %s", metadataIndex(entry, i), message))
+				}
+			}
+		}
+		if len(errorMessages) > 0 {
+			utils.RespondWithError(c, http.StatusBadRequest, strings.Join(errorMessages, "; "), nil)
+			c.Abort()
+			return
+		}
+
+		fileNames := make([]string, len(form.File["files"]))
+		for i, file := range form.File["files"] {
+			fileNames[i] = file.Filename
+		}
+
+		metadata, err := dto.ResolveCertificationMetadata(shared, entries, fileNames)
+		if err != nil {
+			utils.RespondWithError(c, http.StatusBadRequest, "", err)
+			c.Abort()
+			return
+		}
+
+		c.Set("validatedMetadata", metadata)
+		c.Next()
+	}
+}
+
+// metadataIndex returns the index a metadata entry was sent with, for error messages
+func metadataIndex(entry dto.CertificationFileMetadata, position int) int {
+	if entry.Index != nil {
+		return *entry.Index
+	}
+	return position
+}
+
 // formatValidationErrors converts validator errors to human-readable messages
 func formatValidationErrors(errors validator.ValidationErrors) []string {
 	var messages []string
@@ -199,20 +297,30 @@ func formatValidationErrors(errors validator.ValidationErrors) []string {
 			message = fmt.Sprintf("%s is required", field)
 		case "min":
 			// Check if it's numeric validation or string length
-			if err.Type().Kind() == reflect.Int || err.Type().Kind() == reflect.Int64 {
+			if isNumericKind(err.Type().Kind()) {
 				message = fmt.Sprintf("%s must be at least %s", field, err.Param())
 			} else {
 				message = fmt.Sprintf("%s must be at least %s characters", field, err.Param())
 			}
 		case "max":
 			// Check if it's numeric validation or string length
-			if err.Type().Kind() == reflect.Int || err.Type().Kind() == reflect.Int64 {
+			if isNumericKind(err.Type().Kind()) {
 				message = fmt.Sprintf("%s must not exceed %s", field, err.Param())
 			} else {
 				message = fmt.Sprintf("%s must not exceed %s characters", field, err.Param())
 			}
 		case "url":
 			message = fmt.Sprintf("%s must be a valid URL", field)
+		case "eq=|url":
+			message = fmt.Sprintf("%s must be a valid URL or empty", field)
+		case "email":
+			message = fmt.Sprintf("%s must be a valid email address", field)
+		case "len":
+			message = fmt.Sprintf("%s must be exactly %s characters", field, err.Param())
+		case "alpha":
+			message = fmt.Sprintf("%s must contain only letters", field)
+		case "unique":
+			message = fmt.Sprintf("%s must not contain duplicates", field)
 		case "oneof":
 			message = fmt.Sprintf("%s must be one of: %s", field, err.Param())
 		case "gtefield":
@@ -221,6 +329,8 @@ func formatValidationErrors(errors validator.ValidationErrors) []string {
 			message = fmt.Sprintf("%s must be after start date", field)
 		case "after_start_date_str":
 			message = fmt.Sprintf("%s must be after start date", field)
+		case "sort_fields":
+			message = fmt.Sprintf("%s must be a comma-separated list of field:asc|desc using: %s", field, strings.ReplaceAll(err.Param(), " ", ", "))
 		case "date_format":
 			message = fmt.Sprintf("%s must be a valid date (formats: MM/YYYY, YYYY-MM, DD/MM/YYYY, YYYY-MM-DD)", field)
 		default:
@@ -232,3 +342,12 @@ func formatValidationErrors(errors validator.ValidationErrors) []string {
 
 	return messages
 }
+
+// isNumericKind reports whether min/max apply to a number rather than a length
+func isNumericKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return true
+	}
+	return false
+}
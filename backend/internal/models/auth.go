@@ -10,22 +10,99 @@ type User struct {
 	gorm.Model
 	Email    string `json:"email" gorm:"type:varchar(255);not null"`
 	Password string `json:"-" gorm:"type:varchar(255);not null"`
+	// TOTPSecret is the encrypted TOTP secret, set from the start of the enrollment; TOTPEnabledAt is set once
+	// the enrollment is confirmed with a code. TOTPLastStep is the time step of the last accepted code, which
+	// cannot be used again.
+	TOTPSecret    *string    `json:"-" gorm:"column:totp_secret;type:text"`
+	TOTPEnabledAt *time.Time `json:"-" gorm:"column:totp_enabled_at"`
+	TOTPLastStep  int64      `json:"-" gorm:"column:totp_last_step;not null;default:0"`
 }
 
+// TwoFactorEnabled reports whether the user signs in with a TOTP code besides the password
+func (u *User) TwoFactorEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// Session is a signed-in device. Its ID is the SHA-256 of the token of the cookie, which is not stored: Token is
+// only set on a new session. ExpiresAt slides forward while the session is used, up to a fixed lifetime from
+// CreatedAt; UserAgent and IPAddress are those of the login.
 type Session struct {
-	ID        string    `json:"id" gorm:"primaryKey;type:varchar(255)"`
-	UserID    uint      `json:"user_id" gorm:"not null"`
-	User      User      `json:"-" gorm:"foreignKey:UserID"`
-	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at" gorm:"not null"`
+	ID         string    `json:"id" gorm:"primaryKey;type:varchar(255)"`
+	Token      string    `json:"-" gorm:"-"`
+	UserID     uint      `json:"user_id" gorm:"not null"`
+	User       User      `json:"-" gorm:"foreignKey:UserID"`
+	UserAgent  string    `json:"user_agent" gorm:"type:varchar(512);not null"`
+	IPAddress  string    `json:"ip_address" gorm:"type:varchar(45);not null"`
+	LastSeenAt time.Time `json:"last_seen_at" gorm:"not null"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"not null"`
+}
+
+// RevokedSessionsResponse counts the sessions signed out at once
+type RevokedSessionsResponse struct {
+	Revoked int64 `json:"revoked"`
+}
+
+// SessionClient is the device a login comes from
+type SessionClient struct {
+	IPAddress string
+	UserAgent string
+}
+
+// SessionResponse describes a session of the user. ID identifies it to revoke it, it cannot be used to sign in.
+type SessionResponse struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+// LoginChallenge is a login that passed the password check and waits for the second factor
+type LoginChallenge struct {
+	ID        string    `gorm:"primaryKey;type:varchar(255)"`
+	UserID    uint      `gorm:"not null"`
+	User      User      `gorm:"foreignKey:UserID"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+// RecoveryCode is a single-use code that replaces the TOTP code when the authenticator is lost. Only the
+// SHA-256 of the code is stored.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null"`
+	CodeHash  string `gorm:"type:varchar(64);not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"not null"`
 }
 
 type UserResponse struct {
-	ID    uint   `json:"id"`
-	Email string `json:"email"`
+	ID               uint   `json:"id"`
+	Email            string `json:"email"`
+	TwoFactorEnabled bool   `json:"two_factor_enabled"`
 }
 
+// AuthResponse is the outcome of a login step. When the user has two-factor authentication enabled, the
+// password step returns the challenge to verify instead of the user.
 type AuthResponse struct {
-	User    UserResponse `json:"user"`
-	Message string       `json:"message"`
+	User               *UserResponse `json:"user,omitempty"`
+	TwoFactorRequired  bool          `json:"two_factor_required,omitempty"`
+	Challenge          string        `json:"challenge,omitempty"`
+	ChallengeExpiresAt *time.Time    `json:"challenge_expires_at,omitempty"`
+	Message            string        `json:"message"`
+}
+
+// TwoFactorSetup is the secret of a two-factor enrollment, to add to an authenticator app
+type TwoFactorSetup struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// RecoveryCodesResponse lists newly generated recovery codes, shown only once
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
 }
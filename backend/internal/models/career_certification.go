@@ -1,26 +1,113 @@
 package models
 
 import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
 	"time"
 
 	"gorm.io/gorm"
 )
 
+// Certification expiry statuses, see CareerCertification.ExpiryStatus
+const (
+	CertificationValid    = "valid"
+	CertificationExpiring = "expiring"
+	CertificationExpired  = "expired"
+)
+
+// CareerCertification is a certificate file with its metadata. ContentHash is the hex SHA-256 of the stored
+// original file, unique among the certifications that are not deleted. Status is computed from ExpiryDate when
+// the certification is served; ExpiryNotifiedAt records when the admin was warned about the expiry.
 type CareerCertification struct {
-	ID            uint           `gorm:"primaryKey" json:"id"`
-	Title         string         `gorm:"type:varchar(255);not null" json:"title"`
-	Issuer        string         `gorm:"type:varchar(255);not null" json:"issuer"`
-	IssueDate     time.Time      `gorm:"not null" json:"issue_date"`
-	ExpiryDate    *time.Time     `json:"expiry_date,omitempty"`
-	CredentialID  *string        `gorm:"type:varchar(255)" json:"credential_id,omitempty"`
-	CredentialURL *string        `gorm:"type:varchar(500)" json:"credential_url,omitempty"`
-	FileURL       string         `gorm:"type:varchar(500);not null" json:"file_url"`
-	FileName      string         `gorm:"type:varchar(255);not null" json:"file_name"`
-	OriginalName  string         `gorm:"type:varchar(255);not null" json:"original_name"`
-	FileSize      int64          `gorm:"not null" json:"file_size"`
-	MimeType      string         `gorm:"type:varchar(100);not null" json:"mime_type"`
-	Description   string         `gorm:"type:text" json:"description,omitempty"`
-	CreatedAt     time.Time      `json:"created_at"`
-	UpdatedAt     time.Time      `json:"updated_at"`
-	DeletedAt     gorm.DeletedAt `gorm:"index" json:"-"`
+	ID               uint           `gorm:"primaryKey" json:"id"`
+	Title            string         `gorm:"type:varchar(255);not null" json:"title"`
+	Issuer           string         `gorm:"type:varchar(255);not null" json:"issuer"`
+	IssueDate        time.Time      `gorm:"not null" json:"issue_date"`
+	ExpiryDate       *time.Time     `json:"expiry_date,omitempty"`
+	Status           string         `gorm:"-" json:"status"`
+	CredentialID     *string        `gorm:"type:varchar(255)" json:"credential_id,omitempty"`
+	CredentialURL    *string        `gorm:"type:varchar(500)" json:"credential_url,omitempty"`
+	FileURL          string         `gorm:"type:varchar(500);not null" json:"file_url"`
+	FileName         string         `gorm:"type:varchar(255);not null" json:"file_name"`
+	OriginalName     string         `gorm:"type:varchar(255);not null" json:"original_name"`
+	FileSize         int64          `gorm:"not null" json:"file_size"`
+	MimeType         string         `gorm:"type:varchar(100);not null" json:"mime_type"`
+	Width            int            `json:"width,omitempty"`
+	Height           int            `json:"height,omitempty"`
+	Blurhash         string         `gorm:"type:varchar(100)" json:"blurhash,omitempty"`
+	Renditions       Renditions     `gorm:"type:text;default:'[]'" json:"renditions"`
+	ContentHash      *string        `gorm:"type:varchar(64)" json:"content_hash,omitempty"`
+	Description      string         `gorm:"type:text" json:"description,omitempty"`
+	ExpiryNotifiedAt *time.Time     `json:"-"`
+	CreatedAt        time.Time      `json:"created_at"`
+	UpdatedAt        time.Time      `json:"updated_at"`
+	DeletedAt        gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// ExpiryStatus reports whether the certification is expired (its expiry date is before today), expiring (it
+// expires within noticeDays) or valid, which includes certifications that never expire. Dates compare in UTC.
+func (c *CareerCertification) ExpiryStatus(now time.Time, noticeDays int) string {
+	if c.ExpiryDate == nil {
+		return CertificationValid
+	}
+
+	expiry := c.ExpiryDate.UTC().Format(time.DateOnly)
+	switch {
+	case expiry < now.UTC().Format(time.DateOnly):
+		return CertificationExpired
+	case expiry <= now.UTC().AddDate(0, 0, noticeDays).Format(time.DateOnly):
+		return CertificationExpiring
+	default:
+		return CertificationValid
+	}
+}
+
+// Rendition is a resized WebP version of an uploaded image, such as the gallery thumbnail
+type Rendition struct {
+	Name     string `json:"name"`
+	URL      string `json:"url"`
+	FileName string `json:"file_name"`
+	Width    int    `json:"width"`
+	Height   int    `json:"height"`
+	Size     int64  `json:"size"`
+}
+
+// Renditions is stored as a JSON array
+type Renditions []Rendition
+
+func (r *Renditions) Scan(value interface{}) error {
+	if value == nil {
+		*r = Renditions{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into Renditions", value)
+	}
+
+	var result Renditions
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		*r = Renditions{}
+		return nil
+	}
+	*r = result
+	return nil
+}
+
+func (r Renditions) Value() (driver.Value, error) {
+	if r == nil {
+		return "[]", nil
+	}
+	bytes, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
 }
@@ -0,0 +1,24 @@
+package models
+
+import (
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+
+	"gorm.io/gorm"
+)
+
+type Education struct {
+	gorm.Model
+	Institution string      `json:"institution" gorm:"type:varchar(255);not null"`
+	URL         *string     `json:"url,omitempty" gorm:"type:varchar(500)"`
+	Area        string      `json:"area" gorm:"type:varchar(255)"`
+	StudyType   string      `json:"study_type" gorm:"type:varchar(255)"`
+	StartDate   utils.Date  `json:"start_date" gorm:"type:date;not null"`
+	EndDate     *utils.Date `json:"end_date,omitempty"`
+	Score       string      `json:"score,omitempty" gorm:"type:varchar(50)"`
+	Courses     JSONStrings `json:"courses" gorm:"type:text;default:'[]'"`
+}
+
+// TableName keeps the uncountable table name instead of GORM's "educations"
+func (Education) TableName() string {
+	return "education"
+}
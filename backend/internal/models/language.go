@@ -0,0 +1,9 @@
+package models
+
+import "gorm.io/gorm"
+
+type Language struct {
+	gorm.Model
+	Name    string `json:"name" gorm:"type:varchar(100);not null"`
+	Fluency string `json:"fluency" gorm:"type:varchar(100);not null"`
+}
@@ -0,0 +1,72 @@
+package models
+
+import "time"
+
+// Owners of the links checked by the link health checker
+const (
+	LinkOwnerCertification    = "certification"
+	LinkOwnerExperience       = "experience"
+	LinkOwnerExperienceClient = "experience_client"
+	LinkOwnerProject          = "project"
+)
+
+// Link check statuses. A link is unverified when the site refuses automated requests (401, 403, 429, 999), so
+// it cannot be told apart from a working one; pending links have not been checked since they were set.
+const (
+	LinkOK         = "ok"
+	LinkBroken     = "broken"
+	LinkUnverified = "unverified"
+	LinkPending    = "pending"
+)
+
+// Link is a URL of a portfolio record: a certification's credential URL or the URL of an experience, an
+// experience client or a project. Label names the record in reports.
+type Link struct {
+	OwnerType string
+	OwnerID   uint
+	Label     string
+	URL       string
+}
+
+// LinkCheck is the outcome of the last check of a link. ConsecutiveFailures counts the checks in a row that
+// found the URL broken.
+type LinkCheck struct {
+	ID                  uint      `gorm:"primaryKey" json:"-"`
+	OwnerType           string    `gorm:"type:varchar(30);not null" json:"owner_type"`
+	OwnerID             uint      `gorm:"not null" json:"owner_id"`
+	URL                 string    `gorm:"type:varchar(500);not null" json:"url"`
+	Status              string    `gorm:"type:varchar(20);not null" json:"status"`
+	StatusCode          int       `gorm:"not null" json:"status_code,omitempty"`
+	Error               string    `gorm:"type:text" json:"error,omitempty"`
+	ConsecutiveFailures int       `gorm:"not null" json:"consecutive_failures"`
+	CheckedAt           time.Time `gorm:"not null" json:"checked_at"`
+	CreatedAt           time.Time `json:"-"`
+	UpdatedAt           time.Time `json:"-"`
+}
+
+// LinkHealth is the health of one link in the report. Hidden tells whether the link is left out of the public
+// responses.
+type LinkHealth struct {
+	OwnerType           string     `json:"owner_type"`
+	OwnerID             uint       `json:"owner_id"`
+	Label               string     `json:"label"`
+	URL                 string     `json:"url"`
+	Status              string     `json:"status"`
+	StatusCode          int        `json:"status_code,omitempty"`
+	Error               string     `json:"error,omitempty"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	CheckedAt           *time.Time `json:"checked_at,omitempty"`
+	Hidden              bool       `json:"hidden"`
+}
+
+// LinkHealthReport summarizes the health of every link, broken ones first
+type LinkHealthReport struct {
+	Total      int          `json:"total"`
+	OK         int          `json:"ok"`
+	Broken     int          `json:"broken"`
+	Unverified int          `json:"unverified"`
+	Pending    int          `json:"pending"`
+	Hidden     int          `json:"hidden"`
+	LastRunAt  *time.Time   `json:"last_run_at,omitempty"`
+	Links      []LinkHealth `json:"links"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Scopes of the failed login counters
+const (
+	LoginThrottleAccount = "account"
+	LoginThrottleIP      = "ip"
+)
+
+// LoginThrottle counts the recent failed logins of an account, by email, or of an IP address. LockedUntil is set
+// while the key is locked out.
+type LoginThrottle struct {
+	ID            uint       `gorm:"primaryKey" json:"-"`
+	Scope         string     `gorm:"type:varchar(20);not null" json:"scope"`
+	Key           string     `gorm:"type:varchar(255);not null" json:"key"`
+	Failures      int        `gorm:"not null" json:"failures"`
+	LastFailureAt time.Time  `gorm:"not null" json:"last_failure_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
+	CreatedAt     time.Time  `json:"-"`
+	UpdatedAt     time.Time  `json:"-"`
+}
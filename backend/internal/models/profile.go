@@ -0,0 +1,32 @@
+package models
+
+import "gorm.io/gorm"
+
+// ProfileID is the primary key of the single profile row
+const ProfileID = 1
+
+// Profile holds the résumé "basics" of the portfolio owner. The table only ever has one row.
+type Profile struct {
+	gorm.Model
+	Name           string          `json:"name" gorm:"type:varchar(255);not null"`
+	Label          string          `json:"label" gorm:"type:varchar(255)"`
+	Email          string          `json:"email" gorm:"type:varchar(255)"`
+	Phone          string          `json:"phone" gorm:"type:varchar(50)"`
+	URL            string          `json:"url" gorm:"type:varchar(500)"`
+	Summary        string          `json:"summary" gorm:"type:text"`
+	City           string          `json:"city" gorm:"type:varchar(100)"`
+	Region         string          `json:"region" gorm:"type:varchar(100)"`
+	CountryCode    string          `json:"country_code" gorm:"type:varchar(2)"`
+	AvatarURL      *string         `json:"avatar_url,omitempty" gorm:"type:varchar(500)"`
+	AvatarFileName *string         `json:"-" gorm:"type:varchar(255)"`
+	SocialProfiles []SocialProfile `json:"profiles" gorm:"foreignKey:ProfileID"`
+}
+
+// SocialProfile is an account on a social network or code hosting site
+type SocialProfile struct {
+	gorm.Model
+	ProfileID uint   `json:"profile_id" gorm:"not null;index"`
+	Network   string `json:"network" gorm:"type:varchar(100);not null"`
+	Username  string `json:"username" gorm:"type:varchar(255)"`
+	URL       string `json:"url" gorm:"type:varchar(500);not null"`
+}
@@ -0,0 +1,141 @@
+package models
+
+// JSONResumeSchemaURL is the version of the JSON Resume schema the exported document conforms to
+const JSONResumeSchemaURL = "https://raw.githubusercontent.com/jsonresume/resume-schema/v1.0.0/schema.json"
+
+// Resume is a JSON Resume (https://jsonresume.org/schema) document.
+// Only the sections backed by portfolio data are modelled; unknown sections are ignored on import.
+// Dates use the schema's ISO 8601 formats (YYYY-MM-DD, YYYY-MM or YYYY).
+type Resume struct {
+	Schema       string              `json:"$schema,omitempty"`
+	Basics       *ResumeBasics       `json:"basics,omitempty"`
+	Work         []ResumeWork        `json:"work,omitempty"`
+	Education    []ResumeEducation   `json:"education,omitempty"`
+	Certificates []ResumeCertificate `json:"certificates,omitempty"`
+	Skills       []ResumeSkill       `json:"skills,omitempty"`
+	Languages    []ResumeLanguage    `json:"languages,omitempty"`
+	Projects     []ResumeProject     `json:"projects,omitempty"`
+	Meta         *ResumeMeta         `json:"meta,omitempty"`
+}
+
+// ResumeBasics maps to the Profile, profiles holds its social profiles
+type ResumeBasics struct {
+	Name     string          `json:"name"`
+	Label    string          `json:"label,omitempty"`
+	Image    string          `json:"image,omitempty"`
+	Email    string          `json:"email,omitempty"`
+	Phone    string          `json:"phone,omitempty"`
+	URL      string          `json:"url,omitempty"`
+	Summary  string          `json:"summary,omitempty"`
+	Location *ResumeLocation `json:"location,omitempty"`
+	Profiles []ResumeProfile `json:"profiles,omitempty"`
+}
+
+// ResumeLocation is the location part of the basics section
+type ResumeLocation struct {
+	City        string `json:"city,omitempty"`
+	Region      string `json:"region,omitempty"`
+	CountryCode string `json:"countryCode,omitempty"`
+}
+
+// ResumeProfile maps to a SocialProfile
+type ResumeProfile struct {
+	Network  string `json:"network"`
+	Username string `json:"username,omitempty"`
+	URL      string `json:"url,omitempty"`
+}
+
+// ResumeWork maps to an Experience. WorkType is a portfolio extension (Remote, On Site, Hybrid)
+// allowed by the schema's additionalProperties; highlights are built from the client achievements.
+type ResumeWork struct {
+	Name       string   `json:"name"`
+	Position   string   `json:"position"`
+	URL        string   `json:"url,omitempty"`
+	Location   string   `json:"location,omitempty"`
+	WorkType   string   `json:"workType,omitempty"`
+	StartDate  string   `json:"startDate"`
+	EndDate    string   `json:"endDate,omitempty"`
+	Summary    string   `json:"summary,omitempty"`
+	Highlights []string `json:"highlights,omitempty"`
+}
+
+// ResumeEducation maps to an Education entry
+type ResumeEducation struct {
+	Institution string   `json:"institution"`
+	URL         string   `json:"url,omitempty"`
+	Area        string   `json:"area,omitempty"`
+	StudyType   string   `json:"studyType,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Score       string   `json:"score,omitempty"`
+	Courses     []string `json:"courses,omitempty"`
+}
+
+// ResumeSkill maps to a Skill
+type ResumeSkill struct {
+	Name     string   `json:"name"`
+	Level    string   `json:"level,omitempty"`
+	Keywords []string `json:"keywords,omitempty"`
+}
+
+// ResumeLanguage maps to a Language
+type ResumeLanguage struct {
+	Language string `json:"language"`
+	Fluency  string `json:"fluency,omitempty"`
+}
+
+// ResumeProject maps to a Project, keywords hold the project technologies
+type ResumeProject struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	URL         string   `json:"url,omitempty"`
+	StartDate   string   `json:"startDate,omitempty"`
+	EndDate     string   `json:"endDate,omitempty"`
+	Keywords    []string `json:"keywords,omitempty"`
+}
+
+// ResumeCertificate maps to a CareerCertification, url is the credential verification URL
+type ResumeCertificate struct {
+	Name   string `json:"name"`
+	Date   string `json:"date,omitempty"`
+	Issuer string `json:"issuer,omitempty"`
+	URL    string `json:"url,omitempty"`
+}
+
+// ResumeMeta holds the document metadata section
+type ResumeMeta struct {
+	Canonical    string `json:"canonical,omitempty"`
+	Version      string `json:"version,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+// Resume import actions reported for every imported entry
+const (
+	ResumeActionCreate    = "create"
+	ResumeActionUpdate    = "update"
+	ResumeActionUnchanged = "unchanged"
+)
+
+// ResumeFieldChange is the old and new value of a field touched by an import
+type ResumeFieldChange struct {
+	From interface{} `json:"from"`
+	To   interface{} `json:"to"`
+}
+
+// ResumeEntryDiff describes what an import does (or would do, in dry-run mode) to a single entry
+type ResumeEntryDiff struct {
+	Section string                       `json:"section"`
+	Key     string                       `json:"key"`
+	Action  string                       `json:"action"`
+	ID      uint                         `json:"id,omitempty"`
+	Changes map[string]ResumeFieldChange `json:"changes,omitempty"`
+}
+
+// ResumeImportResult summarizes a résumé import
+type ResumeImportResult struct {
+	DryRun    bool              `json:"dryRun"`
+	Created   int               `json:"created"`
+	Updated   int               `json:"updated"`
+	Unchanged int               `json:"unchanged"`
+	Entries   []ResumeEntryDiff `json:"entries"`
+}
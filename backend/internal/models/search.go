@@ -0,0 +1,21 @@
+package models
+
+// SearchHit kinds stored in the search_index FTS5 table
+const (
+	SearchKindProject       = "project"
+	SearchKindExperience    = "experience"
+	SearchKindClient        = "client"
+	SearchKindCertification = "certification"
+)
+
+// SearchHit is a single ranked row returned by the full-text search index.
+// Title, Subtitle and Snippet are HTML-escaped, with <mark> tags around the matched terms.
+type SearchHit struct {
+	Kind     string  `json:"type" gorm:"column:kind"`
+	RefID    uint    `json:"id" gorm:"column:ref_id"`
+	ParentID *uint   `json:"parent_id,omitempty" gorm:"column:parent_id"`
+	Title    string  `json:"title" gorm:"column:title"`
+	Subtitle string  `json:"subtitle,omitempty" gorm:"column:subtitle"`
+	Snippet  string  `json:"snippet" gorm:"column:snippet"`
+	Score    float64 `json:"score" gorm:"column:score"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "gorm.io/gorm"
+
+type SkillLevel string
+
+const (
+	SkillBeginner     SkillLevel = "Beginner"
+	SkillIntermediate SkillLevel = "Intermediate"
+	SkillAdvanced     SkillLevel = "Advanced"
+	SkillExpert       SkillLevel = "Expert"
+)
+
+// Skill is a technology or competence, optionally linked to the projects and experience clients where it was used
+type Skill struct {
+	gorm.Model
+	Name              string             `json:"name" gorm:"type:varchar(100);not null"`
+	Category          string             `json:"category" gorm:"type:varchar(100)"`
+	Level             SkillLevel         `json:"level,omitempty" gorm:"type:varchar(50)"`
+	Keywords          JSONStrings        `json:"keywords" gorm:"type:text;default:'[]'"`
+	Projects          []Project          `json:"projects,omitempty" gorm:"many2many:project_skills;"`
+	ExperienceClients []ExperienceClient `json:"experience_clients,omitempty" gorm:"many2many:experience_client_skills;"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import "time"
+
+// Types of the records that go to the trash when deleted
+const (
+	TrashExperience       = "experience"
+	TrashExperienceClient = "experience_client"
+	TrashProject          = "project"
+	TrashCertification    = "certification"
+)
+
+// TrashItem is a soft-deleted record. ParentID is the experience of an experience client; PurgeAt is when the
+// retention policy deletes the record for good, unset when deleted records are kept forever.
+type TrashItem struct {
+	Type      string     `json:"type"`
+	ID        uint       `json:"id"`
+	Label     string     `json:"label"`
+	ParentID  *uint      `json:"parent_id,omitempty"`
+	DeletedAt time.Time  `json:"deleted_at"`
+	PurgeAt   *time.Time `json:"purge_at,omitempty"`
+}
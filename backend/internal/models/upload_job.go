@@ -0,0 +1,121 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Upload job statuses
+const (
+	UploadJobQueued    = "queued"
+	UploadJobRunning   = "running"
+	UploadJobCompleted = "completed"
+	UploadJobFailed    = "failed"
+)
+
+// Upload job file statuses
+const (
+	UploadFilePending   = "pending"
+	UploadFileStaged    = "staged"
+	UploadFileSucceeded = "succeeded"
+	UploadFileFailed    = "failed"
+	UploadFileDuplicate = "duplicate"
+)
+
+// UploadJob is a certification batch upload processed in the background. The uploaded files are staged on disk
+// until the job finishes, so queued and interrupted jobs are resumed when the server starts.
+// An atomic job stores all of its files or none: they stay "staged" until the whole batch succeeds.
+// A file identical to an existing certification is not stored again, it is reported as a "duplicate" of it.
+type UploadJob struct {
+	ID         uint           `gorm:"primaryKey" json:"id"`
+	Status     string         `gorm:"type:varchar(20);not null" json:"status"`
+	Atomic     bool           `gorm:"not null" json:"atomic"`
+	Workers    int            `gorm:"not null" json:"workers"`
+	Total      int            `gorm:"not null" json:"total"`
+	Processed  int            `gorm:"not null" json:"processed"`
+	Succeeded  int            `gorm:"not null" json:"succeeded"`
+	Failed     int            `gorm:"not null" json:"failed"`
+	Duplicates int            `gorm:"not null" json:"duplicates"`
+	Files      UploadJobFiles `gorm:"type:text;default:'[]'" json:"files"`
+	Metadata   string         `gorm:"type:text;default:'[]'" json:"-"`
+	Error      string         `gorm:"type:text" json:"error,omitempty"`
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	FinishedAt *time.Time     `json:"finished_at,omitempty"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+}
+
+// Finished reports whether the job is done, successfully or not
+func (j *UploadJob) Finished() bool {
+	return j.Status == UploadJobCompleted || j.Status == UploadJobFailed
+}
+
+// UploadJobFile is the progress of a single file of an upload job
+type UploadJobFile struct {
+	Index           int    `json:"index"`
+	OriginalName    string `json:"original_name"`
+	Size            int64  `json:"size"`
+	Status          string `json:"status"`
+	CertificationID *uint  `json:"certification_id,omitempty"`
+	Error           string `json:"error,omitempty"`
+}
+
+// UploadJobFiles is stored as a JSON array
+type UploadJobFiles []UploadJobFile
+
+func (f *UploadJobFiles) Scan(value interface{}) error {
+	if value == nil {
+		*f = UploadJobFiles{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into UploadJobFiles", value)
+	}
+
+	var result UploadJobFiles
+	if err := json.Unmarshal(bytes, &result); err != nil {
+		*f = UploadJobFiles{}
+		return nil
+	}
+	*f = result
+	return nil
+}
+
+func (f UploadJobFiles) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	bytes, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(bytes), nil
+}
+
+// UploadJobEvent is a progress update streamed to the clients following a job. The "file" event carries the file
+// that just finished and the job counters; the "job" and "done" events carry the whole job.
+type UploadJobEvent struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+}
+
+// UploadJobProgress is the payload of the "file" event
+type UploadJobProgress struct {
+	JobID      uint          `json:"job_id"`
+	Status     string        `json:"status"`
+	Total      int           `json:"total"`
+	Processed  int           `json:"processed"`
+	Succeeded  int           `json:"succeeded"`
+	Failed     int           `json:"failed"`
+	Duplicates int           `json:"duplicates"`
+	File       UploadJobFile `json:"file"`
+}
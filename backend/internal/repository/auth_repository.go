@@ -2,6 +2,7 @@ package repository
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
@@ -10,10 +11,30 @@ import (
 
 type AuthRepository interface {
 	FindUserByEmail(email string) (*models.User, error)
+	FindUserByID(id uint) (*models.User, error)
+	FindAllUsers() ([]models.User, error)
+	CreateUser(user *models.User) error
+	UpdateUserPassword(userID uint, hashedPassword string) error
 	CreateSession(session *models.Session) error
 	FindSessionByID(sessionID string) (*models.Session, error)
+	FindSessionsByUserID(userID uint) ([]models.Session, error)
+	RenewSession(sessionID string, lastSeenAt, expiresAt time.Time) error
 	DeleteSession(sessionID string) error
+	DeleteSessionsByUserID(userID uint) (int64, error)
+	DeleteOtherSessions(userID uint, keepSessionID string) (int64, error)
 	DeleteExpiredSessions() error
+	SetTOTPSecret(userID uint, secret *string) error
+	EnableTwoFactor(userID uint, step int64, codes []models.RecoveryCode) error
+	DisableTwoFactor(userID uint) error
+	UpdateTOTPLastStep(userID uint, step int64) error
+	ReplaceRecoveryCodes(userID uint, codes []models.RecoveryCode) error
+	UseRecoveryCode(userID uint, codeHash string) error
+	CreateLoginChallenge(challenge *models.LoginChallenge) error
+	FindLoginChallengeByID(challengeID string) (*models.LoginChallenge, error)
+	IncrementLoginChallengeAttempts(challengeID string) error
+	DeleteLoginChallenge(challengeID string) error
+	DeleteLoginChallengesByUserID(userID uint) error
+	DeleteExpiredLoginChallenges() error
 }
 
 type authRepository struct {
@@ -25,11 +46,11 @@ func NewAuthRepository(db *gorm.DB) AuthRepository {
 	return &authRepository{db: db}
 }
 
-// FindUserByEmail retrieves a user by their email address
+// FindUserByEmail retrieves a user by their email address, regardless of case and surrounding spaces
 func (r *authRepository) FindUserByEmail(email string) (*models.User, error) {
 	var user models.User
 
-	result := r.db.Where("email = ?", email).First(&user)
+	result := r.db.Where("lower(email) = ?", strings.ToLower(strings.TrimSpace(email))).First(&user)
 	if result.Error != nil {
 		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, gorm.ErrRecordNotFound
@@ -40,13 +61,57 @@ func (r *authRepository) FindUserByEmail(email string) (*models.User, error) {
 	return &user, nil
 }
 
+// FindUserByID retrieves a user by ID
+func (r *authRepository) FindUserByID(id uint) (*models.User, error) {
+	var user models.User
+
+	result := r.db.First(&user, id)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &user, nil
+}
+
+// FindAllUsers retrieves all users ordered by creation date
+func (r *authRepository) FindAllUsers() ([]models.User, error) {
+	var users []models.User
+
+	result := r.db.Order("created_at ASC").Find(&users)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return users, nil
+}
+
+// CreateUser inserts a new user into the database
+func (r *authRepository) CreateUser(user *models.User) error {
+	result := r.db.Create(user)
+	return result.Error
+}
+
+// UpdateUserPassword replaces the stored password hash of a user
+func (r *authRepository) UpdateUserPassword(userID uint, hashedPassword string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("password", hashedPassword)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
 // CreateSession inserts a new session into the database
 func (r *authRepository) CreateSession(session *models.Session) error {
 	result := r.db.Create(session)
 	return result.Error
 }
 
-// FindSessionByID retrieves a session by its ID with the associated user preloaded
+// FindSessionByID retrieves a session by its ID, the hash of its token, with the associated user preloaded
 func (r *authRepository) FindSessionByID(sessionID string) (*models.Session, error) {
 	var session models.Session
 
@@ -61,6 +126,35 @@ func (r *authRepository) FindSessionByID(sessionID string) (*models.Session, err
 	return &session, nil
 }
 
+// FindSessionsByUserID retrieves the sessions of a user that have not expired, the most recently used first
+func (r *authRepository) FindSessionsByUserID(userID uint) ([]models.Session, error) {
+	var sessions []models.Session
+	err := r.db.Where("user_id = ? AND expires_at >= ?", userID, time.Now()).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
+
+// RenewSession records the use of a session and moves its expiry
+func (r *authRepository) RenewSession(sessionID string, lastSeenAt, expiresAt time.Time) error {
+	result := r.db.Model(&models.Session{}).Where("id = ?", sessionID).Updates(map[string]interface{}{
+		"last_seen_at": lastSeenAt,
+		"expires_at":   expiresAt,
+	})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
 // DeleteSession removes a session from the database
 func (r *authRepository) DeleteSession(sessionID string) error {
 	result := r.db.Where("id = ?", sessionID).Delete(&models.Session{})
@@ -75,8 +169,162 @@ func (r *authRepository) DeleteSession(sessionID string) error {
 	return nil
 }
 
+// DeleteSessionsByUserID removes every session belonging to a user and returns how many were deleted
+func (r *authRepository) DeleteSessionsByUserID(userID uint) (int64, error) {
+	result := r.db.Where("user_id = ?", userID).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteOtherSessions removes every session of a user but the given one and returns how many were deleted
+func (r *authRepository) DeleteOtherSessions(userID uint, keepSessionID string) (int64, error) {
+	result := r.db.Where("user_id = ? AND id <> ?", userID, keepSessionID).Delete(&models.Session{})
+	return result.RowsAffected, result.Error
+}
+
 // DeleteExpiredSessions removes all expired sessions from the database
 func (r *authRepository) DeleteExpiredSessions() error {
 	result := r.db.Where("expires_at < ?", time.Now()).Delete(&models.Session{})
 	return result.Error
 }
+
+// SetTOTPSecret stores the encrypted TOTP secret of a user starting a two-factor enrollment, or clears it
+func (r *authRepository) SetTOTPSecret(userID uint, secret *string) error {
+	result := r.db.Model(&models.User{}).Where("id = ?", userID).Update("totp_secret", secret)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// EnableTwoFactor confirms the two-factor enrollment of a user with the code of the given time step and replaces
+// their recovery codes
+func (r *authRepository) EnableTwoFactor(userID uint, step int64, codes []models.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ? AND totp_secret IS NOT NULL", userID).
+			Updates(map[string]interface{}{"totp_enabled_at": time.Now(), "totp_last_step": step})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+// DisableTwoFactor removes the TOTP secret, the recovery codes and the pending login challenges of a user
+func (r *authRepository) DisableTwoFactor(userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.User{}).Where("id = ?", userID).
+			Updates(map[string]interface{}{"totp_secret": nil, "totp_enabled_at": nil, "totp_last_step": 0})
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&models.LoginChallenge{}).Error
+	})
+}
+
+// UpdateTOTPLastStep records the time step of the last accepted TOTP code. It returns gorm.ErrRecordNotFound when
+// a code of that step or a later one was already accepted, so a code cannot be used twice.
+func (r *authRepository) UpdateTOTPLastStep(userID uint, step int64) error {
+	result := r.db.Model(&models.User{}).Where("id = ? AND totp_last_step < ?", userID, step).Update("totp_last_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// ReplaceRecoveryCodes deletes the recovery codes of a user and stores the given ones
+func (r *authRepository) ReplaceRecoveryCodes(userID uint, codes []models.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceRecoveryCodes(tx, userID, codes)
+	})
+}
+
+// UseRecoveryCode marks an unused recovery code of a user as used.
+// It returns gorm.ErrRecordNotFound when the user has no unused code with that hash.
+func (r *authRepository) UseRecoveryCode(userID uint, codeHash string) error {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// CreateLoginChallenge inserts a new login challenge into the database
+func (r *authRepository) CreateLoginChallenge(challenge *models.LoginChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+// FindLoginChallengeByID retrieves a login challenge by its ID with the associated user preloaded
+func (r *authRepository) FindLoginChallengeByID(challengeID string) (*models.LoginChallenge, error) {
+	var challenge models.LoginChallenge
+
+	result := r.db.Preload("User").Where("id = ?", challengeID).First(&challenge)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &challenge, nil
+}
+
+// IncrementLoginChallengeAttempts counts a failed verification of a login challenge
+func (r *authRepository) IncrementLoginChallengeAttempts(challengeID string) error {
+	return r.db.Model(&models.LoginChallenge{}).Where("id = ?", challengeID).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// DeleteLoginChallenge removes a login challenge from the database
+func (r *authRepository) DeleteLoginChallenge(challengeID string) error {
+	return r.db.Where("id = ?", challengeID).Delete(&models.LoginChallenge{}).Error
+}
+
+// DeleteLoginChallengesByUserID removes the pending login challenges of a user
+func (r *authRepository) DeleteLoginChallengesByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.LoginChallenge{}).Error
+}
+
+// DeleteExpiredLoginChallenges removes all expired login challenges from the database
+func (r *authRepository) DeleteExpiredLoginChallenges() error {
+	return r.db.Where("expires_at < ?", time.Now()).Delete(&models.LoginChallenge{}).Error
+}
+
+// replaceRecoveryCodes deletes the recovery codes of a user and stores the given ones within tx
+func replaceRecoveryCodes(tx *gorm.DB, userID uint, codes []models.RecoveryCode) error {
+	if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+		return err
+	}
+
+	for i := range codes {
+		codes[i].UserID = userID
+	}
+	return tx.Create(&codes).Error
+}
@@ -1,16 +1,40 @@
 package repository
 
 import (
+	"strings"
+	"time"
+
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"gorm.io/gorm"
 )
 
 type CareerCertificationRepository interface {
 	Create(certification *models.CareerCertification) error
-	FindAll() ([]models.CareerCertification, error)
+	CreateBatch(certifications []*models.CareerCertification) error
+	FindAll(filter CareerCertificationFilter, opts ListOptions) ([]models.CareerCertification, int64, error)
 	FindByID(id uint) (*models.CareerCertification, error)
+	FindByIDWithDeleted(id uint) (*models.CareerCertification, error)
+	FindByContentHash(hash string) (*models.CareerCertification, error)
+	FindAllInCreationOrder() ([]models.CareerCertification, error)
+	FindAllWithDeleted() ([]models.CareerCertification, error)
+	FindExpiringUnnotified(from, until time.Time) ([]models.CareerCertification, error)
+	MarkExpiryNotified(ids []uint, at time.Time) error
 	Update(id uint, updates map[string]interface{}) error
 	Delete(id uint) error
+	Restore(id uint) error
+	Purge(id uint) error
+}
+
+// CareerCertificationFilter holds the optional criteria used to narrow down certification listings.
+// Status keeps the certifications with that expiry status (see models.CareerCertification.ExpiryStatus) on the
+// date of Now, expiring meaning within NoticeDays.
+type CareerCertificationFilter struct {
+	Issuer     string
+	IssuedFrom *time.Time
+	IssuedTo   *time.Time
+	Status     string
+	Now        time.Time
+	NoticeDays int
 }
 
 // careerCertificationRepository provides methods to interact with the career certifications data in the database.
@@ -30,11 +54,53 @@ func (r *careerCertificationRepository) Create(certification *models.CareerCerti
 	return r.db.Create(certification).Error
 }
 
-// FindAll retrieves all career certifications from the database sorted by issue date in descending order.
-func (r *careerCertificationRepository) FindAll() ([]models.CareerCertification, error) {
+// CreateBatch inserts all the certifications in a single transaction, so either all of them are saved or none is.
+func (r *careerCertificationRepository) CreateBatch(certifications []*models.CareerCertification) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, certification := range certifications {
+			if err := tx.Create(certification).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// FindAll retrieves the career certifications matching the filter, sorted by issue date in descending order by default.
+// It returns the requested page together with the total number of matching records.
+func (r *careerCertificationRepository) FindAll(filter CareerCertificationFilter, opts ListOptions) ([]models.CareerCertification, int64, error) {
 	var certifications []models.CareerCertification
-	err := r.db.Order("issue_date DESC").Find(&certifications).Error
-	return certifications, err
+
+	query := r.db.Model(&models.CareerCertification{})
+
+	if filter.Issuer != "" {
+		query = query.Where("LOWER(issuer) LIKE ?", "%"+strings.ToLower(filter.Issuer)+"%")
+	}
+
+	if filter.IssuedFrom != nil {
+		query = query.Where("date(issue_date) >= ?", filter.IssuedFrom.Format("2006-01-02"))
+	}
+
+	if filter.IssuedTo != nil {
+		query = query.Where("date(issue_date) <= ?", filter.IssuedTo.Format("2006-01-02"))
+	}
+
+	if filter.Status != "" {
+		today := filter.Now.UTC().Format(time.DateOnly)
+		until := filter.Now.UTC().AddDate(0, 0, filter.NoticeDays).Format(time.DateOnly)
+
+		switch filter.Status {
+		case models.CertificationExpired:
+			query = query.Where("expiry_date IS NOT NULL AND date(expiry_date) < ?", today)
+		case models.CertificationExpiring:
+			query = query.Where("date(expiry_date) >= ? AND date(expiry_date) <= ?", today, until)
+		case models.CertificationValid:
+			query = query.Where("(expiry_date IS NULL OR date(expiry_date) > ?)", until)
+		}
+	}
+
+	total, err := findPage(query, opts, "issue_date DESC", &certifications)
+	return certifications, total, err
 }
 
 // FindByID retrieves a CareerCertification by its ID from the database. Returns the record or an error if not found.
@@ -44,12 +110,102 @@ func (r *careerCertificationRepository) FindByID(id uint) (*models.CareerCertifi
 	return &certification, err
 }
 
+// FindByIDWithDeleted retrieves a CareerCertification by its ID, even when it is soft-deleted.
+func (r *careerCertificationRepository) FindByIDWithDeleted(id uint) (*models.CareerCertification, error) {
+	var certification models.CareerCertification
+	err := r.db.Unscoped().First(&certification, id).Error
+	return &certification, err
+}
+
+// FindByContentHash retrieves the certification whose stored file has the given SHA-256.
+// It returns gorm.ErrRecordNotFound when no certification has that file.
+func (r *careerCertificationRepository) FindByContentHash(hash string) (*models.CareerCertification, error) {
+	var certification models.CareerCertification
+	err := r.db.Where("content_hash = ?", hash).First(&certification).Error
+	return &certification, err
+}
+
+// FindAllInCreationOrder retrieves every career certification, oldest first and without paging.
+func (r *careerCertificationRepository) FindAllInCreationOrder() ([]models.CareerCertification, error) {
+	var certifications []models.CareerCertification
+	if err := r.db.Order("id ASC").Find(&certifications).Error; err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// FindAllWithDeleted retrieves every career certification including the soft-deleted ones, oldest first.
+func (r *careerCertificationRepository) FindAllWithDeleted() ([]models.CareerCertification, error) {
+	var certifications []models.CareerCertification
+	if err := r.db.Unscoped().Order("id ASC").Find(&certifications).Error; err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// FindExpiringUnnotified retrieves the certifications expiring between the two dates, inclusive, whose expiry
+// has not been notified yet, soonest first.
+func (r *careerCertificationRepository) FindExpiringUnnotified(from, until time.Time) ([]models.CareerCertification, error) {
+	var certifications []models.CareerCertification
+	err := r.db.
+		Where("expiry_notified_at IS NULL").
+		Where("date(expiry_date) >= ? AND date(expiry_date) <= ?", from.UTC().Format(time.DateOnly), until.UTC().Format(time.DateOnly)).
+		Order("expiry_date ASC").
+		Find(&certifications).Error
+	if err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// MarkExpiryNotified records that the expiry of the given certifications was notified at the given time.
+func (r *careerCertificationRepository) MarkExpiryNotified(ids []uint, at time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Model(&models.CareerCertification{}).Where("id IN ?", ids).Update("expiry_notified_at", at).Error
+}
+
 // Update updates fields of a CareerCertification in the database identified by the given ID using the provided map of updates.
+// It returns gorm.ErrRecordNotFound when no certification has that ID.
 func (r *careerCertificationRepository) Update(id uint, updates map[string]interface{}) error {
-	return r.db.Model(&models.CareerCertification{}).Where("id = ?", id).Updates(updates).Error
+	result := r.db.Model(&models.CareerCertification{}).Where("id = ?", id).Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
 }
 
 // Delete removes a CareerCertification record from the database by its ID and returns an error if the operation fails.
 func (r *careerCertificationRepository) Delete(id uint) error {
 	return r.db.Delete(&models.CareerCertification{}, id).Error
 }
+
+// Restore brings back a soft-deleted CareerCertification.
+// It returns gorm.ErrRecordNotFound when no deleted certification has that ID.
+func (r *careerCertificationRepository) Restore(id uint) error {
+	result := r.db.Unscoped().Model(&models.CareerCertification{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Purge permanently removes a CareerCertification record, deleted or not.
+func (r *careerCertificationRepository) Purge(id uint) error {
+	return r.db.Unscoped().Delete(&models.CareerCertification{}, id).Error
+}
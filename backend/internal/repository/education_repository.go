@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// EducationRepository defines the interface for education data operations
+type EducationRepository interface {
+	FindAll(filter EducationFilter, opts ListOptions) ([]models.Education, int64, error)
+	FindByID(id uint) (*models.Education, error)
+	Create(education *models.Education) error
+	Update(id uint, updates map[string]interface{}) error
+	Delete(id uint) error
+}
+
+// EducationFilter holds the optional criteria used to narrow down education listings
+type EducationFilter struct {
+	StudyType string
+	StartFrom *time.Time
+	StartTo   *time.Time
+}
+
+// educationRepository implements EducationRepository interface
+type educationRepository struct {
+	db *gorm.DB
+}
+
+// NewEducationRepository creates a new instance of EducationRepository
+func NewEducationRepository(db *gorm.DB) EducationRepository {
+	return &educationRepository{db: db}
+}
+
+// FindAll retrieves the education entries matching the filter along with the total count
+func (r *educationRepository) FindAll(filter EducationFilter, opts ListOptions) ([]models.Education, int64, error) {
+	var education []models.Education
+
+	query := r.db.Model(&models.Education{})
+
+	if filter.StudyType != "" {
+		query = query.Where("LOWER(study_type) = LOWER(?)", filter.StudyType)
+	}
+
+	if filter.StartFrom != nil {
+		query = query.Where("start_date >= ?", filter.StartFrom.Format("2006-01-02"))
+	}
+
+	if filter.StartTo != nil {
+		query = query.Where("start_date <= ?", filter.StartTo.Format("2006-01-02"))
+	}
+
+	total, err := findPage(query, opts, "start_date DESC", &education)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return education, total, nil
+}
+
+// FindByID retrieves a single education entry by ID
+func (r *educationRepository) FindByID(id uint) (*models.Education, error) {
+	var education models.Education
+
+	result := r.db.First(&education, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &education, nil
+}
+
+// Create inserts a new education entry into the database
+func (r *educationRepository) Create(education *models.Education) error {
+	result := r.db.Create(education)
+	return result.Error
+}
+
+// Update modifies an existing education entry in the database
+func (r *educationRepository) Update(id uint, updates map[string]interface{}) error {
+	result := r.db.Model(&models.Education{}).Where("id = ?", id).Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Delete removes an education entry from the database
+func (r *educationRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Education{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -42,8 +42,15 @@ func (r *experienceClientRepository) FindByID(id uint) (*models.ExperienceClient
 	return &client, nil
 }
 
+// Create inserts a client of an experience that is not deleted, otherwise it returns gorm.ErrRecordNotFound
 func (r *experienceClientRepository) Create(client *models.ExperienceClient) error {
-	return r.db.Create(client).Error
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var experience models.Experience
+		if err := tx.Select("id").First(&experience, client.ExperienceID).Error; err != nil {
+			return err
+		}
+		return tx.Create(client).Error
+	})
 }
 
 func (r *experienceClientRepository) Update(id uint, updates map[string]interface{}) error {
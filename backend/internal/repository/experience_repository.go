@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"gorm.io/gorm"
@@ -9,13 +11,22 @@ import (
 
 // ExperienceRepository defines the interface for experience data operations
 type ExperienceRepository interface {
-	FindAll() ([]models.Experience, error)
+	FindAll(filter ExperienceFilter, opts ListOptions) ([]models.Experience, int64, error)
 	FindByID(id uint) (*models.Experience, error)
 	Create(experience *models.Experience) error
 	Update(id uint, updates map[string]interface{}) error
 	Delete(id uint) error
 }
 
+// ExperienceFilter holds the optional criteria used to narrow down experience listings
+type ExperienceFilter struct {
+	Type      string
+	Company   string
+	Current   *bool
+	StartFrom *time.Time
+	StartTo   *time.Time
+}
+
 // experienceRepository implements ExperienceRepository interface
 type experienceRepository struct {
 	db *gorm.DB
@@ -26,17 +37,42 @@ func NewExperienceRepository(db *gorm.DB) ExperienceRepository {
 	return &experienceRepository{db: db}
 }
 
-// FindAll retrieves all experiences from the database
-func (r *experienceRepository) FindAll() ([]models.Experience, error) {
+// FindAll retrieves the experiences matching the filter along with the total count
+func (r *experienceRepository) FindAll(filter ExperienceFilter, opts ListOptions) ([]models.Experience, int64, error) {
 	var experiences []models.Experience
 
-	result := r.db.Order("start_date DESC").Find(&experiences)
+	query := r.db.Model(&models.Experience{})
 
-	if result.Error != nil {
-		return nil, result.Error
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+
+	if filter.Company != "" {
+		query = query.Where("LOWER(company) LIKE ?", "%"+strings.ToLower(filter.Company)+"%")
+	}
+
+	if filter.Current != nil {
+		if *filter.Current {
+			query = query.Where("end_date IS NULL")
+		} else {
+			query = query.Where("end_date IS NOT NULL")
+		}
+	}
+
+	if filter.StartFrom != nil {
+		query = query.Where("start_date >= ?", filter.StartFrom.Format("2006-01-02"))
+	}
+
+	if filter.StartTo != nil {
+		query = query.Where("start_date <= ?", filter.StartTo.Format("2006-01-02"))
 	}
 
-	return experiences, nil
+	total, err := findPage(query, opts, "start_date DESC", &experiences)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return experiences, total, nil
 }
 
 // FindByID retrieves a single experience by ID
@@ -75,16 +111,21 @@ func (r *experienceRepository) Update(id uint, updates map[string]interface{}) e
 	return nil
 }
 
-// Delete removes an experience from the database
+// Delete soft-deletes an experience along with its clients in a single transaction. They share the deletion time,
+// which tells the clients deleted with the experience apart from those deleted before.
 func (r *experienceRepository) Delete(id uint) error {
-	result := r.db.Delete(&models.Experience{}, id)
-	if result.Error != nil {
-		return result.Error
-	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		deletedAt := time.Now()
 
-	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
-	}
+		result := tx.Model(&models.Experience{}).Where("id = ?", id).UpdateColumn("deleted_at", deletedAt)
+		if result.Error != nil {
+			return result.Error
+		}
 
-	return nil
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return tx.Model(&models.ExperienceClient{}).Where("experience_id = ?", id).UpdateColumn("deleted_at", deletedAt).Error
+	})
 }
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// LanguageRepository defines the interface for spoken language data operations
+type LanguageRepository interface {
+	FindAll(opts ListOptions) ([]models.Language, int64, error)
+	FindByID(id uint) (*models.Language, error)
+	FindByName(name string) (*models.Language, error)
+	Create(language *models.Language) error
+	Update(id uint, updates map[string]interface{}) error
+	Delete(id uint) error
+}
+
+// languageRepository implements LanguageRepository interface
+type languageRepository struct {
+	db *gorm.DB
+}
+
+// NewLanguageRepository creates a new instance of LanguageRepository
+func NewLanguageRepository(db *gorm.DB) LanguageRepository {
+	return &languageRepository{db: db}
+}
+
+// FindAll retrieves the languages along with the total count, in creation order by default
+func (r *languageRepository) FindAll(opts ListOptions) ([]models.Language, int64, error) {
+	var languages []models.Language
+
+	total, err := findPage(r.db.Model(&models.Language{}), opts, "id ASC", &languages)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return languages, total, nil
+}
+
+// FindByID retrieves a single language by ID
+func (r *languageRepository) FindByID(id uint) (*models.Language, error) {
+	var language models.Language
+
+	result := r.db.First(&language, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &language, nil
+}
+
+// FindByName retrieves a language by its case-insensitive name
+func (r *languageRepository) FindByName(name string) (*models.Language, error) {
+	var language models.Language
+
+	result := r.db.Where("name = ? COLLATE NOCASE", name).First(&language)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &language, nil
+}
+
+// Create inserts a new language into the database
+func (r *languageRepository) Create(language *models.Language) error {
+	result := r.db.Create(language)
+	return result.Error
+}
+
+// Update modifies an existing language in the database
+func (r *languageRepository) Update(id uint, updates map[string]interface{}) error {
+	result := r.db.Model(&models.Language{}).Where("id = ?", id).Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a language from the database
+func (r *languageRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Language{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
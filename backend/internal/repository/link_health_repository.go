@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LinkHealthRepository reads the links of the portfolio records and stores the outcome of their checks
+type LinkHealthRepository interface {
+	FindLinks() ([]models.Link, error)
+	FindChecks() ([]models.LinkCheck, error)
+	SaveCheck(check *models.LinkCheck) error
+	DeleteChecks(ids []uint) error
+}
+
+type linkHealthRepository struct {
+	db *gorm.DB
+}
+
+// NewLinkHealthRepository creates a new instance of LinkHealthRepository
+func NewLinkHealthRepository(db *gorm.DB) LinkHealthRepository {
+	return &linkHealthRepository{db: db}
+}
+
+// FindLinks retrieves the non-empty URL of every certification, experience, experience client and project
+// that is not deleted
+func (r *linkHealthRepository) FindLinks() ([]models.Link, error) {
+	var links []models.Link
+	err := r.db.Raw(`
+		SELECT ? AS owner_type, id AS owner_id, title AS label, credential_url AS url
+		FROM career_certifications WHERE deleted_at IS NULL AND credential_url IS NOT NULL AND credential_url <> ''
+		UNION ALL
+		SELECT ?, id, title || ' at ' || company, url
+		FROM experiences WHERE deleted_at IS NULL AND url IS NOT NULL AND url <> ''
+		UNION ALL
+		SELECT ?, id, name, url
+		FROM experience_clients WHERE deleted_at IS NULL AND url IS NOT NULL AND url <> ''
+		UNION ALL
+		SELECT ?, id, name, url
+		FROM projects WHERE deleted_at IS NULL AND url IS NOT NULL AND url <> ''
+		ORDER BY owner_type, owner_id`,
+		models.LinkOwnerCertification, models.LinkOwnerExperience, models.LinkOwnerExperienceClient, models.LinkOwnerProject,
+	).Scan(&links).Error
+	if err != nil {
+		return nil, err
+	}
+	return links, nil
+}
+
+// FindChecks retrieves the last check of every link
+func (r *linkHealthRepository) FindChecks() ([]models.LinkCheck, error) {
+	var checks []models.LinkCheck
+	if err := r.db.Order("owner_type, owner_id").Find(&checks).Error; err != nil {
+		return nil, err
+	}
+	return checks, nil
+}
+
+// SaveCheck stores the check of a link, replacing the previous check of the same record
+func (r *linkHealthRepository) SaveCheck(check *models.LinkCheck) error {
+	return r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "owner_type"}, {Name: "owner_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"url", "status", "status_code", "error", "consecutive_failures", "checked_at"}),
+	}).Create(check).Error
+}
+
+// DeleteChecks removes the checks with the given IDs
+func (r *linkHealthRepository) DeleteChecks(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.Delete(&models.LinkCheck{}, ids).Error
+}
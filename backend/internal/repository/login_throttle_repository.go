@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// LoginThrottleRepository stores the failed login counters of accounts and IP addresses
+type LoginThrottleRepository interface {
+	Find(scope, key string) (*models.LoginThrottle, error)
+	FindAll() ([]models.LoginThrottle, error)
+	RecordFailure(scope, key string, resetBefore time.Time) (*models.LoginThrottle, error)
+	Lock(id uint, until time.Time) error
+	Delete(scope, key string) (int64, error)
+	DeleteAll() (int64, error)
+	DeleteStale(before time.Time) error
+}
+
+type loginThrottleRepository struct {
+	db *gorm.DB
+}
+
+// NewLoginThrottleRepository creates a new instance of LoginThrottleRepository
+func NewLoginThrottleRepository(db *gorm.DB) LoginThrottleRepository {
+	return &loginThrottleRepository{db: db}
+}
+
+// Find retrieves the counter of a key, gorm.ErrRecordNotFound when it has no recent failure
+func (r *loginThrottleRepository) Find(scope, key string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	if err := r.db.Where("scope = ? AND key = ?", scope, key).First(&throttle).Error; err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// FindAll retrieves every counter, the most recent failures first
+func (r *loginThrottleRepository) FindAll() ([]models.LoginThrottle, error) {
+	var throttles []models.LoginThrottle
+	if err := r.db.Order("last_failure_at DESC").Find(&throttles).Error; err != nil {
+		return nil, err
+	}
+	return throttles, nil
+}
+
+// RecordFailure counts a failed login of a key and returns the updated counter. The count starts over when the
+// previous failure happened before resetBefore.
+func (r *loginThrottleRepository) RecordFailure(scope, key string, resetBefore time.Time) (*models.LoginThrottle, error) {
+	now := time.Now()
+	throttle := models.LoginThrottle{Scope: scope, Key: key, Failures: 1, LastFailureAt: now}
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "scope"}, {Name: "key"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failures":        gorm.Expr("CASE WHEN last_failure_at < ? THEN 1 ELSE failures + 1 END", resetBefore),
+			"last_failure_at": now,
+			"updated_at":      now,
+		}),
+	}).Create(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return r.Find(scope, key)
+}
+
+// Lock locks a key out until the given time
+func (r *loginThrottleRepository) Lock(id uint, until time.Time) error {
+	return r.db.Model(&models.LoginThrottle{}).Where("id = ?", id).Update("locked_until", until).Error
+}
+
+// Delete removes the counter of a key and returns how many were removed
+func (r *loginThrottleRepository) Delete(scope, key string) (int64, error) {
+	result := r.db.Where("scope = ? AND key = ?", scope, key).Delete(&models.LoginThrottle{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteAll removes every counter and returns how many were removed
+func (r *loginThrottleRepository) DeleteAll() (int64, error) {
+	result := r.db.Where("1 = 1").Delete(&models.LoginThrottle{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteStale removes the counters whose last failure happened before the given time and that are not locked
+func (r *loginThrottleRepository) DeleteStale(before time.Time) error {
+	return r.db.Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until < ?)", before, time.Now()).
+		Delete(&models.LoginThrottle{}).Error
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"errors"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ProfileRepository defines the interface for the singleton profile data operations
+type ProfileRepository interface {
+	Find() (*models.Profile, error)
+	Create(profile *models.Profile) error
+	Replace(updates map[string]interface{}, socialProfiles []models.SocialProfile) error
+	UpdateAvatar(url, fileName string) error
+}
+
+// profileRepository implements ProfileRepository interface
+type profileRepository struct {
+	db *gorm.DB
+}
+
+// NewProfileRepository creates a new instance of ProfileRepository
+func NewProfileRepository(db *gorm.DB) ProfileRepository {
+	return &profileRepository{db: db}
+}
+
+// Find retrieves the profile with its social profiles
+func (r *profileRepository) Find() (*models.Profile, error) {
+	var profile models.Profile
+
+	result := r.db.Preload("SocialProfiles", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).First(&profile, models.ProfileID)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &profile, nil
+}
+
+// Create inserts the profile and its social profiles
+func (r *profileRepository) Create(profile *models.Profile) error {
+	profile.ID = models.ProfileID
+	result := r.db.Create(profile)
+	return result.Error
+}
+
+// Replace overwrites the profile fields and replaces its social profiles in a single transaction
+func (r *profileRepository) Replace(updates map[string]interface{}, socialProfiles []models.SocialProfile) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Profile{}).Where("id = ?", models.ProfileID).Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if err := tx.Unscoped().Where("profile_id = ?", models.ProfileID).Delete(&models.SocialProfile{}).Error; err != nil {
+			return err
+		}
+
+		for i := range socialProfiles {
+			socialProfiles[i].ProfileID = models.ProfileID
+		}
+		if len(socialProfiles) > 0 {
+			if err := tx.Create(&socialProfiles).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// UpdateAvatar stores the public URL and file name of the profile picture
+func (r *profileRepository) UpdateAvatar(url, fileName string) error {
+	result := r.db.Model(&models.Profile{}).Where("id = ?", models.ProfileID).Updates(map[string]interface{}{
+		"avatar_url":       url,
+		"avatar_file_name": fileName,
+	})
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
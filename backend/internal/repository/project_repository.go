@@ -2,6 +2,8 @@ package repository
 
 import (
 	"errors"
+	"strings"
+	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"gorm.io/gorm"
@@ -9,19 +11,26 @@ import (
 
 // ProjectRepository defines a contract for operations on the Project model.
 // It includes methods for CRUD operations and retrieval of project data.
-// FindAll retrieves all projects, ordered by the default criteria.
+// FindAll retrieves a page of projects matching the filter, along with the total count.
 // FindByID retrieves a single project by its unique identifier.
 // Create adds a new project to the repository.
 // Update modifies the details of an existing project identified by ID.
 // Delete removes a project by its ID from the repository.
 type ProjectRepository interface {
-	FindAll() ([]models.Project, error)
+	FindAll(filter ProjectFilter, opts ListOptions) ([]models.Project, int64, error)
 	FindByID(id uint) (*models.Project, error)
 	Create(project *models.Project) error
 	Update(id uint, updates map[string]interface{}) error
 	Delete(id uint) error
 }
 
+// ProjectFilter holds the optional criteria used to narrow down project listings.
+type ProjectFilter struct {
+	Technology string
+	StartFrom  *time.Time
+	StartTo    *time.Time
+}
+
 // projectRepository is a struct that interacts with the database to manage Project entities using gorm.DB.
 type projectRepository struct {
 	db *gorm.DB
@@ -32,17 +41,31 @@ func NewProjectRepository(db *gorm.DB) ProjectRepository {
 	return &projectRepository{db: db}
 }
 
-// FindAll retrieves all projects from the database, ordered by start_date in descending order. Returns a slice of projects or an error.
-func (p *projectRepository) FindAll() ([]models.Project, error) {
+// FindAll retrieves the projects matching the filter, ordered by start_date in descending order unless a sort is given.
+// Returns the requested page of projects and the total number of matching rows.
+func (p *projectRepository) FindAll(filter ProjectFilter, opts ListOptions) ([]models.Project, int64, error) {
 	var projects []models.Project
 
-	result := p.db.Order("start_date DESC").Find(&projects)
+	query := p.db.Model(&models.Project{})
 
-	if result.Error != nil {
-		return nil, result.Error
+	if filter.Technology != "" {
+		query = query.Where("LOWER(technologies) LIKE ?", "%"+strings.ToLower(filter.Technology)+"%")
+	}
+
+	if filter.StartFrom != nil {
+		query = query.Where("start_date >= ?", filter.StartFrom.Format("2006-01-02"))
+	}
+
+	if filter.StartTo != nil {
+		query = query.Where("start_date <= ?", filter.StartTo.Format("2006-01-02"))
+	}
+
+	total, err := findPage(query, opts, "start_date DESC", &projects)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	return projects, nil
+	return projects, total, nil
 }
 
 // FindByID retrieves a single project by its unique identifier (ID) from the database.
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SortField represents a single column ordering. Column names must come from a whitelist.
+type SortField struct {
+	Column string
+	Desc   bool
+}
+
+// ListOptions holds the pagination and sorting options shared by every list query.
+// A Limit of zero disables pagination and returns every matching row.
+type ListOptions struct {
+	Page  int
+	Limit int
+	Sort  []SortField
+}
+
+// Offset returns the number of rows to skip for the current page.
+func (o ListOptions) Offset() int {
+	if o.Page <= 1 || o.Limit <= 0 {
+		return 0
+	}
+	return (o.Page - 1) * o.Limit
+}
+
+// applyListOptions applies the requested ordering (or the default one) and limit/offset to the query.
+func applyListOptions(db *gorm.DB, opts ListOptions, defaultOrder string) *gorm.DB {
+	if len(opts.Sort) == 0 {
+		db = db.Order(defaultOrder)
+	}
+
+	for _, field := range opts.Sort {
+		db = db.Order(clause.OrderByColumn{
+			Column: clause.Column{Name: field.Column},
+			Desc:   field.Desc,
+		})
+	}
+
+	if opts.Limit > 0 {
+		db = db.Limit(opts.Limit).Offset(opts.Offset())
+	}
+
+	return db
+}
+
+// findPage counts the rows matched by the filtered query and then fetches the requested page into dest.
+func findPage(query *gorm.DB, opts ListOptions, defaultOrder string, dest interface{}) (int64, error) {
+	var total int64
+	if err := query.Session(&gorm.Session{}).Count(&total).Error; err != nil {
+		return 0, err
+	}
+
+	if err := applyListOptions(query, opts, defaultOrder).Find(dest).Error; err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
@@ -0,0 +1,224 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// resumeTables are the tables whose rows end up in the résumé
+var resumeTables = []string{
+	"experiences", "experience_clients", "projects", "career_certifications",
+	"education", "skills", "languages", "profiles", "social_profiles",
+}
+
+// resumeLinkTables are the join tables linking the skills to the records of the résumé, with the two columns of
+// their key. They have no timestamps, so their whole content goes into the fingerprint.
+var resumeLinkTables = [][3]string{
+	{"project_skills", "skill_id", "project_id"},
+	{"experience_client_skills", "skill_id", "experience_client_id"},
+}
+
+// ResumeRepository loads the records that make up the JSON Resume document and
+// applies résumé imports atomically
+type ResumeRepository interface {
+	FindProfile() (*models.Profile, error)
+	FindExperiences() ([]models.Experience, error)
+	FindExperienceClients() ([]models.ExperienceClient, error)
+	FindProjects() ([]models.Project, error)
+	FindCertifications() ([]models.CareerCertification, error)
+	FindEducation() ([]models.Education, error)
+	FindSkills() ([]models.Skill, error)
+	FindLanguages() ([]models.Language, error)
+	ApplyImport(changes ResumeChanges) error
+	Fingerprint() (string, error)
+}
+
+// RecordUpdate is a partial update of a single row
+type RecordUpdate struct {
+	ID      uint
+	Updates map[string]interface{}
+}
+
+// ResumeChanges groups every insert and update produced by a résumé import
+type ResumeChanges struct {
+	NewExperiences       []*models.Experience
+	ExperienceUpdates    []RecordUpdate
+	NewProjects          []*models.Project
+	ProjectUpdates       []RecordUpdate
+	NewCertifications    []*models.CareerCertification
+	CertificationUpdates []RecordUpdate
+}
+
+type resumeRepository struct {
+	db *gorm.DB
+}
+
+// NewResumeRepository creates a new instance of ResumeRepository
+func NewResumeRepository(db *gorm.DB) ResumeRepository {
+	return &resumeRepository{db: db}
+}
+
+// FindProfile retrieves the profile with its social profiles, or nil when it has not been created yet
+func (r *resumeRepository) FindProfile() (*models.Profile, error) {
+	var profiles []models.Profile
+	err := r.db.Preload("SocialProfiles", func(db *gorm.DB) *gorm.DB {
+		return db.Order("id ASC")
+	}).Limit(1).Find(&profiles, models.ProfileID).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, nil
+	}
+	return &profiles[0], nil
+}
+
+// FindExperiences retrieves every experience, most recent first
+func (r *resumeRepository) FindExperiences() ([]models.Experience, error) {
+	var experiences []models.Experience
+	if err := r.db.Order("start_date DESC").Find(&experiences).Error; err != nil {
+		return nil, err
+	}
+	return experiences, nil
+}
+
+// FindExperienceClients retrieves every experience client, most recent first
+func (r *resumeRepository) FindExperienceClients() ([]models.ExperienceClient, error) {
+	var clients []models.ExperienceClient
+	if err := r.db.Order("end_date IS NULL DESC, end_date DESC").Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	return clients, nil
+}
+
+// FindProjects retrieves every project, most recent first
+func (r *resumeRepository) FindProjects() ([]models.Project, error) {
+	var projects []models.Project
+	if err := r.db.Order("start_date DESC").Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// FindCertifications retrieves every certification, most recent first
+func (r *resumeRepository) FindCertifications() ([]models.CareerCertification, error) {
+	var certifications []models.CareerCertification
+	if err := r.db.Order("issue_date DESC").Find(&certifications).Error; err != nil {
+		return nil, err
+	}
+	return certifications, nil
+}
+
+// FindEducation retrieves every education entry, most recent first
+func (r *resumeRepository) FindEducation() ([]models.Education, error) {
+	var education []models.Education
+	if err := r.db.Order("start_date DESC").Find(&education).Error; err != nil {
+		return nil, err
+	}
+	return education, nil
+}
+
+// FindSkills retrieves every skill grouped by category
+func (r *resumeRepository) FindSkills() ([]models.Skill, error) {
+	var skills []models.Skill
+	if err := r.db.Order("category ASC, name ASC").Find(&skills).Error; err != nil {
+		return nil, err
+	}
+	return skills, nil
+}
+
+// FindLanguages retrieves every language in creation order
+func (r *resumeRepository) FindLanguages() ([]models.Language, error) {
+	var languages []models.Language
+	if err := r.db.Order("id ASC").Find(&languages).Error; err != nil {
+		return nil, err
+	}
+	return languages, nil
+}
+
+// ApplyImport writes all the changes in a single transaction, so a failing row leaves the data untouched
+func (r *resumeRepository) ApplyImport(changes ResumeChanges) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for _, experience := range changes.NewExperiences {
+			if err := tx.Create(experience).Error; err != nil {
+				return err
+			}
+		}
+		if err := applyUpdates(tx, &models.Experience{}, changes.ExperienceUpdates); err != nil {
+			return err
+		}
+
+		for _, project := range changes.NewProjects {
+			if err := tx.Create(project).Error; err != nil {
+				return err
+			}
+		}
+		if err := applyUpdates(tx, &models.Project{}, changes.ProjectUpdates); err != nil {
+			return err
+		}
+
+		for _, certification := range changes.NewCertifications {
+			if err := tx.Create(certification).Error; err != nil {
+				return err
+			}
+		}
+		return applyUpdates(tx, &models.CareerCertification{}, changes.CertificationUpdates)
+	})
+}
+
+// applyUpdates runs each partial update against the table of the given model
+func applyUpdates(tx *gorm.DB, model interface{}, updates []RecordUpdate) error {
+	for _, update := range updates {
+		result := tx.Model(model).Where("id = ?", update.ID).Updates(update.Updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+	}
+	return nil
+}
+
+// Fingerprint summarizes the row count and the latest update and deletion of every résumé table, and the links
+// between the skills and the other records. The value changes whenever a row is created, updated, soft-deleted
+// or removed, or a skill is linked or unlinked.
+func (r *resumeRepository) Fingerprint() (string, error) {
+	parts := make([]string, 0, len(resumeTables)+len(resumeLinkTables))
+	for _, table := range resumeTables {
+		var row struct {
+			Total       int64
+			LastUpdated sql.NullString
+			LastDeleted sql.NullString
+		}
+
+		query := fmt.Sprintf(
+			"SELECT COUNT(*) AS total, CAST(MAX(updated_at) AS TEXT) AS last_updated, CAST(MAX(deleted_at) AS TEXT) AS last_deleted FROM %s",
+			table,
+		)
+		if err := r.db.Raw(query).Scan(&row).Error; err != nil {
+			return "", err
+		}
+
+		parts = append(parts, fmt.Sprintf("%s:%d:%s:%s", table, row.Total, row.LastUpdated.String, row.LastDeleted.String))
+	}
+
+	for _, link := range resumeLinkTables {
+		var pairs sql.NullString
+		query := fmt.Sprintf(
+			"SELECT group_concat(%[2]s || '-' || %[3]s, ',') FROM (SELECT %[2]s, %[3]s FROM %[1]s ORDER BY %[2]s, %[3]s)",
+			link[0], link[1], link[2],
+		)
+		if err := r.db.Raw(query).Row().Scan(&pairs); err != nil {
+			return "", err
+		}
+
+		parts = append(parts, fmt.Sprintf("%s:%s", link[0], pairs.String))
+	}
+
+	return strings.Join(parts, "|"), nil
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func TestFingerprintChangesWithSkillLinks(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database: %v", err)
+	}
+	// Every connection to :memory: is a different database
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(&models.Experience{}, &models.ExperienceClient{}, &models.Project{}, &models.CareerCertification{},
+		&models.Education{}, &models.Skill{}, &models.Language{}, &models.Profile{}, &models.SocialProfile{}); err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+
+	skills := []models.Skill{{Name: "Go"}, {Name: "SQL"}}
+	if err := db.Create(&skills).Error; err != nil {
+		t.Fatalf("failed to create skills: %v", err)
+	}
+	project := models.Project{Name: "Portfolio", StartDate: utils.Date{Time: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}}
+	if err := db.Create(&project).Error; err != nil {
+		t.Fatalf("failed to create project: %v", err)
+	}
+
+	repo := NewResumeRepository(db)
+	fingerprint := func() string {
+		value, err := repo.Fingerprint()
+		if err != nil {
+			t.Fatalf("Fingerprint returned an error: %v", err)
+		}
+		return value
+	}
+	link := func(skillID uint) {
+		if err := db.Exec("INSERT INTO project_skills (skill_id, project_id) VALUES (?, ?)", skillID, project.ID).Error; err != nil {
+			t.Fatalf("failed to link skill %d: %v", skillID, err)
+		}
+	}
+	unlink := func(skillID uint) {
+		if err := db.Exec("DELETE FROM project_skills WHERE skill_id = ?", skillID).Error; err != nil {
+			t.Fatalf("failed to unlink skill %d: %v", skillID, err)
+		}
+	}
+
+	unlinked := fingerprint()
+
+	link(skills[0].ID)
+	linked := fingerprint()
+	if linked == unlinked {
+		t.Error("linking a skill did not change the fingerprint")
+	}
+
+	// Same number of links, another skill
+	unlink(skills[0].ID)
+	link(skills[1].ID)
+	if swapped := fingerprint(); swapped == linked || swapped == unlinked {
+		t.Error("swapping the linked skill did not change the fingerprint")
+	}
+
+	unlink(skills[1].ID)
+	if again := fingerprint(); again != unlinked {
+		t.Errorf("fingerprint without links = %q, want %q", again, unlinked)
+	}
+}
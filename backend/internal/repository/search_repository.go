@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"html"
+	"strings"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// Column weights used by bm25 for (kind, ref_id, parent_id, title, subtitle, body)
+const searchRankWeights = "0.0, 0.0, 0.0, 10.0, 5.0, 1.0"
+
+// The matched terms are delimited by control characters, swapped for <mark> tags once the text is HTML-escaped
+const (
+	matchStart = "\x02"
+	matchEnd   = "\x03"
+)
+
+// highlightMarkup escapes the stored text and turns the match delimiters into <mark> tags
+var highlightMarkup = strings.NewReplacer(matchStart, "<mark>", matchEnd, "</mark>")
+
+// SearchRepository queries the search_index FTS5 virtual table
+type SearchRepository interface {
+	Search(match string, kinds []string, limit int) ([]models.SearchHit, error)
+}
+
+type searchRepository struct {
+	db *gorm.DB
+}
+
+// NewSearchRepository creates a new instance of SearchRepository
+func NewSearchRepository(db *gorm.DB) SearchRepository {
+	return &searchRepository{db: db}
+}
+
+// Search runs an FTS5 MATCH query and returns the best ranked hits, optionally restricted to some kinds.
+// The match expression must already be a valid FTS5 query.
+func (r *searchRepository) Search(match string, kinds []string, limit int) ([]models.SearchHit, error) {
+	var hits []models.SearchHit
+
+	query := r.db.Table("search_index").
+		Select(`kind, ref_id, parent_id,
+			highlight(search_index, 3, ?, ?) AS title,
+			highlight(search_index, 4, ?, ?) AS subtitle,
+			snippet(search_index, 5, ?, ?, '…', 24) AS snippet,
+			-bm25(search_index, `+searchRankWeights+`) AS score`,
+			matchStart, matchEnd, matchStart, matchEnd, matchStart, matchEnd).
+		Where("search_index MATCH ?", match)
+
+	if len(kinds) > 0 {
+		query = query.Where("kind IN ?", kinds)
+	}
+
+	result := query.Order("bm25(search_index, " + searchRankWeights + ")").
+		Limit(limit).
+		Scan(&hits)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	// The text is stored as entered, only the <mark> tags are markup
+	for i := range hits {
+		hits[i].Title = highlightHTML(hits[i].Title)
+		hits[i].Subtitle = highlightHTML(hits[i].Subtitle)
+		hits[i].Snippet = highlightHTML(hits[i].Snippet)
+	}
+
+	return hits, nil
+}
+
+// highlightHTML returns the highlighted text as HTML: escaped, with the matched terms wrapped in <mark> tags
+func highlightHTML(text string) string {
+	return highlightMarkup.Replace(html.EscapeString(text))
+}
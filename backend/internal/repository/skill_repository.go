@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// ErrLinkedRecordNotFound is returned when a skill is linked to a project or experience client that does not exist
+var ErrLinkedRecordNotFound = errors.New("linked record not found")
+
+// SkillRepository defines the interface for skill data operations.
+// Skills are returned with their linked projects and experience clients.
+type SkillRepository interface {
+	FindAll(filter SkillFilter, opts ListOptions) ([]models.Skill, int64, error)
+	FindByID(id uint) (*models.Skill, error)
+	FindByName(name string) (*models.Skill, error)
+	Create(skill *models.Skill, links SkillLinks) error
+	Update(id uint, updates map[string]interface{}, links SkillLinks) error
+	Delete(id uint) error
+}
+
+// SkillFilter holds the optional criteria used to narrow down skill listings
+type SkillFilter struct {
+	Category  string
+	Level     string
+	ProjectID uint
+	ClientID  uint
+}
+
+// SkillLinks holds the projects and experience clients a skill is linked to.
+// A nil slice leaves the corresponding links untouched, an empty one removes them all.
+type SkillLinks struct {
+	ProjectIDs []uint
+	ClientIDs  []uint
+}
+
+// skillRepository implements SkillRepository interface
+type skillRepository struct {
+	db *gorm.DB
+}
+
+// NewSkillRepository creates a new instance of SkillRepository
+func NewSkillRepository(db *gorm.DB) SkillRepository {
+	return &skillRepository{db: db}
+}
+
+// withLinks preloads the minimal fields of the linked projects and experience clients
+func withLinks(db *gorm.DB) *gorm.DB {
+	return db.
+		Preload("Projects", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "name").Order("start_date DESC")
+		}).
+		Preload("ExperienceClients", func(db *gorm.DB) *gorm.DB {
+			return db.Select("id", "experience_id", "name").Order("start_date DESC")
+		})
+}
+
+// FindAll retrieves the skills matching the filter along with the total count
+func (r *skillRepository) FindAll(filter SkillFilter, opts ListOptions) ([]models.Skill, int64, error) {
+	var skills []models.Skill
+
+	query := r.db.Model(&models.Skill{})
+
+	if filter.Category != "" {
+		query = query.Where("LOWER(category) = LOWER(?)", filter.Category)
+	}
+
+	if filter.Level != "" {
+		query = query.Where("level = ?", filter.Level)
+	}
+
+	if filter.ProjectID != 0 {
+		query = query.Where("id IN (SELECT skill_id FROM project_skills WHERE project_id = ?)", filter.ProjectID)
+	}
+
+	if filter.ClientID != 0 {
+		query = query.Where("id IN (SELECT skill_id FROM experience_client_skills WHERE experience_client_id = ?)", filter.ClientID)
+	}
+
+	total, err := findPage(withLinks(query), opts, "category ASC, name ASC", &skills)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return skills, total, nil
+}
+
+// FindByID retrieves a single skill by ID
+func (r *skillRepository) FindByID(id uint) (*models.Skill, error) {
+	var skill models.Skill
+
+	result := withLinks(r.db).First(&skill, id)
+	if result.Error != nil {
+		if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return nil, result.Error
+	}
+
+	return &skill, nil
+}
+
+// FindByName retrieves a skill by its case-insensitive name
+func (r *skillRepository) FindByName(name string) (*models.Skill, error) {
+	var skill models.Skill
+
+	result := r.db.Where("name = ? COLLATE NOCASE", name).First(&skill)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+
+	return &skill, nil
+}
+
+// Create inserts a new skill and its links in a single transaction
+func (r *skillRepository) Create(skill *models.Skill, links SkillLinks) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Projects", "ExperienceClients").Create(skill).Error; err != nil {
+			return err
+		}
+		return replaceSkillLinks(tx, skill.ID, links)
+	})
+}
+
+// Update modifies an existing skill and replaces the links that were provided
+func (r *skillRepository) Update(id uint, updates map[string]interface{}, links SkillLinks) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Skill{}).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		if len(updates) > 0 {
+			if err := tx.Model(&models.Skill{}).Where("id = ?", id).Updates(updates).Error; err != nil {
+				return err
+			}
+		}
+
+		return replaceSkillLinks(tx, id, links)
+	})
+}
+
+// Delete removes a skill from the database. Its links are kept so a restored skill gets them back.
+func (r *skillRepository) Delete(id uint) error {
+	result := r.db.Delete(&models.Skill{}, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// replaceSkillLinks rewrites the join table rows of the links that were provided
+func replaceSkillLinks(tx *gorm.DB, skillID uint, links SkillLinks) error {
+	if links.ProjectIDs != nil {
+		if err := replaceLinks(tx, "project_skills", "project_id", &models.Project{}, skillID, links.ProjectIDs); err != nil {
+			return err
+		}
+	}
+
+	if links.ClientIDs != nil {
+		if err := replaceLinks(tx, "experience_client_skills", "experience_client_id", &models.ExperienceClient{}, skillID, links.ClientIDs); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// replaceLinks checks that every linked record exists and replaces the skill rows of the join table
+func replaceLinks(tx *gorm.DB, table, column string, model interface{}, skillID uint, ids []uint) error {
+	if len(ids) > 0 {
+		var count int64
+		if err := tx.Model(model).Where("id IN ?", ids).Count(&count).Error; err != nil {
+			return err
+		}
+		if count != int64(len(ids)) {
+			return ErrLinkedRecordNotFound
+		}
+	}
+
+	if err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE skill_id = ?", table), skillID).Error; err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	rows := make([]map[string]interface{}, len(ids))
+	for i, id := range ids {
+		rows[i] = map[string]interface{}{"skill_id": skillID, column: id}
+	}
+	return tx.Table(table).Create(rows).Error
+}
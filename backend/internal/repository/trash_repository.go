@@ -0,0 +1,239 @@
+package repository
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// TrashRepository reads, restores and permanently deletes the soft-deleted experiences, experience clients,
+// projects and certifications
+type TrashRepository interface {
+	FindAll(deletedBefore *time.Time) ([]models.TrashItem, error)
+	FindByID(itemType string, id uint) (*models.TrashItem, error)
+	Restore(itemType string, id uint) error
+	Purge(itemType string, id uint) error
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+// NewTrashRepository creates a new instance of TrashRepository
+func NewTrashRepository(db *gorm.DB) TrashRepository {
+	return &trashRepository{db: db}
+}
+
+// FindAll retrieves every soft-deleted record, or only those deleted before the given time, most recently deleted first
+func (r *trashRepository) FindAll(deletedBefore *time.Time) ([]models.TrashItem, error) {
+	deleted := func() *gorm.DB {
+		query := r.db.Unscoped().Where("deleted_at IS NOT NULL")
+		if deletedBefore != nil {
+			// datetime() normalizes the timestamps to UTC whatever format the driver stored them in
+			query = query.Where("datetime(deleted_at) < datetime(?)", deletedBefore.UTC().Format(time.DateTime))
+		}
+		return query
+	}
+
+	var items []models.TrashItem
+
+	var experiences []models.Experience
+	if err := deleted().Find(&experiences).Error; err != nil {
+		return nil, err
+	}
+	for _, experience := range experiences {
+		items = append(items, experienceTrashItem(experience))
+	}
+
+	var clients []models.ExperienceClient
+	if err := deleted().Find(&clients).Error; err != nil {
+		return nil, err
+	}
+	for _, client := range clients {
+		items = append(items, clientTrashItem(client))
+	}
+
+	var projects []models.Project
+	if err := deleted().Find(&projects).Error; err != nil {
+		return nil, err
+	}
+	for _, project := range projects {
+		items = append(items, projectTrashItem(project))
+	}
+
+	var certifications []models.CareerCertification
+	if err := deleted().Find(&certifications).Error; err != nil {
+		return nil, err
+	}
+	for _, certification := range certifications {
+		items = append(items, certificationTrashItem(certification))
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return items[i].DeletedAt.After(items[j].DeletedAt)
+	})
+	return items, nil
+}
+
+// FindByID retrieves a soft-deleted record. It returns gorm.ErrRecordNotFound when the record does not exist or
+// is not deleted.
+func (r *trashRepository) FindByID(itemType string, id uint) (*models.TrashItem, error) {
+	deleted := r.db.Unscoped().Where("deleted_at IS NOT NULL")
+
+	var item models.TrashItem
+	switch itemType {
+	case models.TrashExperience:
+		var experience models.Experience
+		if err := deleted.First(&experience, id).Error; err != nil {
+			return nil, err
+		}
+		item = experienceTrashItem(experience)
+	case models.TrashExperienceClient:
+		var client models.ExperienceClient
+		if err := deleted.First(&client, id).Error; err != nil {
+			return nil, err
+		}
+		item = clientTrashItem(client)
+	case models.TrashProject:
+		var project models.Project
+		if err := deleted.First(&project, id).Error; err != nil {
+			return nil, err
+		}
+		item = projectTrashItem(project)
+	case models.TrashCertification:
+		var certification models.CareerCertification
+		if err := deleted.First(&certification, id).Error; err != nil {
+			return nil, err
+		}
+		item = certificationTrashItem(certification)
+	default:
+		return nil, fmt.Errorf("unknown trash item type: %s", itemType)
+	}
+	return &item, nil
+}
+
+// Restore clears the deletion of a soft-deleted record. An experience is restored together with the clients
+// deleted along with it, the clients deleted before stay in the trash.
+// It returns gorm.ErrRecordNotFound when the record does not exist or is not deleted.
+func (r *trashRepository) Restore(itemType string, id uint) error {
+	model, err := trashModel(itemType)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if itemType == models.TrashExperience {
+			deletedAt := tx.Unscoped().Model(&models.Experience{}).Select("deleted_at").Where("id = ?", id)
+			err := tx.Unscoped().Model(&models.ExperienceClient{}).
+				Where("experience_id = ? AND deleted_at = (?)", id, deletedAt).
+				Update("deleted_at", nil).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		result := tx.Unscoped().Model(model).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Update("deleted_at", nil)
+
+		if result.Error != nil {
+			return result.Error
+		}
+
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		return nil
+	})
+}
+
+// Purge permanently deletes a soft-deleted record along with its skill links and, for an experience, its clients.
+// It returns gorm.ErrRecordNotFound when the record does not exist or is not deleted.
+func (r *trashRepository) Purge(itemType string, id uint) error {
+	model, err := trashModel(itemType)
+	if err != nil {
+		return err
+	}
+
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Where("id = ? AND deleted_at IS NOT NULL", id).Delete(model)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+
+		switch itemType {
+		case models.TrashProject:
+			return tx.Exec("DELETE FROM project_skills WHERE project_id = ?", id).Error
+		case models.TrashExperienceClient:
+			return tx.Exec("DELETE FROM experience_client_skills WHERE experience_client_id = ?", id).Error
+		case models.TrashExperience:
+			clients := tx.Unscoped().Model(&models.ExperienceClient{}).Select("id").Where("experience_id = ?", id)
+			if err := tx.Exec("DELETE FROM experience_client_skills WHERE experience_client_id IN (?)", clients).Error; err != nil {
+				return err
+			}
+			return tx.Unscoped().Where("experience_id = ?", id).Delete(&models.ExperienceClient{}).Error
+		}
+		return nil
+	})
+}
+
+// trashModel returns the model a trash item type is stored as
+func trashModel(itemType string) (interface{}, error) {
+	switch itemType {
+	case models.TrashExperience:
+		return &models.Experience{}, nil
+	case models.TrashExperienceClient:
+		return &models.ExperienceClient{}, nil
+	case models.TrashProject:
+		return &models.Project{}, nil
+	case models.TrashCertification:
+		return &models.CareerCertification{}, nil
+	default:
+		return nil, fmt.Errorf("unknown trash item type: %s", itemType)
+	}
+}
+
+func experienceTrashItem(experience models.Experience) models.TrashItem {
+	return models.TrashItem{
+		Type:      models.TrashExperience,
+		ID:        experience.ID,
+		Label:     experience.Title + " at " + experience.Company,
+		DeletedAt: experience.DeletedAt.Time,
+	}
+}
+
+func clientTrashItem(client models.ExperienceClient) models.TrashItem {
+	experienceID := client.ExperienceID
+	return models.TrashItem{
+		Type:      models.TrashExperienceClient,
+		ID:        client.ID,
+		Label:     client.Name,
+		ParentID:  &experienceID,
+		DeletedAt: client.DeletedAt.Time,
+	}
+}
+
+func projectTrashItem(project models.Project) models.TrashItem {
+	return models.TrashItem{
+		Type:      models.TrashProject,
+		ID:        project.ID,
+		Label:     project.Name,
+		DeletedAt: project.DeletedAt.Time,
+	}
+}
+
+func certificationTrashItem(certification models.CareerCertification) models.TrashItem {
+	return models.TrashItem{
+		Type:      models.TrashCertification,
+		ID:        certification.ID,
+		Label:     certification.Title,
+		DeletedAt: certification.DeletedAt.Time,
+	}
+}
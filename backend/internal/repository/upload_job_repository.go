@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"gorm.io/gorm"
+)
+
+// UploadJobRepository persists the background certification upload jobs
+type UploadJobRepository interface {
+	Create(job *models.UploadJob) error
+	FindByID(id uint) (*models.UploadJob, error)
+	FindUnfinished() ([]models.UploadJob, error)
+	Update(id uint, updates map[string]interface{}) error
+}
+
+type uploadJobRepository struct {
+	db *gorm.DB
+}
+
+// NewUploadJobRepository creates a new instance of UploadJobRepository
+func NewUploadJobRepository(db *gorm.DB) UploadJobRepository {
+	return &uploadJobRepository{db: db}
+}
+
+// Create inserts a new upload job
+func (r *uploadJobRepository) Create(job *models.UploadJob) error {
+	return r.db.Create(job).Error
+}
+
+// FindByID retrieves an upload job by ID
+func (r *uploadJobRepository) FindByID(id uint) (*models.UploadJob, error) {
+	var job models.UploadJob
+	if err := r.db.First(&job, id).Error; err != nil {
+		return nil, err
+	}
+	return &job, nil
+}
+
+// FindUnfinished retrieves the queued and running jobs, oldest first
+func (r *uploadJobRepository) FindUnfinished() ([]models.UploadJob, error) {
+	var jobs []models.UploadJob
+	err := r.db.Where("status IN ?", []string{models.UploadJobQueued, models.UploadJobRunning}).
+		Order("id ASC").
+		Find(&jobs).Error
+	return jobs, err
+}
+
+// Update applies a partial update to an upload job
+func (r *uploadJobRepository) Update(id uint, updates map[string]interface{}) error {
+	result := r.db.Model(&models.UploadJob{}).Where("id = ?", id).Updates(updates)
+
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
@@ -5,19 +5,30 @@ import (
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/middleware"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/services"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
 	"github.com/gin-gonic/gin"
 )
 
+// Handlers groups the HTTP handlers registered by SetupRoutes
+type Handlers struct {
+	Experience          *handlers.ExperienceHandler
+	ExperienceClient    *handlers.ExperienceClientHandler
+	Project             *handlers.ProjectHandler
+	CareerCertification *handlers.CareerCertificationHandler
+	Education           *handlers.EducationHandler
+	Skill               *handlers.SkillHandler
+	Language            *handlers.LanguageHandler
+	Profile             *handlers.ProfileHandler
+	Search              *handlers.SearchHandler
+	Resume              *handlers.ResumeHandler
+	UploadJob           *handlers.UploadJobHandler
+	LinkHealth          *handlers.LinkHealthHandler
+	Trash               *handlers.TrashHandler
+	Auth                *handlers.AuthHandler
+}
+
 // SetupRoutes configures all application routes
-func SetupRoutes(
-	router *gin.Engine,
-	experienceHandler *handlers.ExperienceHandler,
-	experienceClientHandler *handlers.ExperienceClientHandler,
-	projectHandler *handlers.ProjectHandler,
-	uploadCertificatesHandler *handlers.CareerCertificationHandler,
-	authHandler *handlers.AuthHandler,
-	authService services.AuthService,
-) {
+func SetupRoutes(router *gin.Engine, h Handlers, authService services.AuthService) {
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(200, gin.H{
 			"status":  "ok",
@@ -40,55 +51,77 @@ func SetupRoutes(
 		// Auth routes
 		auth := v1.Group("/auth")
 		{
-			auth.POST("/login", authHandler.Login)
-			auth.GET("/me", authHandler.GetCurrentUser)
-			auth.POST("/logout", authHandler.Logout)
+			auth.POST("/login", h.Auth.Login)
+			auth.POST("/login/verify", h.Auth.VerifyLogin)
+			auth.GET("/me", h.Auth.GetCurrentUser)
+			auth.POST("/logout", middleware.CSRFMiddleware(), h.Auth.Logout)
+
+			// Sessions of the current user
+			sessions := auth.Group("/sessions", middleware.AuthMiddleware(authService))
+			{
+				sessions.GET("", h.Auth.ListSessions)
+				sessions.DELETE("", h.Auth.RevokeOtherSessions)
+				sessions.DELETE("/:id", h.Auth.RevokeSession)
+			}
+
+			// Two-factor authentication
+			twoFactor := auth.Group("/2fa", middleware.AuthMiddleware(authService))
+			{
+				twoFactor.POST("/setup", h.Auth.SetupTwoFactor)
+				twoFactor.POST("/enable", h.Auth.EnableTwoFactor)
+				twoFactor.POST("/disable", h.Auth.DisableTwoFactor)
+				twoFactor.POST("/recovery-codes", h.Auth.RegenerateRecoveryCodes)
+			}
 		}
 
 		// Experience routes
 		experiences := v1.Group("/experiences")
 		{
 			// Public routes
-			experiences.GET("", experienceHandler.GetAllExperiences)
-			experiences.GET("/:id", experienceHandler.GetExperienceByID)
+			experiences.GET("",
+				middleware.OptionalAuthMiddleware(authService),
+				middleware.ValidateQuery[dto.ExperienceListQuery](),
+				h.Experience.GetAllExperiences,
+			)
+			experiences.GET("/:id", middleware.OptionalAuthMiddleware(authService), h.Experience.GetExperienceByID)
 
 			// Protected routes
 			experiences.POST("",
 				middleware.AuthMiddleware(authService),
 				middleware.ValidateRequest[dto.ExperienceRequest](),
-				experienceHandler.CreateExperience,
+				h.Experience.CreateExperience,
 			)
 			experiences.PATCH("/:id",
 				middleware.AuthMiddleware(authService),
 				middleware.ValidateRequest[dto.UpdateExperienceRequest](),
-				experienceHandler.UpdateExperience,
+				h.Experience.UpdateExperience,
 			)
 			experiences.DELETE("/:id",
 				middleware.AuthMiddleware(authService),
-				experienceHandler.DeleteExperience,
+				h.Experience.DeleteExperience,
 			)
 
 			// Experience Clients sub-resource
 			clientsGroup := experiences.Group("/:id/clients")
 			{
 				// Public routes
-				clientsGroup.GET("", experienceClientHandler.GetClientsByExperienceID)
-				clientsGroup.GET("/:clientId", experienceClientHandler.GetClientByID)
+				clientsGroup.GET("", middleware.OptionalAuthMiddleware(authService), h.ExperienceClient.GetClientsByExperienceID)
+				clientsGroup.GET("/:clientId", middleware.OptionalAuthMiddleware(authService), h.ExperienceClient.GetClientByID)
 
 				// Protected routes
 				clientsGroup.POST("",
 					middleware.AuthMiddleware(authService),
 					middleware.ValidateRequest[dto.ExperienceClientRequest](),
-					experienceClientHandler.CreateClient,
+					h.ExperienceClient.CreateClient,
 				)
 				clientsGroup.PATCH("/:clientId",
 					middleware.AuthMiddleware(authService),
 					middleware.ValidateRequest[dto.UpdateExperienceClientRequest](),
-					experienceClientHandler.UpdateClient,
+					h.ExperienceClient.UpdateClient,
 				)
 				clientsGroup.DELETE("/:clientId",
 					middleware.AuthMiddleware(authService),
-					experienceClientHandler.DeleteClient,
+					h.ExperienceClient.DeleteClient,
 				)
 			}
 		}
@@ -97,42 +130,205 @@ func SetupRoutes(
 		projects := v1.Group("/projects")
 		{
 			// Public routes
-			projects.GET("", projectHandler.GetAllProjects)
-			projects.GET("/:id", projectHandler.GetProjectById)
+			projects.GET("",
+				middleware.OptionalAuthMiddleware(authService),
+				middleware.ValidateQuery[dto.ProjectListQuery](),
+				h.Project.GetAllProjects,
+			)
+			projects.GET("/:id", middleware.OptionalAuthMiddleware(authService), h.Project.GetProjectById)
 
 			// Protected routes
 			projects.POST("",
 				middleware.AuthMiddleware(authService),
 				middleware.ValidateRequest[dto.ProjectRequest](),
-				projectHandler.CreateProject,
+				h.Project.CreateProject,
 			)
 			projects.PATCH("/:id",
 				middleware.AuthMiddleware(authService),
 				middleware.ValidateRequest[dto.UpdateProjectRequest](),
-				projectHandler.UpdateProject,
+				h.Project.UpdateProject,
 			)
 			projects.DELETE("/:id",
 				middleware.AuthMiddleware(authService),
-				projectHandler.DeleteProject,
+				h.Project.DeleteProject,
+			)
+		}
+
+		// Education routes
+		education := v1.Group("/education")
+		{
+			// Public routes
+			education.GET("",
+				middleware.ValidateQuery[dto.EducationListQuery](),
+				h.Education.GetAllEducation,
+			)
+			education.GET("/:id", h.Education.GetEducationByID)
+
+			// Protected routes
+			education.POST("",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.EducationRequest](),
+				h.Education.CreateEducation,
+			)
+			education.PATCH("/:id",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.UpdateEducationRequest](),
+				h.Education.UpdateEducation,
+			)
+			education.DELETE("/:id",
+				middleware.AuthMiddleware(authService),
+				h.Education.DeleteEducation,
 			)
 		}
 
+		// Skill routes
+		skills := v1.Group("/skills")
+		{
+			// Public routes
+			skills.GET("",
+				middleware.ValidateQuery[dto.SkillListQuery](),
+				h.Skill.GetAllSkills,
+			)
+			skills.GET("/:id", h.Skill.GetSkillByID)
+
+			// Protected routes
+			skills.POST("",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.SkillRequest](),
+				h.Skill.CreateSkill,
+			)
+			skills.PATCH("/:id",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.UpdateSkillRequest](),
+				h.Skill.UpdateSkill,
+			)
+			skills.DELETE("/:id",
+				middleware.AuthMiddleware(authService),
+				h.Skill.DeleteSkill,
+			)
+		}
+
+		// Language routes
+		languages := v1.Group("/languages")
+		{
+			// Public routes
+			languages.GET("",
+				middleware.ValidateQuery[dto.LanguageListQuery](),
+				h.Language.GetAllLanguages,
+			)
+			languages.GET("/:id", h.Language.GetLanguageByID)
+
+			// Protected routes
+			languages.POST("",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.LanguageRequest](),
+				h.Language.CreateLanguage,
+			)
+			languages.PATCH("/:id",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.UpdateLanguageRequest](),
+				h.Language.UpdateLanguage,
+			)
+			languages.DELETE("/:id",
+				middleware.AuthMiddleware(authService),
+				h.Language.DeleteLanguage,
+			)
+		}
+
+		// Profile routes
+		profile := v1.Group("/profile")
+		{
+			// Public routes
+			profile.GET("", h.Profile.GetProfile)
+
+			// Protected routes
+			profile.PUT("",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.ProfileRequest](),
+				h.Profile.UpdateProfile,
+			)
+			profile.PUT("/avatar",
+				middleware.AuthMiddleware(authService),
+				middleware.MaxBodySize(constants.MaxAvatarUploadRequestSize),
+				h.Profile.UploadAvatar,
+			)
+		}
+
+		// Full-text search
+		v1.GET("/search",
+			middleware.ValidateQuery[dto.SearchQuery](),
+			h.Search.Search,
+		)
+
+		// Résumé export (JSON Resume and PDF) and import
+		v1.GET("/resume.json", middleware.OptionalAuthMiddleware(authService), h.Resume.ExportResume)
+		v1.GET("/resume.pdf",
+			middleware.OptionalAuthMiddleware(authService),
+			middleware.ValidateQuery[dto.ResumePDFQuery](),
+			h.Resume.ExportResumePDF,
+		)
+		v1.POST("/resume/import",
+			middleware.AuthMiddleware(authService),
+			middleware.ValidateQuery[dto.ResumeImportQuery](),
+			h.Resume.ImportResume,
+		)
+
+		// Background upload jobs
+		jobs := v1.Group("/jobs", middleware.AuthMiddleware(authService))
+		{
+			jobs.GET("/:id", h.UploadJob.GetJob)
+			jobs.GET("/:id/events", h.UploadJob.StreamJobEvents)
+		}
+
+		// Admin reports and tools
+		admin := v1.Group("/admin", middleware.AuthMiddleware(authService))
+		{
+			admin.GET("/link-health",
+				middleware.ValidateQuery[dto.LinkHealthQuery](),
+				h.LinkHealth.GetLinkHealth,
+			)
+
+			// Deleted records
+			admin.GET("/trash",
+				middleware.ValidateQuery[dto.TrashQuery](),
+				h.Trash.ListTrash,
+			)
+			admin.POST("/trash/:type/:id/restore", h.Trash.RestoreTrashItem)
+			admin.DELETE("/trash/:type/:id/purge", h.Trash.PurgeTrashItem)
+		}
+
 		// Upload Certificates
 		uploadCertificates := v1.Group("/upload-certificates")
 		{
 			// Public routes
-			uploadCertificates.GET("", uploadCertificatesHandler.GetAllCertifications)
-			uploadCertificates.GET("/:id", uploadCertificatesHandler.GetCertificationByID)
+			uploadCertificates.GET("",
+				middleware.OptionalAuthMiddleware(authService),
+				middleware.ValidateQuery[dto.CertificationListQuery](),
+				h.CareerCertification.GetAllCertifications,
+			)
+			uploadCertificates.GET("/:id", middleware.OptionalAuthMiddleware(authService), h.CareerCertification.GetCertificationByID)
 
 			// Protected routes
 			uploadCertificates.POST("",
 				middleware.AuthMiddleware(authService),
+				middleware.MaxBodySize(constants.MaxCertificateUploadRequestSize),
 				middleware.ValidateQuery[dto.UploadCertificatesRequest](),
-				uploadCertificatesHandler.UploadAcademicCertificates,
+				middleware.ValidateCertificationMetadata(),
+				h.CareerCertification.UploadAcademicCertificates,
+			)
+			uploadCertificates.PATCH("/:id",
+				middleware.AuthMiddleware(authService),
+				middleware.ValidateRequest[dto.UpdateCertificationRequest](),
+				h.CareerCertification.UpdateCertification,
+			)
+			uploadCertificates.PUT("/:id/file",
+				middleware.AuthMiddleware(authService),
+				middleware.MaxBodySize(constants.MaxCertificateFileRequestSize),
+				h.CareerCertification.ReplaceCertificationFile,
 			)
 			uploadCertificates.DELETE("/:id",
 				middleware.AuthMiddleware(authService),
-				uploadCertificatesHandler.DeleteCertification,
+				h.CareerCertification.DeleteCertification,
 			)
 		}
 	}
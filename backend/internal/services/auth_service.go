@@ -1,107 +1,613 @@
 package services
 
 import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/totp"
 	"github.com/google/uuid"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 )
 
-// SessionDuration Session duration - 2 hours
-const SessionDuration = 2 * time.Hour
+// Session lifetime settings
+const (
+	// SessionDuration is how long a session lasts without being used, each use extends it
+	SessionDuration = 2 * time.Hour
+	// SessionMaxLifetime is how long a session lasts at most from the login, however much it is used
+	SessionMaxLifetime = 24 * time.Hour
+	// SessionCleanupInterval is how often the expired sessions are removed
+	SessionCleanupInterval = 15 * time.Minute
+	// sessionRenewInterval spares a write per request, the use of a session is recorded at most this often
+	sessionRenewInterval = time.Minute
+	// maxUserAgentLength truncates the user agents stored with the sessions
+	maxUserAgentLength = 512
+	// sessionTokenBytes is the entropy of the session tokens
+	sessionTokenBytes = 32
+)
+
+// Two-factor authentication settings
+const (
+	// LoginChallengeDuration is how long the second step of a login can be completed after the password check
+	LoginChallengeDuration = 5 * time.Minute
+	// MaxLoginChallengeAttempts is how many wrong codes a login challenge accepts before it is dropped
+	MaxLoginChallengeAttempts = 5
+	// RecoveryCodeCount is how many recovery codes are generated at once
+	RecoveryCodeCount = 10
+	// TOTPIssuer names the account in authenticator apps
+	TOTPIssuer = "Portfolio Admin"
+	// totpSkew is how many 30 second steps a code may be off, to tolerate clock drift
+	totpSkew = 1
+)
 
 var (
-	ErrInvalidCredentials = errors.New("invalid email or password")
-	ErrSessionNotFound    = errors.New("session not found")
-	ErrSessionExpired     = errors.New("session has expired")
+	ErrInvalidCredentials      = errors.New("invalid email or password")
+	ErrSessionNotFound         = errors.New("session not found")
+	ErrSessionExpired          = errors.New("session has expired")
+	ErrInvalidChallenge        = errors.New("login challenge is invalid or has expired")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+	ErrTwoFactorNotConfigured  = errors.New("two-factor authentication is not configured, set TOTP_ENCRYPTION_KEY")
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp       = errors.New("two-factor authentication has not been set up")
 )
 
+// totpCodePattern tells TOTP codes apart from recovery codes
+var totpCodePattern = regexp.MustCompile(`^[0-9]{6}$`)
+
+// recoveryCodeEncoding spells the recovery codes, lower case and without padding
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
 type AuthService interface {
-	Login(email, password string) (*models.Session, *models.AuthResponse, error)
-	Logout(sessionId string) error
+	Login(email, password string, client models.SessionClient) (*models.Session, *models.AuthResponse, error)
+	VerifyLogin(challengeID, code string, client models.SessionClient) (*models.Session, *models.AuthResponse, error)
+	Logout(token string) error
 	CleanUpExpiredSessions() error
-	ValidateSession(sessionId string) (*models.Session, error)
-	GetUserBySessionID(sessionId string) (*models.UserResponse, error)
+	ValidateSession(token string) (*models.Session, error)
+	RenewSession(session *models.Session) (bool, error)
+	ListSessions(user *models.User, currentSessionID string) ([]models.SessionResponse, error)
+	RevokeSession(user *models.User, currentSessionID, id string) (bool, error)
+	RevokeOtherSessions(user *models.User, currentSessionID string) (int64, error)
+	GetUserBySessionID(token string) (*models.UserResponse, error)
+	SetupTwoFactor(user *models.User) (*models.TwoFactorSetup, error)
+	EnableTwoFactor(user *models.User, code string) ([]string, error)
+	DisableTwoFactor(user *models.User, code string) error
+	RegenerateRecoveryCodes(user *models.User, code string) ([]string, error)
 }
 
 type authService struct {
-	repo repository.AuthRepository
+	repo  repository.AuthRepository
+	guard LoginGuard
+	// totpKey encrypts the TOTP secrets, nil when two-factor authentication is not configured
+	totpKey []byte
+	// dummyHash is checked against the password of an unknown email, so the response takes as long as for an
+	// existing account
+	dummyHash []byte
 }
 
-func NewAuthService(repo repository.AuthRepository) AuthService {
-	return &authService{repo: repo}
+// NewAuthService creates an AuthService whose logins are throttled by guard. The TOTP secrets are encrypted with a
+// key derived from totpEncryptionKey; two-factor authentication cannot be enabled when it is empty.
+func NewAuthService(repo repository.AuthRepository, guard LoginGuard, totpEncryptionKey string) AuthService {
+	service := &authService{repo: repo, guard: guard}
+	service.dummyHash, _ = bcrypt.GenerateFromPassword([]byte(uuid.New().String()), bcrypt.DefaultCost)
+	if totpEncryptionKey != "" {
+		key := sha256.Sum256([]byte(totpEncryptionKey))
+		service.totpKey = key[:]
+	}
+	return service
 }
 
-// Login authenticates a user and creates a new session
-func (a *authService) Login(email, password string) (*models.Session, *models.AuthResponse, error) {
+// Login authenticates a user and creates a new session. When the user has two-factor authentication enabled,
+// no session is created: the response carries the challenge to complete with VerifyLogin. The account and the IP
+// address the login comes from are refused with a LoginThrottledError after too many failures; an unknown email
+// fails the same way and takes as long as a wrong password.
+func (a *authService) Login(email, password string, client models.SessionClient) (*models.Session, *models.AuthResponse, error) {
+	email, ip := normalizeEmail(email), client.IPAddress
+	if err := a.guard.Check(email, ip); err != nil {
+		return nil, nil, err
+	}
+
 	user, err := a.repo.FindUserByEmail(email)
-	if err != nil {
-		return nil, nil, ErrInvalidCredentials
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, err
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password)); err != nil {
+	hash := a.dummyHash
+	if user != nil {
+		hash = []byte(user.Password)
+	}
+
+	if err := bcrypt.CompareHashAndPassword(hash, []byte(password)); err != nil || user == nil {
+		a.guard.RecordFailure(email, ip)
+		logger.Warn("Failed login for %s from %s", email, ip)
 		return nil, nil, ErrInvalidCredentials
 	}
 
-	session := &models.Session{
-		ID:        uuid.New().String(),
-		UserID:    user.ID,
-		ExpiresAt: time.Now().Add(SessionDuration),
-		CreatedAt: time.Now(),
+	if user.TwoFactorEnabled() {
+		challenge := &models.LoginChallenge{
+			ID:        uuid.New().String(),
+			UserID:    user.ID,
+			ExpiresAt: time.Now().Add(LoginChallengeDuration),
+			CreatedAt: time.Now(),
+		}
+
+		if err := a.repo.CreateLoginChallenge(challenge); err != nil {
+			return nil, nil, err
+		}
+
+		// The failures are only cleared by the second step, so the codes cannot be guessed over many challenges
+		return nil, &models.AuthResponse{
+			TwoFactorRequired:  true,
+			Challenge:          challenge.ID,
+			ChallengeExpiresAt: &challenge.ExpiresAt,
+			Message:            "Two-factor code required",
+		}, nil
 	}
 
-	if err := a.repo.CreateSession(session); err != nil {
+	a.guard.RecordSuccess(email, ip)
+	return a.createSession(user, client)
+}
+
+// VerifyLogin completes a login challenge with a TOTP code or a recovery code and creates the session. The
+// challenge is dropped after MaxLoginChallengeAttempts wrong codes, and every wrong code counts as a failed login
+// of the account and the IP address.
+func (a *authService) VerifyLogin(challengeID, code string, client models.SessionClient) (*models.Session, *models.AuthResponse, error) {
+	challenge, err := a.repo.FindLoginChallengeByID(challengeID)
+	if err != nil {
+		return nil, nil, ErrInvalidChallenge
+	}
+
+	email, ip := challenge.User.Email, client.IPAddress
+	if err := a.guard.Check(email, ip); err != nil {
 		return nil, nil, err
 	}
 
-	response := &models.AuthResponse{
-		User: models.UserResponse{
-			ID:    user.ID,
-			Email: user.Email,
-		},
-		Message: "Login successful",
+	if time.Now().After(challenge.ExpiresAt) || challenge.Attempts >= MaxLoginChallengeAttempts {
+		_ = a.repo.DeleteLoginChallenge(challengeID)
+		return nil, nil, ErrInvalidChallenge
 	}
 
-	return session, response, nil
+	// Two-factor authentication was turned off since the challenge was created, the login starts over
+	if !challenge.User.TwoFactorEnabled() {
+		_ = a.repo.DeleteLoginChallenge(challengeID)
+		return nil, nil, ErrInvalidChallenge
+	}
+
+	if err := a.verifySecondFactor(&challenge.User, code, true); err != nil {
+		if errors.Is(err, ErrInvalidTwoFactorCode) {
+			if err := a.repo.IncrementLoginChallengeAttempts(challengeID); err != nil {
+				logger.Error("Failed to count login challenge attempt: %v", err)
+			}
+			a.guard.RecordFailure(email, ip)
+			logger.Warn("Invalid two-factor code for user %d from %s", challenge.UserID, ip)
+		}
+		return nil, nil, err
+	}
+
+	if err := a.repo.DeleteLoginChallenge(challengeID); err != nil {
+		return nil, nil, err
+	}
+
+	a.guard.RecordSuccess(email, ip)
+	return a.createSession(&challenge.User, client)
 }
 
-// Logout removes a session
-func (a *authService) Logout(sessionId string) error {
-	return a.repo.DeleteSession(sessionId)
+// Logout removes the session of a token
+func (a *authService) Logout(token string) error {
+	return a.repo.DeleteSession(hashSessionToken(token))
 }
 
-// CleanUpExpiredSessions removes all expired sessions
+// CleanUpExpiredSessions removes all expired sessions and login challenges, and the failed login counters past
+// the failure window
 func (a *authService) CleanUpExpiredSessions() error {
+	if err := a.repo.DeleteExpiredLoginChallenges(); err != nil {
+		return err
+	}
+	if err := a.guard.CleanUp(); err != nil {
+		return err
+	}
 	return a.repo.DeleteExpiredSessions()
 }
 
-// ValidateSession checks if a session exists and is not expired
-func (a *authService) ValidateSession(sessionId string) (*models.Session, error) {
-	session, err := a.repo.FindSessionByID(sessionId)
+// ValidateSession checks that the session of a token exists and is not expired
+func (a *authService) ValidateSession(token string) (*models.Session, error) {
+	session, err := a.repo.FindSessionByID(hashSessionToken(token))
 	if err != nil {
 		return nil, ErrSessionNotFound
 	}
 
 	if time.Now().After(session.ExpiresAt) {
-		_ = a.repo.DeleteSession(sessionId)
+		_ = a.repo.DeleteSession(session.ID)
 		return nil, ErrSessionExpired
 	}
 
 	return session, nil
 }
 
-// GetUserBySessionID returns user data for a valid session
-func (a *authService) GetUserBySessionID(sessionId string) (*models.UserResponse, error) {
-	session, err := a.ValidateSession(sessionId)
+// RenewSession records the use of a session and extends it by SessionDuration, without going past
+// SessionMaxLifetime from the login. A session used less than a minute ago is left as is; it reports whether the
+// session was renewed.
+func (a *authService) RenewSession(session *models.Session) (bool, error) {
+	now := time.Now()
+	if now.Sub(session.LastSeenAt) < sessionRenewInterval {
+		return false, nil
+	}
+
+	expiresAt := now.Add(SessionDuration)
+	if limit := session.CreatedAt.Add(SessionMaxLifetime); expiresAt.After(limit) {
+		expiresAt = limit
+	}
+
+	if err := a.repo.RenewSession(session.ID, now, expiresAt); err != nil {
+		return false, fmt.Errorf("failed to renew session: %w", err)
+	}
+
+	session.LastSeenAt = now
+	session.ExpiresAt = expiresAt
+	return true, nil
+}
+
+// ListSessions returns the sessions of the user that have not expired, marking the one of the current request
+func (a *authService) ListSessions(user *models.User, currentSessionID string) ([]models.SessionResponse, error) {
+	sessions, err := a.repo.FindSessionsByUserID(user.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+
+	responses := make([]models.SessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		responses = append(responses, models.SessionResponse{
+			ID:         session.ID,
+			UserAgent:  session.UserAgent,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			ExpiresAt:  session.ExpiresAt,
+			Current:    session.ID == currentSessionID,
+		})
+	}
+	return responses, nil
+}
+
+// RevokeSession signs out a session of the user and reports whether it was the current one. It returns ErrSessionNotFound when the user has no such session.
+func (a *authService) RevokeSession(user *models.User, currentSessionID, id string) (bool, error) {
+	sessions, err := a.repo.FindSessionsByUserID(user.ID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch sessions: %w", err)
+	}
+
+	for _, session := range sessions {
+		if session.ID != id {
+			continue
+		}
+
+		if err := a.repo.DeleteSession(session.ID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return false, ErrSessionNotFound
+			}
+			return false, fmt.Errorf("failed to revoke session: %w", err)
+		}
+
+		logger.Info("Revoked session %s of user %d", id, user.ID)
+		return session.ID == currentSessionID, nil
+	}
+
+	return false, ErrSessionNotFound
+}
+
+// RevokeOtherSessions signs out every session of the user but the current one and returns how many were revoked
+func (a *authService) RevokeOtherSessions(user *models.User, currentSessionID string) (int64, error) {
+	count, err := a.repo.DeleteOtherSessions(user.ID, currentSessionID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	logger.Info("Revoked %d other sessions of user %d", count, user.ID)
+	return count, nil
+}
+
+// GetUserBySessionID returns user data for the valid session of a token
+func (a *authService) GetUserBySessionID(token string) (*models.UserResponse, error) {
+	session, err := a.ValidateSession(token)
 	if err != nil {
 		return nil, err
 	}
 
-	return &models.UserResponse{
-		ID:    session.User.ID,
-		Email: session.User.Email,
+	return userResponse(&session.User), nil
+}
+
+// SetupTwoFactor starts a two-factor enrollment: it generates and stores a new TOTP secret, which only takes
+// effect once EnableTwoFactor confirms it with a code. Setting up again replaces a secret not confirmed yet.
+func (a *authService) SetupTwoFactor(user *models.User) (*models.TwoFactorSetup, error) {
+	if a.totpKey == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted, err := a.encryptSecret(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.repo.SetTOTPSecret(user.ID, &encrypted); err != nil {
+		return nil, fmt.Errorf("failed to store TOTP secret: %w", err)
+	}
+
+	logger.Info("Started two-factor enrollment for user %d", user.ID)
+	return &models.TwoFactorSetup{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(TOTPIssuer, user.Email, secret),
 	}, nil
 }
+
+// EnableTwoFactor confirms the enrollment started by SetupTwoFactor with a code from the authenticator app and
+// returns the recovery codes, which are not stored in clear and cannot be shown again
+func (a *authService) EnableTwoFactor(user *models.User, code string) ([]string, error) {
+	if user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if user.TOTPSecret == nil {
+		return nil, ErrTwoFactorNotSetUp
+	}
+
+	secret, err := a.decryptSecret(*user.TOTPSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	step, ok := totp.Validate(secret, strings.TrimSpace(code), time.Now(), totpSkew)
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+
+	codes, records, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.repo.EnableTwoFactor(user.ID, step, records); err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	logger.Info("Enabled two-factor authentication for user %d", user.ID)
+	return codes, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off after checking a TOTP code or a recovery code
+func (a *authService) DisableTwoFactor(user *models.User, code string) error {
+	if !user.TwoFactorEnabled() {
+		return ErrTwoFactorNotEnabled
+	}
+
+	if err := a.verifySecondFactor(user, code, true); err != nil {
+		return err
+	}
+
+	if err := a.repo.DisableTwoFactor(user.ID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	logger.Info("Disabled two-factor authentication for user %d", user.ID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the user after checking a TOTP code
+func (a *authService) RegenerateRecoveryCodes(user *models.User, code string) ([]string, error) {
+	if !user.TwoFactorEnabled() {
+		return nil, ErrTwoFactorNotEnabled
+	}
+
+	if err := a.verifySecondFactor(user, code, false); err != nil {
+		return nil, err
+	}
+
+	codes, records, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.repo.ReplaceRecoveryCodes(user.ID, records); err != nil {
+		return nil, fmt.Errorf("failed to store recovery codes: %w", err)
+	}
+
+	logger.Info("Regenerated recovery codes for user %d", user.ID)
+	return codes, nil
+}
+
+// createSession starts a session for an authenticated user on the given device
+func (a *authService) createSession(user *models.User, client models.SessionClient) (*models.Session, *models.AuthResponse, error) {
+	userAgent := client.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = strings.ToValidUTF8(userAgent[:maxUserAgentLength], "")
+	}
+
+	token, err := generateSessionToken()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	now := time.Now()
+	session := &models.Session{
+		ID:         hashSessionToken(token),
+		Token:      token,
+		UserID:     user.ID,
+		UserAgent:  userAgent,
+		IPAddress:  client.IPAddress,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(SessionDuration),
+		CreatedAt:  now,
+	}
+
+	if err := a.repo.CreateSession(session); err != nil {
+		return nil, nil, err
+	}
+
+	response := &models.AuthResponse{
+		User:    userResponse(user),
+		Message: "Login successful",
+	}
+
+	return session, response, nil
+}
+
+// verifySecondFactor checks a TOTP code of the user, or one of their recovery codes when allowRecovery is set.
+// An accepted code cannot be used again.
+func (a *authService) verifySecondFactor(user *models.User, code string, allowRecovery bool) error {
+	code = strings.TrimSpace(code)
+
+	if !totpCodePattern.MatchString(code) {
+		if !allowRecovery {
+			return ErrInvalidTwoFactorCode
+		}
+		if err := a.repo.UseRecoveryCode(user.ID, hashRecoveryCode(code)); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return ErrInvalidTwoFactorCode
+			}
+			return fmt.Errorf("failed to check recovery code: %w", err)
+		}
+		logger.Warn("User %d signed in with a recovery code", user.ID)
+		return nil
+	}
+
+	if user.TOTPSecret == nil {
+		return ErrTwoFactorNotEnabled
+	}
+
+	secret, err := a.decryptSecret(*user.TOTPSecret)
+	if err != nil {
+		return err
+	}
+
+	step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	if err := a.repo.UpdateTOTPLastStep(user.ID, step); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// The code was already used
+			return ErrInvalidTwoFactorCode
+		}
+		return fmt.Errorf("failed to record TOTP code: %w", err)
+	}
+	return nil
+}
+
+// encryptSecret seals a TOTP secret with AES-GCM and returns the nonce and ciphertext, base64 encoded
+func (a *authService) encryptSecret(secret string) (string, error) {
+	gcm, err := a.totpCipher()
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	sealed := gcm.Seal(nonce, nonce, []byte(secret), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// decryptSecret opens a TOTP secret sealed by encryptSecret
+func (a *authService) decryptSecret(encrypted string) (string, error) {
+	gcm, err := a.totpCipher()
+	if err != nil {
+		return "", err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", errors.New("failed to decode TOTP secret")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	secret, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", errors.New("failed to decrypt TOTP secret, was TOTP_ENCRYPTION_KEY changed?")
+	}
+	return string(secret), nil
+}
+
+// totpCipher returns the AES-GCM cipher of the TOTP secrets
+func (a *authService) totpCipher() (cipher.AEAD, error) {
+	if a.totpKey == nil {
+		return nil, ErrTwoFactorNotConfigured
+	}
+
+	block, err := aes.NewCipher(a.totpKey)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// generateRecoveryCodes returns RecoveryCodeCount new codes, to show to the user, and their records to store
+func generateRecoveryCodes() ([]string, []models.RecoveryCode, error) {
+	codes := make([]string, RecoveryCodeCount)
+	records := make([]models.RecoveryCode, RecoveryCodeCount)
+	for i := range codes {
+		raw := make([]byte, 7)
+		if _, err := rand.Read(raw); err != nil {
+			return nil, nil, fmt.Errorf("failed to generate recovery code: %w", err)
+		}
+
+		encoded := recoveryCodeEncoding.EncodeToString(raw)[:10]
+		codes[i] = encoded[:5] + "-" + encoded[5:]
+		records[i] = models.RecoveryCode{CodeHash: hashRecoveryCode(codes[i]), CreatedAt: time.Now()}
+	}
+	return codes, records, nil
+}
+
+// hashRecoveryCode returns the SHA-256 of a recovery code, ignoring case, spaces and dashes
+func hashRecoveryCode(code string) string {
+	normalized := strings.NewReplacer("-", "", " ", "").Replace(strings.ToLower(code))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// generateSessionToken returns a new random session token, URL-safe base64 encoded
+func generateSessionToken() (string, error) {
+	raw := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("failed to generate session token: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
+
+// hashSessionToken returns the SHA-256 of a session token, the ID its session is stored under
+func hashSessionToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// userResponse returns the public data of a user
+func userResponse(user *models.User) *models.UserResponse {
+	return &models.UserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		TwoFactorEnabled: user.TwoFactorEnabled(),
+	}
+}
@@ -0,0 +1,215 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/totp"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newTestDB opens an in-memory SQLite database with the tables of the given models
+func newTestDB(t *testing.T, tables ...interface{}) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("failed to open database: %v", err)
+	}
+
+	// Every connection to :memory: is a different database
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatalf("failed to get database: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	if err := db.AutoMigrate(tables...); err != nil {
+		t.Fatalf("failed to create tables: %v", err)
+	}
+	return db
+}
+
+func TestVerifySecondFactorRejectsReplayedCodes(t *testing.T) {
+	db := newTestDB(t, &models.User{}, &models.RecoveryCode{})
+	service := NewAuthService(repository.NewAuthRepository(db), nil, "test-encryption-key").(*authService)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	sealed, err := service.encryptSecret(secret)
+	if err != nil {
+		t.Fatalf("failed to encrypt secret: %v", err)
+	}
+
+	enabledAt := time.Now()
+	user := &models.User{Email: "admin@example.com", Password: "hash", TOTPSecret: &sealed, TOTPEnabledAt: &enabledAt}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	current := totp.Step(time.Now())
+	codeAt := func(step int64) string {
+		code, err := totp.Code(secret, step)
+		if err != nil {
+			t.Fatalf("failed to compute code: %v", err)
+		}
+		return code
+	}
+
+	// The user is read again before every attempt, as a login does
+	verify := func(code string) error {
+		var stored models.User
+		if err := db.First(&stored, user.ID).Error; err != nil {
+			t.Fatalf("failed to read user: %v", err)
+		}
+		return service.verifySecondFactor(&stored, code, false)
+	}
+
+	steps := []struct {
+		name    string
+		code    string
+		wantErr error
+	}{
+		{"current code", codeAt(current), nil},
+		{"same code again", codeAt(current), ErrInvalidTwoFactorCode},
+		{"code of the previous step", codeAt(current - 1), ErrInvalidTwoFactorCode},
+		{"code of the next step", codeAt(current + 1), nil},
+		{"next step code again", codeAt(current + 1), ErrInvalidTwoFactorCode},
+		{"malformed code", "12345", ErrInvalidTwoFactorCode},
+	}
+
+	for _, step := range steps {
+		err := verify(step.code)
+		if step.wantErr == nil && err != nil {
+			t.Errorf("%s: unexpected error %v", step.name, err)
+		}
+		if step.wantErr != nil && !errors.Is(err, step.wantErr) {
+			t.Errorf("%s: error = %v, want %v", step.name, err, step.wantErr)
+		}
+	}
+}
+
+func TestSessionsAreStoredByTokenHash(t *testing.T) {
+	db := newTestDB(t, &models.User{}, &models.Session{}, &models.LoginThrottle{})
+	repo := repository.NewAuthRepository(db)
+	guard := NewLoginGuard(repository.NewLoginThrottleRepository(db), 10, 30, time.Minute)
+	service := NewAuthService(repo, guard, "")
+
+	hash, err := hashPassword("correct horse battery")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	if err := db.Create(&models.User{Email: "admin@example.com", Password: hash}).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	session, _, err := service.Login("admin@example.com", "correct horse battery", models.SessionClient{IPAddress: "127.0.0.1"})
+	if err != nil {
+		t.Fatalf("Login returned an error: %v", err)
+	}
+	if session.Token == "" || session.ID != hashSessionToken(session.Token) {
+		t.Fatalf("session ID %q is not the hash of its token %q", session.ID, session.Token)
+	}
+
+	var stored models.Session
+	if err := db.First(&stored).Error; err != nil {
+		t.Fatalf("failed to read session: %v", err)
+	}
+	if stored.ID == session.Token {
+		t.Error("the session token is stored in clear")
+	}
+
+	if _, err := service.ValidateSession(session.Token); err != nil {
+		t.Errorf("ValidateSession with the token returned %v", err)
+	}
+	// A leaked database only holds the hashes, which do not work as cookies
+	if _, err := service.ValidateSession(stored.ID); !errors.Is(err, ErrSessionNotFound) {
+		t.Errorf("ValidateSession with the stored hash = %v, want %v", err, ErrSessionNotFound)
+	}
+}
+
+func TestGenerateSessionToken(t *testing.T) {
+	token, err := generateSessionToken()
+	if err != nil {
+		t.Fatalf("generateSessionToken returned an error: %v", err)
+	}
+	// 32 random bytes, base64url without padding
+	if len(token) != 43 {
+		t.Errorf("token %q is %d characters, want 43", token, len(token))
+	}
+
+	other, _ := generateSessionToken()
+	if other == token {
+		t.Error("generateSessionToken returned the same token twice")
+	}
+	if hashSessionToken(token) == hashSessionToken(other) {
+		t.Error("two tokens have the same hash")
+	}
+}
+
+func TestPendingLoginChallengeAfterTwoFactorIsDisabled(t *testing.T) {
+	db := newTestDB(t, &models.User{}, &models.RecoveryCode{}, &models.LoginChallenge{}, &models.Session{}, &models.LoginThrottle{})
+	repo := repository.NewAuthRepository(db)
+	guard := NewLoginGuard(repository.NewLoginThrottleRepository(db), 10, 30, time.Minute)
+	service := NewAuthService(repo, guard, "test-encryption-key").(*authService)
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatalf("failed to generate secret: %v", err)
+	}
+	sealed, err := service.encryptSecret(secret)
+	if err != nil {
+		t.Fatalf("failed to encrypt secret: %v", err)
+	}
+	hash, err := hashPassword("correct horse battery")
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	enabledAt := time.Now()
+	user := &models.User{Email: "admin@example.com", Password: hash, TOTPSecret: &sealed, TOTPEnabledAt: &enabledAt}
+	if err := db.Create(user).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+
+	client := models.SessionClient{IPAddress: "127.0.0.1"}
+	_, response, err := service.Login("admin@example.com", "correct horse battery", client)
+	if err != nil || response == nil || !response.TwoFactorRequired {
+		t.Fatalf("Login = %+v, %v, want a two-factor challenge", response, err)
+	}
+
+	if err := NewUserService(repo).DisableTwoFactor("admin@example.com"); err != nil {
+		t.Fatalf("DisableTwoFactor returned an error: %v", err)
+	}
+
+	var pending int64
+	db.Model(&models.LoginChallenge{}).Count(&pending)
+	if pending != 0 {
+		t.Errorf("%d login challenges left after disabling two-factor authentication, want none", pending)
+	}
+
+	// A challenge that outlived the disabling, as if it was created concurrently
+	challenge := &models.LoginChallenge{ID: "stale-challenge", UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute), CreatedAt: time.Now()}
+	if err := db.Create(challenge).Error; err != nil {
+		t.Fatalf("failed to create challenge: %v", err)
+	}
+
+	code, err := totp.Code(secret, totp.Step(time.Now()))
+	if err != nil {
+		t.Fatalf("failed to compute code: %v", err)
+	}
+	if _, _, err := service.VerifyLogin(challenge.ID, code, client); !errors.Is(err, ErrInvalidChallenge) {
+		t.Errorf("VerifyLogin = %v, want %v", err, ErrInvalidChallenge)
+	}
+	if err := db.First(&models.LoginChallenge{}, "id = ?", challenge.ID).Error; err == nil {
+		t.Error("the challenge was not deleted")
+	}
+}
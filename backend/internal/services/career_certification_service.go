@@ -1,12 +1,14 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
-	"os"
-	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -15,26 +17,67 @@ import (
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
 	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/imaging"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
 	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
-	"github.com/google/uuid"
+	"gorm.io/gorm"
 )
 
 var (
 	DefaultMaxWorkers = 3
 )
 
+var (
+	// ErrBatchRolledBack is the error of the files of an atomic batch that were discarded because another file failed
+	ErrBatchRolledBack = errors.New("upload rolled back")
+	// ErrDuplicateCertificate is matched by the DuplicateCertificateError of a file that is already stored
+	ErrDuplicateCertificate = errors.New("duplicate certificate")
+)
+
+// DuplicateCertificateError is returned instead of storing a file identical to the one of an existing certification
+type DuplicateCertificateError struct {
+	Existing *models.CareerCertification
+}
+
+func (e *DuplicateCertificateError) Error() string {
+	return fmt.Sprintf("the file is already stored as certification %d (%s)", e.Existing.ID, e.Existing.Title)
+}
+
+func (e *DuplicateCertificateError) Unwrap() error {
+	return ErrDuplicateCertificate
+}
+
+// DuplicateCertification is a certification whose file is identical to the one of an older certification
+type DuplicateCertification struct {
+	Certification models.CareerCertification
+	DuplicateOf   uint
+}
+
+// DedupeReport summarizes a deduplication of the stored certificates. Hashed counts the certifications whose
+// content hash was computed from their stored file; Missing lists the certifications whose file could not be read.
+type DedupeReport struct {
+	Scanned    int
+	Hashed     int
+	Duplicates []DuplicateCertification
+	Missing    []models.CareerCertification
+	FreedBytes int64
+}
+
 // UploadResult represents the outcome of an individual file upload process, including its success status and any errors encountered.
+// Index is the position of the file in the batch. A file that is already stored fails with a DuplicateCertificateError.
 type UploadResult struct {
+	Index         int
 	Certification *models.CareerCertification
 	OriginalName  string
 	Error         error
 	Success       bool
 }
 
-// FileWithMetadata represents a file upload along with its associated metadata.
+// FileWithMetadata represents a file upload along with its associated metadata and its position in the batch.
 type FileWithMetadata struct {
-	File     *multipart.FileHeader
+	Index    int
+	File     *UploadFile
 	Metadata *dto.CertificationMetadata
 }
 
@@ -43,36 +86,179 @@ type FileWithMetadata struct {
 // The interface provides methods to store, retrieve, and delete career certification records. It supports batch
 // uploading of certification files with metadata and handles storage and CRUD operations.
 type CareerCertificationService interface {
-	StoreBatch(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, baseURL string) []UploadResult
-	GetAll() ([]models.CareerCertification, error)
+	StoreBatch(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, onResult func(UploadResult)) []UploadResult
+	StoreBatchAtomic(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, onStaged func(UploadResult)) []UploadResult
+	GetAll(filter repository.CareerCertificationFilter, opts repository.ListOptions) ([]models.CareerCertification, int64, error)
 	GetByID(id uint) (*models.CareerCertification, error)
-	Delete(id uint) error
+	Update(id uint, updates map[string]interface{}) error
+	ReplaceFile(ctx context.Context, id uint, file *multipart.FileHeader) (*models.CareerCertification, error)
+	Delete(ctx context.Context, id uint) error
+	Deduplicate(ctx context.Context, dryRun bool) (*DedupeReport, error)
+	ClearStaging(ctx context.Context) error
 }
 
 // careerCertificationService provides methods for managing career certifications, including file handling and database operations.
 type careerCertificationService struct {
-	uploadDir string
-	repo      repository.CareerCertificationRepository
+	storage          storage.Blob
+	staging          storage.Blob
+	repo             repository.CareerCertificationRepository
+	expiryNoticeDays int
 }
 
 // NewCareerCertificationService initializes and returns a new CareerCertificationService implementation.
-// It creates the upload directory for career certifications if it does not exist and utilizes the provided repository.
-func NewCareerCertificationService(repo repository.CareerCertificationRepository) CareerCertificationService {
-	service := &careerCertificationService{
-		uploadDir: constants.CareerCertificationsDir,
-		repo:      repo,
+// Certification files are kept in the given blob storage and their records in the provided repository. Atomic
+// batches stage their files in the staging storage, which must not be publicly served.
+// Certifications expiring within expiryNoticeDays are served with the "expiring" status.
+func NewCareerCertificationService(repo repository.CareerCertificationRepository, blob, staging storage.Blob, expiryNoticeDays int) CareerCertificationService {
+	return &careerCertificationService{
+		storage:          blob,
+		staging:          staging,
+		repo:             repo,
+		expiryNoticeDays: expiryNoticeDays,
+	}
+}
+
+// StoreBatch uploads multiple files concurrently, utilizing a worker pool. Returns a slice of UploadResult for each file.
+// When onResult is not nil it is called with every result as soon as its file is done, from a single goroutine.
+func (c *careerCertificationService) StoreBatch(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, onResult func(UploadResult)) []UploadResult {
+	return c.runBatch(ctx, filesWithMetadata, maxWorkers, nil, onResult)
+}
+
+// StoreBatchAtomic stores every file of the batch or none of them. The workers only stage the files in the private
+// staging storage, under a prefix of the batch; the first failure cancels the rest of the batch, otherwise all the
+// records are inserted in a single transaction and the files are then copied to their public keys. On failure the
+// records and the copied files are removed and every result is failed, the untouched files with ErrBatchRolledBack.
+// The staging prefix is dropped either way.
+// Duplicates do not fail the batch: a file already stored, or staged earlier in the same batch, is reported as a
+// duplicate and left out.
+// onStaged, when not nil, is called as each file is staged or fails, before the outcome of the batch is known.
+func (c *careerCertificationService) StoreBatchAtomic(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, onStaged func(UploadResult)) []UploadResult {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	staging := &fileTarget{blob: c.staging, prefix: uniqueFileName("-")}
+	defer func() {
+		if err := c.clearStaging(context.Background(), staging.prefix); err != nil {
+			logger.Warn("Failed to drop the staging area of atomic batch %s: %v", staging.prefix, err)
+		}
+	}()
+
+	var failure *UploadResult
+	results := c.runBatch(ctx, filesWithMetadata, maxWorkers, staging, func(result UploadResult) {
+		if !result.Success && !errors.Is(result.Error, ErrDuplicateCertificate) && failure == nil {
+			failure = &result
+			cancel()
+		}
+		if onStaged != nil {
+			onStaged(result)
+		}
+	})
+
+	var staged []*models.CareerCertification
+	byHash := make(map[string]*models.CareerCertification)
+	for i, result := range results {
+		if !result.Success {
+			continue
+		}
+		if first, ok := byHash[*result.Certification.ContentHash]; ok {
+			results[i].Certification = nil
+			results[i].Success = false
+			results[i].Error = &DuplicateCertificateError{Existing: first}
+			continue
+		}
+		byHash[*result.Certification.ContentHash] = result.Certification
+		staged = append(staged, result.Certification)
 	}
 
-	if err := os.MkdirAll(service.uploadDir, 0755); err != nil {
-		logger.Fatal("Failed to create upload directory: %v", err)
-		panic(err)
+	var cause error
+	if failure != nil {
+		cause = fmt.Errorf("%w: %s failed", ErrBatchRolledBack, failure.OriginalName)
+	} else if err := c.repo.CreateBatch(staged); err != nil {
+		logger.Error("Failed to commit atomic batch: %v", err)
+		cause = fmt.Errorf("%w: failed to save the certifications: %v", ErrBatchRolledBack, err)
+	} else if err := c.promote(ctx, staging, staged); err != nil {
+		logger.Error("Failed to publish atomic batch: %v", err)
+		for _, certification := range staged {
+			if err := c.repo.Purge(certification.ID); err != nil {
+				logger.Error("Failed to remove certification %d of the rolled back batch: %v", certification.ID, err)
+			}
+		}
+		cause = fmt.Errorf("%w: failed to publish the files: %v", ErrBatchRolledBack, err)
+	} else {
+		logger.Info("Committed atomic batch of %d certifications", len(staged))
+		return results
 	}
 
-	return service
+	logger.Warn("Rolling back atomic batch of %d files: %v", len(filesWithMetadata), cause)
+	for i := range results {
+		if failure != nil && results[i].Index == failure.Index {
+			continue
+		}
+		results[i].Certification = nil
+		results[i].Success = false
+		results[i].Error = cause
+	}
+	return results
 }
 
-// StoreBatch uploads multiple files concurrently, utilizing a worker pool. Returns a slice of UploadResult for each file.
-func (c *careerCertificationService) StoreBatch(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, baseURL string) []UploadResult {
+// promote copies the staged files of the certifications to their public keys. When a copy fails, the files
+// already copied are deleted again.
+func (c *careerCertificationService) promote(ctx context.Context, staging *fileTarget, certifications []*models.CareerCertification) error {
+	var promoted []string
+	for _, certification := range certifications {
+		for _, key := range certificationFiles(certification) {
+			if err := c.copyStaged(ctx, staging, key); err != nil {
+				c.deleteFiles(context.Background(), promoted)
+				return fmt.Errorf("failed to publish %s: %w", key, err)
+			}
+			promoted = append(promoted, key)
+		}
+	}
+	return nil
+}
+
+// copyStaged copies a staged file to the same key in the certification storage
+func (c *careerCertificationService) copyStaged(ctx context.Context, staging *fileTarget, key string) error {
+	info, err := staging.blob.Stat(ctx, staging.prefix+key)
+	if err != nil {
+		return err
+	}
+
+	src, err := staging.blob.Get(ctx, staging.prefix+key)
+	if err != nil {
+		return err
+	}
+	defer src.Close()
+
+	return c.storage.Put(ctx, key, src, info.Size, info.ContentType)
+}
+
+// ClearStaging deletes every file of the staging storage. Atomic batches drop their own staging area, the files
+// left there were staged by a batch interrupted by a crash.
+func (c *careerCertificationService) ClearStaging(ctx context.Context) error {
+	return c.clearStaging(ctx, "")
+}
+
+// clearStaging deletes the files of the staging storage whose key starts with prefix
+func (c *careerCertificationService) clearStaging(ctx context.Context, prefix string) error {
+	objects, err := c.staging.List(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list staged files: %w", err)
+	}
+
+	for _, object := range objects {
+		if strings.HasPrefix(object.Key, prefix) {
+			if err := c.staging.Delete(ctx, object.Key); err != nil {
+				return fmt.Errorf("failed to delete staged file %s: %w", object.Key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// runBatch processes the files with a pool of upload workers. When staging is not nil the files are only written
+// to it, the certifications of the results are not saved.
+func (c *careerCertificationService) runBatch(ctx context.Context, filesWithMetadata []FileWithMetadata, maxWorkers int, staging *fileTarget, onResult func(UploadResult)) []UploadResult {
 	if len(filesWithMetadata) == 0 {
 		return []UploadResult{}
 	}
@@ -95,7 +281,7 @@ func (c *careerCertificationService) StoreBatch(ctx context.Context, filesWithMe
 	// Start workers' goroutines
 	for i := 0; i < maxWorkers; i++ {
 		wg.Add(1)
-		go c.uploadWorker(ctx, i+1, jobs, results, &wg, baseURL)
+		go c.uploadWorker(ctx, i+1, staging, jobs, results, &wg)
 	}
 
 	for _, fwm := range filesWithMetadata {
@@ -111,6 +297,9 @@ func (c *careerCertificationService) StoreBatch(ctx context.Context, filesWithMe
 
 	uploadResults := make([]UploadResult, 0, len(filesWithMetadata))
 	for result := range results {
+		if onResult != nil {
+			onResult(result)
+		}
 		uploadResults = append(uploadResults, result)
 	}
 
@@ -126,11 +315,12 @@ func (c *careerCertificationService) StoreBatch(ctx context.Context, filesWithMe
 }
 
 // uploadWorker handles the upload and processing of career certification files in a concurrent worker routine.
-// It reads file and metadata from the jobs channel, processes the file, and stores the result in the database.
+// It reads file and metadata from the jobs channel, processes the file, and stores the result in the database
+// unless the file is only written to the staging area.
 // Results are sent to the results channel, capturing any errors or success status.
 // The method stops processing when jobs channel is closed or context cancellation occurs.
 // It must be called in a goroutine and signals completion by calling Done on the provided WaitGroup.
-func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID int, jobs <-chan FileWithMetadata, results chan<- UploadResult, wg *sync.WaitGroup, baseURL string) {
+func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID int, staging *fileTarget, jobs <-chan FileWithMetadata, results chan<- UploadResult, wg *sync.WaitGroup) {
 	defer wg.Done()
 
 	for fwm := range jobs {
@@ -140,6 +330,7 @@ func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID
 		select {
 		case <-ctx.Done():
 			results <- UploadResult{
+				Index:        fwm.Index,
 				OriginalName: file.Filename,
 				Error:        ctx.Err(),
 				Success:      false,
@@ -148,25 +339,39 @@ func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID
 		default:
 		}
 
-		ext := strings.ToLower(filepath.Ext(file.Filename))
-
 		// Validate file extension
-		if !utils.AllowedExtensions[ext] {
+		ext, err := certificateExtension(file.Filename)
+		if err != nil {
 			logger.Warn("Worker %d: invalid file type %s for %s", workerID, ext, file.Filename)
 			results <- UploadResult{
+				Index:        fwm.Index,
 				OriginalName: file.Filename,
-				Error:        fmt.Errorf("invalid file type: only JPG, JPEG, PNG, and WEBP images are allowed"),
+				Error:        err,
 				Success:      false,
 			}
 			continue
 		}
 
-		filename := fmt.Sprintf("%d-%s%s", time.Now().UnixNano(), uuid.New().String(), ext)
-		filePath := filepath.Join(c.uploadDir, filename)
+		target := staging
+		if target == nil {
+			target = &fileTarget{blob: c.storage}
+		}
 
-		if err := c.saveFile(file, filePath); err != nil {
+		stored, err := c.storeFile(ctx, target, file, ext)
+		if errors.Is(err, ErrDuplicateCertificate) {
+			logger.Info("Worker %d: skipped %s: %v", workerID, file.Filename, err)
+			results <- UploadResult{
+				Index:        fwm.Index,
+				OriginalName: file.Filename,
+				Error:        err,
+				Success:      false,
+			}
+			continue
+		}
+		if err != nil {
 			logger.Error("Worker %d: failed to save %s: %v", workerID, file.Filename, err)
 			results <- UploadResult{
+				Index:        fwm.Index,
 				OriginalName: file.Filename,
 				Error:        err,
 				Success:      false,
@@ -174,14 +379,30 @@ func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID
 			continue
 		}
 
-		certification := c.buildCareerCertification(metadata, file, baseURL, filename)
+		certification := c.buildCareerCertification(metadata, file, stored)
 
 		c.setOptionalFields(metadata, certification)
 
+		if staging != nil {
+			logger.Debug("Worker %d: staged %s", workerID, stored.fileName)
+			results <- UploadResult{
+				Index:         fwm.Index,
+				Certification: certification,
+				OriginalName:  file.Filename,
+				Success:       true,
+			}
+			continue
+		}
+
 		if err := c.repo.Create(certification); err != nil {
+			// Another worker may have saved the same file since storeFile checked it
+			if duplicate := c.duplicateOf(stored.contentHash); errors.Is(duplicate, ErrDuplicateCertificate) {
+				err = duplicate
+			}
 			logger.Error("Worker %d: failed to save to database: %v", workerID, err)
-			os.Remove(filePath)
+			c.deleteFiles(context.Background(), certificationFiles(certification))
 			results <- UploadResult{
+				Index:        fwm.Index,
 				OriginalName: file.Filename,
 				Error:        err,
 				Success:      false,
@@ -189,8 +410,9 @@ func (c *careerCertificationService) uploadWorker(ctx context.Context, workerID
 			continue
 		}
 
-		logger.Debug("Worker %d: successfully saved %s", workerID, filename)
+		logger.Debug("Worker %d: successfully saved %s", workerID, stored.fileName)
 		results <- UploadResult{
+			Index:         fwm.Index,
 			Certification: certification,
 			OriginalName:  file.Filename,
 			Success:       true,
@@ -221,69 +443,375 @@ func (c *careerCertificationService) setOptionalFields(metadata *dto.Certificati
 }
 
 // buildCareerCertification constructs a CareerCertification model combining metadata, file details, and generated fields.
-func (c *careerCertificationService) buildCareerCertification(metadata *dto.CertificationMetadata, file *multipart.FileHeader, baseURL string, filename string) *models.CareerCertification {
+func (c *careerCertificationService) buildCareerCertification(metadata *dto.CertificationMetadata, file *UploadFile, stored *storedFile) *models.CareerCertification {
 	return &models.CareerCertification{
 		Title:        getOrDefault(metadata.Title, file.Filename),
 		Issuer:       getOrDefault(metadata.Issuer, "N/A"),
 		IssueDate:    parseIssueDate(metadata.IssueDate),
-		FileURL:      fmt.Sprintf("%s/%s", baseURL, filename),
-		FileName:     filename,
+		FileURL:      c.storage.URL(stored.fileName),
+		FileName:     stored.fileName,
 		OriginalName: file.Filename,
-		FileSize:     file.Size,
-		MimeType:     file.Header.Get("Content-Type"),
+		FileSize:     stored.size,
+		MimeType:     stored.mimeType,
+		Width:        stored.width,
+		Height:       stored.height,
+		Blurhash:     stored.blurhash,
+		Renditions:   stored.renditions,
+		ContentHash:  &stored.contentHash,
 	}
 }
 
-// saveFile saves an uploaded file to the specified destination path. It opens the source file, creates the destination file,
-// copies the file data to the destination, and handles cleanup on failure. Returns an error if any operation fails.
-func (c *careerCertificationService) saveFile(file *multipart.FileHeader, path string) error {
-	src, err := file.Open()
+// storedFile describes the files written to storage for an uploaded certificate
+type storedFile struct {
+	fileName    string
+	size        int64
+	mimeType    string
+	width       int
+	height      int
+	blurhash    string
+	renditions  models.Renditions
+	contentHash string
+}
+
+// fileTarget is where storeFile writes the files of a certificate: the blob storage, and the prefix of their keys
+// in it. The keys of the stored files are the ones without the prefix.
+type fileTarget struct {
+	blob   storage.Blob
+	prefix string
+}
+
+// storeFile checks the content of an uploaded certificate against its extension, renders its WebP renditions
+// (from the first page for PDFs) and writes all of them to the target. Images are stored without their metadata.
+// The renditions share the original's name with a "-<rendition>" suffix; nothing is left behind on failure.
+// A file whose original has the same SHA-256 as an existing certification's is not stored, a
+// DuplicateCertificateError is returned instead.
+func (c *careerCertificationService) storeFile(ctx context.Context, target *fileTarget, file *UploadFile, ext string) (*storedFile, error) {
+	isDocument := utils.AllowedDocumentExtensions[ext]
+
+	maxSize := int64(maxImageSize)
+	if isDocument {
+		maxSize = maxDocumentSize
+	}
+
+	data, err := readUpload(file, maxSize)
 	if err != nil {
-		return fmt.Errorf("failed to open uploaded file: %w", err)
+		return nil, err
 	}
-	defer src.Close()
 
-	dst, err := os.Create(path)
+	if _, err := sniffContentType(data, ext); err != nil {
+		return nil, err
+	}
+
+	var processed *imaging.Result
+	if isDocument {
+		processed, err = imaging.ProcessPDF(data, imaging.DefaultRenditions)
+	} else {
+		processed, err = imaging.Process(data, imaging.DefaultRenditions)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The hash is taken from the stored original, so it can be recomputed from the files already in storage
+	sum := sha256.Sum256(processed.Original.Data)
+	contentHash := hex.EncodeToString(sum[:])
+	if err := c.duplicateOf(contentHash); err != nil {
+		return nil, err
+	}
+
+	base := uniqueFileName("")
+	stored := &storedFile{
+		fileName:    base + processed.Original.Extension,
+		size:        int64(len(processed.Original.Data)),
+		mimeType:    processed.Original.ContentType,
+		width:       processed.Width,
+		height:      processed.Height,
+		blurhash:    processed.Blurhash,
+		renditions:  models.Renditions{},
+		contentHash: contentHash,
+	}
+
+	if err := putEncoded(ctx, target, stored.fileName, processed.Original); err != nil {
+		return nil, err
+	}
+
+	for _, rendition := range processed.Renditions {
+		key := fmt.Sprintf("%s-%s%s", base, rendition.Name, rendition.Extension)
+		if err := putEncoded(ctx, target, key, rendition); err != nil {
+			deleteFrom(context.Background(), target, stored.files())
+			return nil, err
+		}
+
+		stored.renditions = append(stored.renditions, models.Rendition{
+			Name:     rendition.Name,
+			URL:      c.storage.URL(key),
+			FileName: key,
+			Width:    rendition.Width,
+			Height:   rendition.Height,
+			Size:     int64(len(rendition.Data)),
+		})
+	}
+
+	return stored, nil
+}
+
+// duplicateOf returns a DuplicateCertificateError when a certification already stores the file with the given hash
+func (c *careerCertificationService) duplicateOf(contentHash string) error {
+	existing, err := c.repo.FindByContentHash(contentHash)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("failed to create destination file: %w", err)
+		return fmt.Errorf("failed to check for duplicate certificates: %w", err)
 	}
-	defer dst.Close()
+	return &DuplicateCertificateError{Existing: existing}
+}
 
-	if _, err := io.Copy(dst, src); err != nil {
-		os.Remove(path)
-		return fmt.Errorf("failed to copy uploaded file: %w", err)
+// files lists the storage keys written for the certificate
+func (f *storedFile) files() []string {
+	keys := []string{f.fileName}
+	for _, rendition := range f.renditions {
+		keys = append(keys, rendition.FileName)
 	}
+	return keys
+}
 
+// putEncoded writes an encoded file to the target under the given key
+func putEncoded(ctx context.Context, target *fileTarget, key string, encoded imaging.Encoded) error {
+	if err := target.blob.Put(ctx, target.prefix+key, bytes.NewReader(encoded.Data), int64(len(encoded.Data)), encoded.ContentType); err != nil {
+		return fmt.Errorf("failed to store uploaded file: %w", err)
+	}
 	return nil
 }
 
-// GetAll retrieves all CareerCertification records from the repository and returns them along with any potential error.
-func (c *careerCertificationService) GetAll() ([]models.CareerCertification, error) {
-	return c.repo.FindAll()
+// deleteFiles removes stored files, failures are only logged since the records no longer point to them
+func (c *careerCertificationService) deleteFiles(ctx context.Context, keys []string) {
+	deleteFrom(ctx, &fileTarget{blob: c.storage}, keys)
+}
+
+// deleteFrom removes files written to the target, failures are only logged
+func deleteFrom(ctx context.Context, target *fileTarget, keys []string) {
+	for _, key := range keys {
+		if err := target.blob.Delete(ctx, target.prefix+key); err != nil {
+			logger.Warn("Failed to delete stored file %s: %v", target.prefix+key, err)
+		}
+	}
+}
+
+// certificationFiles lists the storage keys of a certification file and its renditions
+func certificationFiles(certification *models.CareerCertification) []string {
+	keys := []string{certification.FileName}
+	for _, rendition := range certification.Renditions {
+		keys = append(keys, rendition.FileName)
+	}
+	return keys
+}
+
+// GetAll retrieves the CareerCertification records matching the filter and returns them with the total count.
+func (c *careerCertificationService) GetAll(filter repository.CareerCertificationFilter, opts repository.ListOptions) ([]models.CareerCertification, int64, error) {
+	now := time.Now()
+	filter.Now = now
+	filter.NoticeDays = c.expiryNoticeDays
+
+	certifications, total, err := c.repo.FindAll(filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := range certifications {
+		certifications[i].Status = certifications[i].ExpiryStatus(now, c.expiryNoticeDays)
+	}
+	return certifications, total, nil
 }
 
 // GetByID retrieves a CareerCertification by its unique ID from the repository and returns it.
 func (c *careerCertificationService) GetByID(id uint) (*models.CareerCertification, error) {
-	return c.repo.FindByID(id)
+	cert, err := c.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Certification not found: %d", id)
+			return nil, constants.ErrCertificationNotFound
+		}
+		logger.Error("Failed to fetch certification %d: %v", id, err)
+		return nil, fmt.Errorf("failed to fetch certification: %w", err)
+	}
+	cert.Status = cert.ExpiryStatus(time.Now(), c.expiryNoticeDays)
+	return cert, nil
 }
 
-// Delete removes a career certification by its ID, deletes the corresponding file from disk, and returns an error if any occur.
-func (c *careerCertificationService) Delete(id uint) error {
-	cert, err := c.repo.FindByID(id)
+// Update edits the metadata of a certification, its files are left untouched.
+// A new expiry date is notified again when it approaches.
+func (c *careerCertificationService) Update(id uint, updates map[string]interface{}) error {
+	logger.Info("Updating certification with ID: %d", id)
+	if _, ok := updates["expiry_date"]; ok {
+		updates["expiry_notified_at"] = nil
+	}
+	if err := c.repo.Update(id, updates); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Certification not found for update: %d", id)
+			return constants.ErrCertificationNotFound
+		}
+		logger.Error("Failed to update certification %d: %v", id, err)
+		return fmt.Errorf("failed to update certification: %w", err)
+	}
+
+	logger.Info("Successfully updated certification: %d", id)
+	return nil
+}
+
+// ReplaceFile swaps the file of a certification for a new upload. The new file and its renditions are stored
+// before the record is pointed at them, so a failure at any step leaves the certification serving its old file;
+// the old files are only removed once the record has been updated. A file identical to the current one is not
+// stored again, while one identical to another certification's fails with a DuplicateCertificateError.
+func (c *careerCertificationService) ReplaceFile(ctx context.Context, id uint, file *multipart.FileHeader) (*models.CareerCertification, error) {
+	cert, err := c.GetByID(id)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	ext, err := certificateExtension(file.Filename)
+	if err != nil {
+		logger.Warn("Invalid file type %s for %s", ext, file.Filename)
+		return nil, err
+	}
+
+	stored, err := c.storeFile(ctx, &fileTarget{blob: c.storage}, NewUploadFile(file), ext)
+	var duplicate *DuplicateCertificateError
+	if errors.As(err, &duplicate) {
+		if duplicate.Existing.ID == id {
+			logger.Info("Replacement file %s is identical to the file of certification %d", file.Filename, id)
+			return cert, nil
+		}
+		logger.Warn("Rejected replacement file %s for certification %d: %v", file.Filename, id, err)
+		return nil, err
+	}
+	if err != nil {
+		logger.Error("Failed to store replacement file %s for certification %d: %v", file.Filename, id, err)
+		return nil, err
 	}
 
-	if err := c.repo.Delete(id); err != nil {
+	updates := map[string]interface{}{
+		"file_url":      c.storage.URL(stored.fileName),
+		"file_name":     stored.fileName,
+		"original_name": file.Filename,
+		"file_size":     stored.size,
+		"mime_type":     stored.mimeType,
+		"width":         stored.width,
+		"height":        stored.height,
+		"blurhash":      stored.blurhash,
+		"renditions":    stored.renditions,
+		"content_hash":  stored.contentHash,
+	}
+	if err := c.Update(id, updates); err != nil {
+		c.deleteFiles(context.Background(), stored.files())
+		if duplicate := c.duplicateOf(stored.contentHash); errors.Is(duplicate, ErrDuplicateCertificate) {
+			return nil, duplicate
+		}
+		return nil, err
+	}
+
+	c.deleteFiles(ctx, certificationFiles(cert))
+
+	logger.Info("Replaced file of certification %d with %s", id, stored.fileName)
+	return c.GetByID(id)
+}
+
+// Delete moves a career certification to the trash by its ID. Its files are kept so it can be restored, they are
+// deleted when the certification is purged from the trash.
+func (c *careerCertificationService) Delete(ctx context.Context, id uint) error {
+	if _, err := c.GetByID(id); err != nil {
 		return err
 	}
 
-	filePath := filepath.Join(c.uploadDir, cert.FileName)
-	if err := os.Remove(filePath); err != nil {
-		logger.Warn("Failed to delete physical file %s: %v", filePath, err)
+	return c.repo.Delete(id)
+}
+
+// Deduplicate finds the certifications whose stored files are identical and keeps only the oldest of each group:
+// the newer ones are deleted for good, without going through the trash, along with their files. The content hash of the certifications uploaded before
+// hashing existed is computed from their stored file and saved. In dry-run mode nothing is changed.
+func (c *careerCertificationService) Deduplicate(ctx context.Context, dryRun bool) (*DedupeReport, error) {
+	certifications, err := c.repo.FindAllInCreationOrder()
+	if err != nil {
+		logger.Error("Failed to fetch certifications: %v", err)
+		return nil, fmt.Errorf("failed to fetch certifications: %w", err)
 	}
 
-	return nil
+	report := &DedupeReport{Scanned: len(certifications)}
+	kept := make(map[string]uint)
+	var backfill []models.CareerCertification
+
+	for _, certification := range certifications {
+		if certification.FileName == "" {
+			// Imported from a résumé, there is no file to compare
+			continue
+		}
+
+		hashed := certification.ContentHash == nil
+		if hashed {
+			contentHash, err := c.hashStoredFile(ctx, certification.FileName)
+			if err != nil {
+				logger.Warn("Failed to hash file of certification %d: %v", certification.ID, err)
+				report.Missing = append(report.Missing, certification)
+				continue
+			}
+			certification.ContentHash = &contentHash
+		}
+		contentHash := *certification.ContentHash
+
+		if originalID, ok := kept[contentHash]; ok {
+			report.Duplicates = append(report.Duplicates, DuplicateCertification{Certification: certification, DuplicateOf: originalID})
+			report.FreedBytes += certification.FileSize
+			for _, rendition := range certification.Renditions {
+				report.FreedBytes += rendition.Size
+			}
+			continue
+		}
+
+		kept[contentHash] = certification.ID
+		if hashed {
+			backfill = append(backfill, certification)
+		}
+	}
+	report.Hashed = len(backfill)
+
+	if dryRun {
+		return report, nil
+	}
+
+	// The duplicates go first, the unique index would reject the hash of their original otherwise
+	for _, duplicate := range report.Duplicates {
+		if err := c.repo.Purge(duplicate.Certification.ID); err != nil {
+			logger.Error("Failed to delete duplicate certification %d: %v", duplicate.Certification.ID, err)
+			return nil, fmt.Errorf("failed to delete duplicate certification %d: %w", duplicate.Certification.ID, err)
+		}
+		c.deleteFiles(ctx, certificationFiles(&duplicate.Certification))
+		logger.Info("Deleted certification %d, a duplicate of %d", duplicate.Certification.ID, duplicate.DuplicateOf)
+	}
+
+	for _, certification := range backfill {
+		if err := c.repo.Update(certification.ID, map[string]interface{}{"content_hash": *certification.ContentHash}); err != nil {
+			logger.Error("Failed to save content hash of certification %d: %v", certification.ID, err)
+			return nil, fmt.Errorf("failed to save content hash of certification %d: %w", certification.ID, err)
+		}
+	}
+
+	logger.Info("Deduplicated %d certifications: %d duplicates removed, %d hashes saved", report.Scanned, len(report.Duplicates), report.Hashed)
+	return report, nil
+}
+
+// hashStoredFile computes the hex SHA-256 of a file in storage
+func (c *careerCertificationService) hashStoredFile(ctx context.Context, key string) (string, error) {
+	src, err := c.storage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, src); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
 }
 
 // getOrDefault returns the `value` if it is not an empty string, otherwise it returns the `defaultValue`.
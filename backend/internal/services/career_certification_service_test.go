@@ -0,0 +1,235 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"sort"
+	"testing"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
+)
+
+// batchRepository fails CreateBatch when failCreate is set, the other methods use the database
+type batchRepository struct {
+	repository.CareerCertificationRepository
+	failCreate bool
+}
+
+func (r *batchRepository) CreateBatch(certifications []*models.CareerCertification) error {
+	if r.failCreate {
+		return errors.New("database is locked")
+	}
+	return r.CareerCertificationRepository.CreateBatch(certifications)
+}
+
+// failingPutBlob fails every Put after the first allowed ones
+type failingPutBlob struct {
+	storage.Blob
+	allowed int
+}
+
+func (b *failingPutBlob) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	if b.allowed == 0 {
+		return errors.New("bucket unavailable")
+	}
+	b.allowed--
+	return b.Blob.Put(ctx, key, r, size, contentType)
+}
+
+// testPNG encodes a small PNG filled with the given shade of gray, so every shade is a different file
+func testPNG(t *testing.T, shade uint8) []byte {
+	t.Helper()
+
+	img := image.NewGray(image.Rect(0, 0, 64, 48))
+	for i := range img.Pix {
+		img.Pix[i] = shade
+	}
+	img.SetGray(0, 0, color.Gray{Y: shade + 1})
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return buf.Bytes()
+}
+
+// uploadOf wraps data as the index-th file of a batch
+func uploadOf(index int, filename string, data []byte) FileWithMetadata {
+	return FileWithMetadata{
+		Index: index,
+		File: &UploadFile{
+			Filename: filename,
+			Size:     int64(len(data)),
+			open: func() (io.ReadCloser, error) {
+				return io.NopCloser(bytes.NewReader(data)), nil
+			},
+		},
+		Metadata: &dto.CertificationMetadata{},
+	}
+}
+
+// storedKeys lists the keys of a blob storage, sorted
+func storedKeys(t *testing.T, blob storage.Blob) []string {
+	t.Helper()
+
+	objects, err := blob.List(context.Background())
+	if err != nil {
+		t.Fatalf("failed to list stored files: %v", err)
+	}
+	keys := make([]string, 0, len(objects))
+	for _, object := range objects {
+		keys = append(keys, object.Key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestStoreBatchAtomic(t *testing.T) {
+	tests := []struct {
+		name        string
+		files       func(t *testing.T) []FileWithMetadata
+		failCreate  bool
+		allowedPuts int
+		wantSaved   bool
+	}{
+		{
+			name: "every file stored",
+			files: func(t *testing.T) []FileWithMetadata {
+				return []FileWithMetadata{uploadOf(0, "first.png", testPNG(t, 10)), uploadOf(1, "second.png", testPNG(t, 200))}
+			},
+			allowedPuts: -1,
+			wantSaved:   true,
+		},
+		{
+			name: "one failing file",
+			files: func(t *testing.T) []FileWithMetadata {
+				return []FileWithMetadata{uploadOf(0, "first.png", testPNG(t, 10)), uploadOf(1, "broken.png", []byte("\x89PNG\r\n\x1a\nnot an image"))}
+			},
+			allowedPuts: -1,
+		},
+		{
+			name: "failing CreateBatch",
+			files: func(t *testing.T) []FileWithMetadata {
+				return []FileWithMetadata{uploadOf(0, "first.png", testPNG(t, 10)), uploadOf(1, "second.png", testPNG(t, 200))}
+			},
+			failCreate:  true,
+			allowedPuts: -1,
+		},
+		{
+			name: "failing publication",
+			files: func(t *testing.T) []FileWithMetadata {
+				return []FileWithMetadata{uploadOf(0, "first.png", testPNG(t, 10)), uploadOf(1, "second.png", testPNG(t, 200))}
+			},
+			allowedPuts: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newTestDB(t, &models.CareerCertification{})
+			repo := &batchRepository{CareerCertificationRepository: repository.NewCareerCertificationRepository(db), failCreate: tt.failCreate}
+
+			public, err := storage.NewLocalBlob(t.TempDir(), "http://localhost/certifications")
+			if err != nil {
+				t.Fatalf("failed to create storage: %v", err)
+			}
+			staging, err := storage.NewLocalBlob(t.TempDir(), "http://localhost/certification-staging")
+			if err != nil {
+				t.Fatalf("failed to create staging storage: %v", err)
+			}
+
+			var blob storage.Blob = public
+			if tt.allowedPuts >= 0 {
+				blob = &failingPutBlob{Blob: public, allowed: tt.allowedPuts}
+			}
+			service := NewCareerCertificationService(repo, blob, staging, 30)
+
+			// Nothing may be public before the records are committed
+			var publishedEarly []string
+			results := service.StoreBatchAtomic(context.Background(), tt.files(t), 2, func(UploadResult) {
+				publishedEarly = append(publishedEarly, storedKeys(t, public)...)
+			})
+			if len(publishedEarly) > 0 {
+				t.Errorf("files were public while the batch was staged: %v", publishedEarly)
+			}
+
+			var rows int64
+			if err := db.Model(&models.CareerCertification{}).Count(&rows).Error; err != nil {
+				t.Fatalf("failed to count certifications: %v", err)
+			}
+			if keys := storedKeys(t, staging); len(keys) > 0 {
+				t.Errorf("the staging area was not dropped: %v", keys)
+			}
+
+			if !tt.wantSaved {
+				if rows != 0 {
+					t.Errorf("%d certifications saved, want none", rows)
+				}
+				if keys := storedKeys(t, public); len(keys) > 0 {
+					t.Errorf("files stored after the rollback: %v", keys)
+				}
+				for _, result := range results {
+					if result.Success {
+						t.Errorf("file %d succeeded in a rolled back batch", result.Index)
+					}
+				}
+				return
+			}
+
+			if rows != int64(len(results)) {
+				t.Errorf("%d certifications saved, want %d", rows, len(results))
+			}
+			var want []string
+			for _, result := range results {
+				if !result.Success {
+					t.Fatalf("file %d failed: %v", result.Index, result.Error)
+				}
+				want = append(want, certificationFiles(result.Certification)...)
+			}
+			sort.Strings(want)
+			if got := storedKeys(t, public); !equalStrings(got, want) {
+				t.Errorf("stored files = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestClearStaging(t *testing.T) {
+	staging, err := storage.NewLocalBlob(t.TempDir(), "http://localhost/certification-staging")
+	if err != nil {
+		t.Fatalf("failed to create staging storage: %v", err)
+	}
+	for _, key := range []string{"1-batch-a.png", "2-batch-b.png"} {
+		if err := staging.Put(context.Background(), key, bytes.NewReader([]byte("data")), 4, "image/png"); err != nil {
+			t.Fatalf("failed to stage %s: %v", key, err)
+		}
+	}
+
+	service := NewCareerCertificationService(nil, nil, staging, 30)
+	if err := service.ClearStaging(context.Background()); err != nil {
+		t.Fatalf("ClearStaging returned an error: %v", err)
+	}
+	if keys := storedKeys(t, staging); len(keys) > 0 {
+		t.Errorf("staged files left: %v", keys)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
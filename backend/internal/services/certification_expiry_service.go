@@ -0,0 +1,164 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/notify"
+)
+
+// ExpiringCertificationsEvent is the event of the notification listing the certifications about to expire
+const ExpiringCertificationsEvent = "certifications.expiring"
+
+// expiryNotificationTimeout bounds the delivery of a notification to every destination
+const expiryNotificationTimeout = time.Minute
+
+// ExpiringCertification is a certification listed in the expiry notification sent to webhooks
+type ExpiringCertification struct {
+	ID            uint   `json:"id"`
+	Title         string `json:"title"`
+	Issuer        string `json:"issuer"`
+	ExpiryDate    string `json:"expiry_date"`
+	DaysLeft      int    `json:"days_left"`
+	CredentialURL string `json:"credential_url,omitempty"`
+}
+
+// CertificationExpiryNotifier warns the admin about the certifications that are about to expire
+type CertificationExpiryNotifier interface {
+	Start()
+	Check(ctx context.Context) (int, error)
+}
+
+// certificationExpiryNotifier checks for expiring certifications periodically and notifies each expiry once
+type certificationExpiryNotifier struct {
+	repo       repository.CareerCertificationRepository
+	sender     notify.Sender
+	noticeDays int
+	interval   time.Duration
+}
+
+// NewCertificationExpiryNotifier creates a CertificationExpiryNotifier that notifies the certifications expiring
+// within noticeDays through sender, checking every interval. A nil sender disables the notifications.
+func NewCertificationExpiryNotifier(repo repository.CareerCertificationRepository, sender notify.Sender, noticeDays int, interval time.Duration) CertificationExpiryNotifier {
+	return &certificationExpiryNotifier{
+		repo:       repo,
+		sender:     sender,
+		noticeDays: noticeDays,
+		interval:   interval,
+	}
+}
+
+// Start runs a check right away and then every interval in the background
+func (n *certificationExpiryNotifier) Start() {
+	if n.sender == nil {
+		logger.Info("Certification expiry notifications disabled: no email or webhook configured")
+		return
+	}
+
+	logger.Info("Checking for certifications expiring within %d days every %s", n.noticeDays, n.interval)
+	go func() {
+		ticker := time.NewTicker(n.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := n.Check(context.Background()); err != nil {
+				logger.Error("Failed to notify expiring certifications: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Check notifies the certifications that expire within the notice period and were not notified yet, in a
+// single notification, and returns how many were notified. They are only marked as notified once the
+// notification was delivered to every destination, otherwise the next check tries again.
+func (n *certificationExpiryNotifier) Check(ctx context.Context) (int, error) {
+	if n.sender == nil {
+		return 0, nil
+	}
+
+	now := time.Now()
+	certifications, err := n.repo.FindExpiringUnnotified(now, now.AddDate(0, 0, n.noticeDays))
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch expiring certifications: %w", err)
+	}
+	if len(certifications) == 0 {
+		logger.Debug("No certifications to notify about")
+		return 0, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, expiryNotificationTimeout)
+	defer cancel()
+
+	if err := n.sender.Send(ctx, expiryNotification(certifications, now)); err != nil {
+		return 0, err
+	}
+
+	ids := make([]uint, len(certifications))
+	for i, certification := range certifications {
+		ids[i] = certification.ID
+	}
+	if err := n.repo.MarkExpiryNotified(ids, now); err != nil {
+		return 0, fmt.Errorf("failed to record expiry notifications: %w", err)
+	}
+
+	logger.Info("Notified %d expiring certifications", len(certifications))
+	return len(certifications), nil
+}
+
+// expiryNotification lists the expiring certifications, soonest first
+func expiryNotification(certifications []models.CareerCertification, now time.Time) notify.Notification {
+	today, _ := time.Parse(time.DateOnly, now.UTC().Format(time.DateOnly))
+
+	expiring := make([]ExpiringCertification, len(certifications))
+	var body strings.Builder
+	body.WriteString("The following certifications are about to expire:\n\n")
+
+	for i, certification := range certifications {
+		expiry := certification.ExpiryDate.UTC()
+		expiring[i] = ExpiringCertification{
+			ID:         certification.ID,
+			Title:      certification.Title,
+			Issuer:     certification.Issuer,
+			ExpiryDate: expiry.Format(time.DateOnly),
+			DaysLeft:   int(expiry.Truncate(24*time.Hour).Sub(today).Hours() / 24),
+		}
+		if certification.CredentialURL != nil {
+			expiring[i].CredentialURL = *certification.CredentialURL
+		}
+
+		fmt.Fprintf(&body, "- %s (%s) expires on %s, %s\n", certification.Title, certification.Issuer, expiring[i].ExpiryDate, daysLeft(expiring[i].DaysLeft))
+		if expiring[i].CredentialURL != "" {
+			fmt.Fprintf(&body, "  %s\n", expiring[i].CredentialURL)
+		}
+	}
+
+	subject := fmt.Sprintf("%s is about to expire", certifications[0].Title)
+	if len(certifications) > 1 {
+		subject = fmt.Sprintf("%d certifications are about to expire", len(certifications))
+	}
+
+	return notify.Notification{
+		Event:   ExpiringCertificationsEvent,
+		Subject: subject,
+		Body:    body.String(),
+		Data:    map[string]interface{}{"certifications": expiring},
+	}
+}
+
+// daysLeft describes the days left before an expiry
+func daysLeft(days int) string {
+	switch days {
+	case 0:
+		return "today"
+	case 1:
+		return "tomorrow"
+	default:
+		return fmt.Sprintf("in %d days", days)
+	}
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/notify"
+)
+
+// expiryRepository keeps certifications in memory for the expiry notifier, the other repository methods are
+// not used
+type expiryRepository struct {
+	repository.CareerCertificationRepository
+	certifications []models.CareerCertification
+	marked         [][]uint
+}
+
+func (r *expiryRepository) FindExpiringUnnotified(from, until time.Time) ([]models.CareerCertification, error) {
+	var expiring []models.CareerCertification
+	for _, certification := range r.certifications {
+		if certification.ExpiryNotifiedAt != nil || certification.ExpiryDate == nil {
+			continue
+		}
+		if certification.ExpiryDate.Before(from) || certification.ExpiryDate.After(until) {
+			continue
+		}
+		expiring = append(expiring, certification)
+	}
+	return expiring, nil
+}
+
+func (r *expiryRepository) MarkExpiryNotified(ids []uint, at time.Time) error {
+	r.marked = append(r.marked, ids)
+	for i := range r.certifications {
+		for _, id := range ids {
+			if r.certifications[i].ID == id {
+				r.certifications[i].ExpiryNotifiedAt = &at
+			}
+		}
+	}
+	return nil
+}
+
+// failingSender fails the first failures sends and records the delivered notifications
+type failingSender struct {
+	failures  int
+	attempts  int
+	delivered []notify.Notification
+}
+
+func (s *failingSender) Send(_ context.Context, notification notify.Notification) error {
+	s.attempts++
+	if s.attempts <= s.failures {
+		return errors.New("smtp server unavailable")
+	}
+	s.delivered = append(s.delivered, notification)
+	return nil
+}
+
+func TestCertificationExpiryNotifierCheck(t *testing.T) {
+	soon := time.Now().AddDate(0, 0, 10)
+	later := time.Now().AddDate(0, 0, 90)
+	repo := &expiryRepository{certifications: []models.CareerCertification{
+		{ID: 1, Title: "Kubernetes Administrator", Issuer: "CNCF", ExpiryDate: &soon},
+		{ID: 2, Title: "Cloud Architect", Issuer: "Google", ExpiryDate: &later},
+		{ID: 3, Title: "Go Developer", Issuer: "Acme"},
+	}}
+	sender := &failingSender{failures: 1}
+	notifier := NewCertificationExpiryNotifier(repo, sender, 30, time.Hour)
+
+	// A failed delivery leaves the certification unnotified
+	notified, err := notifier.Check(context.Background())
+	if err == nil {
+		t.Fatal("Check returned no error for a failed send")
+	}
+	if notified != 0 {
+		t.Errorf("Check notified %d certifications on a failed send, want 0", notified)
+	}
+	if len(repo.marked) != 0 {
+		t.Errorf("certifications %v were marked as notified after a failed send", repo.marked)
+	}
+
+	// The next check retries and marks it once delivered
+	notified, err = notifier.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check returned an error: %v", err)
+	}
+	if notified != 1 {
+		t.Errorf("Check notified %d certifications, want 1", notified)
+	}
+	if len(sender.delivered) != 1 {
+		t.Fatalf("%d notifications delivered, want 1", len(sender.delivered))
+	}
+	if got := sender.delivered[0].Subject; got != "Kubernetes Administrator is about to expire" {
+		t.Errorf("subject = %q", got)
+	}
+	if len(repo.marked) != 1 || len(repo.marked[0]) != 1 || repo.marked[0][0] != 1 {
+		t.Errorf("marked %v as notified, want [[1]]", repo.marked)
+	}
+
+	// A notified expiry is not sent again
+	notified, err = notifier.Check(context.Background())
+	if err != nil {
+		t.Fatalf("Check returned an error: %v", err)
+	}
+	if notified != 0 || sender.attempts != 2 {
+		t.Errorf("a notified certification was sent again: %d notified, %d attempts", notified, sender.attempts)
+	}
+}
+
+func TestCertificationExpiryNotifierCheckWithoutSender(t *testing.T) {
+	notifier := NewCertificationExpiryNotifier(&expiryRepository{}, nil, 30, time.Hour)
+
+	notified, err := notifier.Check(context.Background())
+	if err != nil || notified != 0 {
+		t.Errorf("Check without a sender = %d, %v, want 0, nil", notified, err)
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// EducationService defines the interface for education business logic
+type EducationService interface {
+	GetAllEducation(filter repository.EducationFilter, opts repository.ListOptions) ([]models.Education, int64, error)
+	GetEducationByID(id uint) (*models.Education, error)
+	CreateEducation(education *models.Education) error
+	UpdateEducation(id uint, updates map[string]interface{}) error
+	DeleteEducation(id uint) error
+}
+
+// educationService implements EducationService interface
+type educationService struct {
+	repo repository.EducationRepository
+}
+
+// NewEducationService creates a new instance of EducationService
+func NewEducationService(repo repository.EducationRepository) EducationService {
+	return &educationService{repo: repo}
+}
+
+// GetAllEducation retrieves the education entries matching the filter and the total count
+func (s *educationService) GetAllEducation(filter repository.EducationFilter, opts repository.ListOptions) ([]models.Education, int64, error) {
+	logger.Debug("Fetching all education entries")
+	education, total, err := s.repo.FindAll(filter, opts)
+	if err != nil {
+		logger.Error("Failed to fetch education entries: %v", err)
+		return nil, 0, fmt.Errorf("failed to fetch education entries: %w", err)
+	}
+
+	logger.Info("Successfully fetched %d of %d education entries", len(education), total)
+	return education, total, nil
+}
+
+// GetEducationByID retrieves a single education entry by ID
+func (s *educationService) GetEducationByID(id uint) (*models.Education, error) {
+	logger.Debug("Fetching education with ID: %d", id)
+	education, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Education not found: %d", id)
+			return nil, constants.ErrEducationNotFound
+		}
+		logger.Error("Failed to fetch education %d: %v", id, err)
+		return nil, fmt.Errorf("failed to fetch education: %w", err)
+	}
+
+	logger.Info("Successfully fetched education: %d", id)
+	return education, nil
+}
+
+// CreateEducation creates a new education entry
+func (s *educationService) CreateEducation(education *models.Education) error {
+	logger.Info("Creating new education: %s at %s", education.StudyType, education.Institution)
+	if err := s.repo.Create(education); err != nil {
+		logger.Error("Failed to create education: %v", err)
+		return fmt.Errorf("failed to create education: %w", err)
+	}
+
+	logger.Info("Successfully created education with ID: %d", education.ID)
+	return nil
+}
+
+// UpdateEducation updates an existing education entry
+func (s *educationService) UpdateEducation(id uint, updates map[string]interface{}) error {
+	logger.Info("Updating education with ID: %d", id)
+	if err := s.repo.Update(id, updates); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Education not found for update: %d", id)
+			return constants.ErrEducationNotFound
+		}
+		logger.Error("Failed to update education %d: %v", id, err)
+		return fmt.Errorf("failed to update education: %w", err)
+	}
+
+	logger.Info("Successfully updated education: %d", id)
+	return nil
+}
+
+// DeleteEducation deletes an education entry by ID
+func (s *educationService) DeleteEducation(id uint) error {
+	logger.Info("Deleting education with ID: %d", id)
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Education not found for deletion: %d", id)
+			return constants.ErrEducationNotFound
+		}
+		logger.Error("Failed to delete education %d: %v", id, err)
+		return fmt.Errorf("failed to delete education: %w", err)
+	}
+
+	logger.Info("Successfully deleted education: %d", id)
+	return nil
+}
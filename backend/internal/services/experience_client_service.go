@@ -57,6 +57,10 @@ func (s *experienceClientService) CreateClient(experienceID uint, client *models
 	logger.Info("Creating new client for experience ID: %d", experienceID)
 	client.ExperienceID = experienceID
 	if err := s.repo.Create(client); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Experience not found for new client: %d", experienceID)
+			return constants.ErrExperienceNotFound
+		}
 		logger.Error("Failed to create client for experience %d: %v", experienceID, err)
 		return fmt.Errorf("creating client: %w", err)
 	}
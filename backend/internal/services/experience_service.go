@@ -13,7 +13,7 @@ import (
 
 // ExperienceService defines the interface for experience business logic
 type ExperienceService interface {
-	GetAllExperiences() ([]models.Experience, error)
+	GetAllExperiences(filter repository.ExperienceFilter, opts repository.ListOptions) ([]models.Experience, int64, error)
 	GetExperienceByID(id uint) (*models.Experience, error)
 	CreateExperience(experience *models.Experience) error
 	UpdateExperience(id uint, updates map[string]interface{}) error
@@ -30,17 +30,17 @@ func NewExperienceService(repo repository.ExperienceRepository) ExperienceServic
 	return &experienceService{repo: repo}
 }
 
-// GetAllExperiences retrieves all experiences
-func (s *experienceService) GetAllExperiences() ([]models.Experience, error) {
+// GetAllExperiences retrieves the experiences matching the filter and the total count
+func (s *experienceService) GetAllExperiences(filter repository.ExperienceFilter, opts repository.ListOptions) ([]models.Experience, int64, error) {
 	logger.Debug("Fetching all experiences")
-	experiences, err := s.repo.FindAll()
+	experiences, total, err := s.repo.FindAll(filter, opts)
 	if err != nil {
 		logger.Error("Failed to fetch experiences: %v", err)
-		return nil, fmt.Errorf("failed to fetch experiences: %w", err)
+		return nil, 0, fmt.Errorf("failed to fetch experiences: %w", err)
 	}
 
-	logger.Info("Successfully fetched %d experiences", len(experiences))
-	return experiences, nil
+	logger.Info("Successfully fetched %d of %d experiences", len(experiences), total)
+	return experiences, total, nil
 }
 
 // GetExperienceByID retrieves a single experience by ID
@@ -88,7 +88,7 @@ func (s *experienceService) UpdateExperience(id uint, updates map[string]interfa
 	return nil
 }
 
-// DeleteExperience deletes an experience by ID
+// DeleteExperience moves an experience to the trash along with its clients; restoring it brings them back
 func (s *experienceService) DeleteExperience(id uint) error {
 	logger.Info("Deleting experience with ID: %d", id)
 	err := s.repo.Delete(id)
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrInvalidImageType is returned when an uploaded file does not have one of the utils.AllowedExtensions
+	ErrInvalidImageType = errors.New("invalid file type: only JPG, JPEG, PNG, and WEBP images are allowed")
+	// ErrInvalidCertificateType is returned when a certificate is neither an allowed image nor a PDF
+	ErrInvalidCertificateType = errors.New("invalid file type: only JPG, JPEG, PNG, WEBP images and PDF documents are allowed")
+	// ErrContentMismatch is returned when the bytes of a file do not match its extension
+	ErrContentMismatch = errors.New("file content does not match its extension")
+	// ErrFileTooLarge is returned when an uploaded file exceeds the size accepted for its type
+	ErrFileTooLarge = errors.New("the file exceeds the maximum size")
+)
+
+const (
+	// maxImageSize is the largest image accepted by the upload pipeline, in bytes
+	maxImageSize = 20 << 20
+	// maxDocumentSize is the largest PDF certificate accepted, in bytes
+	maxDocumentSize = 20 << 20
+)
+
+// UploadFile is a file received in an upload request. The files of upload jobs are staged on disk,
+// since the multipart files of a request are removed once its handler returns.
+type UploadFile struct {
+	Filename string
+	Size     int64
+	open     func() (io.ReadCloser, error)
+}
+
+// NewUploadFile wraps a file of a multipart request
+func NewUploadFile(file *multipart.FileHeader) *UploadFile {
+	return &UploadFile{
+		Filename: file.Filename,
+		Size:     file.Size,
+		open: func() (io.ReadCloser, error) {
+			return file.Open()
+		},
+	}
+}
+
+// newStagedFile wraps a file staged on disk, keeping the name it was uploaded with
+func newStagedFile(path, filename string, size int64) *UploadFile {
+	return &UploadFile{
+		Filename: filename,
+		Size:     size,
+		open: func() (io.ReadCloser, error) {
+			return os.Open(path)
+		},
+	}
+}
+
+// Open opens the file for reading
+func (f *UploadFile) Open() (io.ReadCloser, error) {
+	return f.open()
+}
+
+// imageExtension returns the lower-cased extension of filename, or ErrInvalidImageType when it is not an allowed image
+func imageExtension(filename string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !utils.AllowedExtensions[ext] {
+		return ext, ErrInvalidImageType
+	}
+	return ext, nil
+}
+
+// certificateExtension returns the lower-cased extension of filename, or ErrInvalidCertificateType when it is
+// neither an allowed image nor an allowed document
+func certificateExtension(filename string) (string, error) {
+	ext := strings.ToLower(filepath.Ext(filename))
+	if !utils.AllowedExtensions[ext] && !utils.AllowedDocumentExtensions[ext] {
+		return ext, ErrInvalidCertificateType
+	}
+	return ext, nil
+}
+
+// sniffContentType detects the content type from the first bytes of a file and checks it matches the extension,
+// so a renamed executable or HTML page cannot be stored as an image
+func sniffContentType(data []byte, ext string) (string, error) {
+	detected, _, _ := strings.Cut(http.DetectContentType(data), ";")
+	if expected := utils.ExtensionContentTypes[ext]; detected != expected {
+		return "", fmt.Errorf("%w: %s files must contain %s, got %s", ErrContentMismatch, ext, expected, detected)
+	}
+	return detected, nil
+}
+
+// sniffUpload reads the head of an uploaded file and checks its content matches the extension
+func sniffUpload(file *multipart.FileHeader, ext string) (string, error) {
+	src, err := file.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(src, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	return sniffContentType(head[:n], ext)
+}
+
+// uniqueFileName builds a collision-free file name with the given extension
+func uniqueFileName(ext string) string {
+	return fmt.Sprintf("%d-%s%s", time.Now().UnixNano(), uuid.New().String(), ext)
+}
+
+// readUpload reads an uploaded file into memory, rejecting files larger than maxSize bytes
+func readUpload(file *UploadFile, maxSize int64) ([]byte, error) {
+	if file.Size > maxSize {
+		return nil, fmt.Errorf("%w of %dMB", ErrFileTooLarge, maxSize>>20)
+	}
+
+	src, err := file.Open()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, maxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	if int64(len(data)) > maxSize {
+		return nil, fmt.Errorf("%w of %dMB", ErrFileTooLarge, maxSize>>20)
+	}
+
+	return data, nil
+}
+
+// saveFile saves an uploaded file to the specified destination path. It opens the source file, creates the destination file,
+// copies the file data to the destination, and handles cleanup on failure. Returns an error if any operation fails.
+func saveFile(file *multipart.FileHeader, path string) error {
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	dst, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer dst.Close()
+
+	if _, err := io.Copy(dst, src); err != nil {
+		os.Remove(path)
+		return fmt.Errorf("failed to copy uploaded file: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// LanguageService defines the interface for spoken language business logic
+type LanguageService interface {
+	GetAllLanguages(opts repository.ListOptions) ([]models.Language, int64, error)
+	GetLanguageByID(id uint) (*models.Language, error)
+	CreateLanguage(language *models.Language) error
+	UpdateLanguage(id uint, updates map[string]interface{}) error
+	DeleteLanguage(id uint) error
+}
+
+// languageService implements LanguageService interface
+type languageService struct {
+	repo repository.LanguageRepository
+}
+
+// NewLanguageService creates a new instance of LanguageService
+func NewLanguageService(repo repository.LanguageRepository) LanguageService {
+	return &languageService{repo: repo}
+}
+
+// GetAllLanguages retrieves the languages and the total count
+func (s *languageService) GetAllLanguages(opts repository.ListOptions) ([]models.Language, int64, error) {
+	logger.Debug("Fetching all languages")
+	languages, total, err := s.repo.FindAll(opts)
+	if err != nil {
+		logger.Error("Failed to fetch languages: %v", err)
+		return nil, 0, fmt.Errorf("failed to fetch languages: %w", err)
+	}
+
+	logger.Info("Successfully fetched %d of %d languages", len(languages), total)
+	return languages, total, nil
+}
+
+// GetLanguageByID retrieves a single language by ID
+func (s *languageService) GetLanguageByID(id uint) (*models.Language, error) {
+	logger.Debug("Fetching language with ID: %d", id)
+	language, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Language not found: %d", id)
+			return nil, constants.ErrLanguageNotFound
+		}
+		logger.Error("Failed to fetch language %d: %v", id, err)
+		return nil, fmt.Errorf("failed to fetch language: %w", err)
+	}
+
+	logger.Info("Successfully fetched language: %d", id)
+	return language, nil
+}
+
+// CreateLanguage creates a new language, rejecting duplicated names
+func (s *languageService) CreateLanguage(language *models.Language) error {
+	logger.Info("Creating new language: %s", language.Name)
+	if err := s.ensureUniqueName(language.Name, 0); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(language); err != nil {
+		logger.Error("Failed to create language: %v", err)
+		return fmt.Errorf("failed to create language: %w", err)
+	}
+
+	logger.Info("Successfully created language with ID: %d", language.ID)
+	return nil
+}
+
+// UpdateLanguage updates an existing language
+func (s *languageService) UpdateLanguage(id uint, updates map[string]interface{}) error {
+	logger.Info("Updating language with ID: %d", id)
+	if name, ok := updates["name"].(string); ok {
+		if err := s.ensureUniqueName(name, id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Update(id, updates); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Language not found for update: %d", id)
+			return constants.ErrLanguageNotFound
+		}
+		logger.Error("Failed to update language %d: %v", id, err)
+		return fmt.Errorf("failed to update language: %w", err)
+	}
+
+	logger.Info("Successfully updated language: %d", id)
+	return nil
+}
+
+// DeleteLanguage deletes a language by ID
+func (s *languageService) DeleteLanguage(id uint) error {
+	logger.Info("Deleting language with ID: %d", id)
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Language not found for deletion: %d", id)
+			return constants.ErrLanguageNotFound
+		}
+		logger.Error("Failed to delete language %d: %v", id, err)
+		return fmt.Errorf("failed to delete language: %w", err)
+	}
+
+	logger.Info("Successfully deleted language: %d", id)
+	return nil
+}
+
+// ensureUniqueName fails when another language (other than id) already uses the name
+func (s *languageService) ensureUniqueName(name string, id uint) error {
+	existing, err := s.repo.FindByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to look up language: %w", err)
+	}
+
+	if existing.ID != id {
+		logger.Warn("Language already exists: %s", name)
+		return constants.ErrLanguageAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+)
+
+const (
+	// linkCheckConcurrency is how many URLs are requested at the same time
+	linkCheckConcurrency = 4
+	// linkCheckTimeout bounds each request, redirects included
+	linkCheckTimeout = 15 * time.Second
+	// linkCheckUserAgent identifies the checker to the sites it visits
+	linkCheckUserAgent = "Mozilla/5.0 (compatible; PortfolioLinkChecker/1.0)"
+)
+
+// linkStatusOrder sorts the report, the links that need attention first
+var linkStatusOrder = map[string]int{
+	models.LinkBroken:     0,
+	models.LinkUnverified: 1,
+	models.LinkPending:    2,
+	models.LinkOK:         3,
+}
+
+// linkOwner identifies the record a link belongs to
+type linkOwner struct {
+	ownerType string
+	ownerID   uint
+}
+
+// BrokenLinks are the links hidden from public responses, by record
+type BrokenLinks map[linkOwner]string
+
+// Hides reports whether url, the current link of the given record, is hidden
+func (b BrokenLinks) Hides(ownerType string, ownerID uint, url string) bool {
+	return url != "" && b[linkOwner{ownerType: ownerType, ownerID: ownerID}] == url
+}
+
+// LinkHealthService checks the URLs of the portfolio records periodically and reports the broken ones
+type LinkHealthService interface {
+	Start()
+	Check(ctx context.Context) error
+	Report(status string) (*models.LinkHealthReport, error)
+	BrokenLinks() BrokenLinks
+}
+
+type linkHealthService struct {
+	repo       repository.LinkHealthRepository
+	client     *http.Client
+	interval   time.Duration
+	hideBroken bool
+
+	mu     sync.RWMutex
+	broken BrokenLinks
+}
+
+// linkProbe is the outcome of requesting a URL
+type linkProbe struct {
+	status     string
+	statusCode int
+	err        string
+}
+
+// NewLinkHealthService creates a LinkHealthService that checks every link each interval. When hideBroken is
+// set, links found broken constants.LinkHealthHideAfterFailures times in a row are left out of public responses.
+func NewLinkHealthService(repo repository.LinkHealthRepository, interval time.Duration, hideBroken bool) LinkHealthService {
+	return &linkHealthService{
+		repo:       repo,
+		client:     &http.Client{Timeout: linkCheckTimeout},
+		interval:   interval,
+		hideBroken: hideBroken,
+		broken:     BrokenLinks{},
+	}
+}
+
+// Start loads the last checks and runs the checker in the background. The first check waits for the interval
+// to elapse since the previous run, so restarting the server does not probe every site again.
+func (s *linkHealthService) Start() {
+	checks, err := s.repo.FindChecks()
+	if err != nil {
+		logger.Error("Failed to load link checks: %v", err)
+	}
+	s.refreshBroken(checks)
+
+	var lastRun time.Time
+	for _, check := range checks {
+		if check.CheckedAt.After(lastRun) {
+			lastRun = check.CheckedAt
+		}
+	}
+
+	logger.Info("Checking links every %s", s.interval)
+	go func() {
+		if wait := time.Until(lastRun.Add(s.interval)); wait > 0 {
+			time.Sleep(wait)
+		}
+
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if err := s.Check(context.Background()); err != nil {
+				logger.Error("Failed to check links: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Check requests every link, each distinct URL once, and stores the outcome. The checks of the records that no
+// longer have a link are removed.
+func (s *linkHealthService) Check(ctx context.Context) error {
+	links, err := s.repo.FindLinks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	checks, err := s.repo.FindChecks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch link checks: %w", err)
+	}
+	previous := make(map[linkOwner]models.LinkCheck, len(checks))
+	for _, check := range checks {
+		previous[linkOwner{ownerType: check.OwnerType, ownerID: check.OwnerID}] = check
+	}
+
+	logger.Info("Checking %d links", len(links))
+	probes := s.probeAll(ctx, links)
+
+	now := time.Now()
+	current := make(map[linkOwner]bool, len(links))
+	broken := 0
+	for _, link := range links {
+		owner := linkOwner{ownerType: link.OwnerType, ownerID: link.OwnerID}
+		current[owner] = true
+		probe := probes[link.URL]
+
+		check := models.LinkCheck{
+			OwnerType:  link.OwnerType,
+			OwnerID:    link.OwnerID,
+			URL:        link.URL,
+			Status:     probe.status,
+			StatusCode: probe.statusCode,
+			Error:      probe.err,
+			CheckedAt:  now,
+		}
+		if probe.status == models.LinkBroken {
+			broken++
+			check.ConsecutiveFailures = 1
+			if last, ok := previous[owner]; ok && last.URL == link.URL && last.Status == models.LinkBroken {
+				check.ConsecutiveFailures = last.ConsecutiveFailures + 1
+			}
+		}
+
+		if err := s.repo.SaveCheck(&check); err != nil {
+			return fmt.Errorf("failed to save check of %s: %w", link.URL, err)
+		}
+	}
+
+	var stale []uint
+	for owner, check := range previous {
+		if !current[owner] {
+			stale = append(stale, check.ID)
+		}
+	}
+	if err := s.repo.DeleteChecks(stale); err != nil {
+		return fmt.Errorf("failed to remove stale link checks: %w", err)
+	}
+
+	checks, err = s.repo.FindChecks()
+	if err != nil {
+		return fmt.Errorf("failed to fetch link checks: %w", err)
+	}
+	s.refreshBroken(checks)
+
+	logger.Info("Checked %d links: %d broken", len(links), broken)
+	return nil
+}
+
+// probeAll requests every distinct URL of the links with a bounded number of concurrent requests
+func (s *linkHealthService) probeAll(ctx context.Context, links []models.Link) map[string]linkProbe {
+	probes := make(map[string]linkProbe)
+	for _, link := range links {
+		probes[link.URL] = linkProbe{}
+	}
+
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, linkCheckConcurrency)
+	)
+	for url := range probes {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(url string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			probe := s.probe(ctx, url)
+			mu.Lock()
+			probes[url] = probe
+			mu.Unlock()
+		}(url)
+	}
+	wg.Wait()
+
+	return probes
+}
+
+// probe requests a URL with HEAD, falling back to GET when HEAD fails since many servers do not implement it
+func (s *linkHealthService) probe(ctx context.Context, url string) linkProbe {
+	statusCode, err := s.request(ctx, http.MethodHead, url)
+	if err != nil || statusCode >= 400 {
+		statusCode, err = s.request(ctx, http.MethodGet, url)
+	}
+
+	switch {
+	case err != nil:
+		return linkProbe{status: models.LinkBroken, err: err.Error()}
+	case statusCode < 400:
+		return linkProbe{status: models.LinkOK, statusCode: statusCode}
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden,
+		statusCode == http.StatusTooManyRequests, statusCode == 999:
+		// LinkedIn answers 999 to automated requests
+		return linkProbe{status: models.LinkUnverified, statusCode: statusCode}
+	default:
+		return linkProbe{status: models.LinkBroken, statusCode: statusCode, err: http.StatusText(statusCode)}
+	}
+}
+
+// request sends a request without reading the response body and returns the final status code
+func (s *linkHealthService) request(ctx context.Context, method, url string) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("User-Agent", linkCheckUserAgent)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	resp.Body.Close()
+	return resp.StatusCode, nil
+}
+
+// Report returns the health of every current link, optionally only those with the given status. The counts
+// always cover every link.
+func (s *linkHealthService) Report(status string) (*models.LinkHealthReport, error) {
+	links, err := s.repo.FindLinks()
+	if err != nil {
+		logger.Error("Failed to fetch links: %v", err)
+		return nil, fmt.Errorf("failed to fetch links: %w", err)
+	}
+
+	checks, err := s.repo.FindChecks()
+	if err != nil {
+		logger.Error("Failed to fetch link checks: %v", err)
+		return nil, fmt.Errorf("failed to fetch link checks: %w", err)
+	}
+	byOwner := make(map[linkOwner]models.LinkCheck, len(checks))
+	for _, check := range checks {
+		byOwner[linkOwner{ownerType: check.OwnerType, ownerID: check.OwnerID}] = check
+	}
+
+	broken := s.BrokenLinks()
+	report := &models.LinkHealthReport{Links: []models.LinkHealth{}}
+	for _, link := range links {
+		health := models.LinkHealth{
+			OwnerType: link.OwnerType,
+			OwnerID:   link.OwnerID,
+			Label:     link.Label,
+			URL:       link.URL,
+			Status:    models.LinkPending,
+			Hidden:    broken.Hides(link.OwnerType, link.OwnerID, link.URL),
+		}
+		// A check of a previous URL of the record says nothing about the current one
+		if check, ok := byOwner[linkOwner{ownerType: link.OwnerType, ownerID: link.OwnerID}]; ok && check.URL == link.URL {
+			checkedAt := check.CheckedAt
+			health.Status = check.Status
+			health.StatusCode = check.StatusCode
+			health.Error = check.Error
+			health.ConsecutiveFailures = check.ConsecutiveFailures
+			health.CheckedAt = &checkedAt
+			if report.LastRunAt == nil || checkedAt.After(*report.LastRunAt) {
+				report.LastRunAt = &checkedAt
+			}
+		}
+
+		report.Total++
+		switch health.Status {
+		case models.LinkOK:
+			report.OK++
+		case models.LinkBroken:
+			report.Broken++
+		case models.LinkUnverified:
+			report.Unverified++
+		case models.LinkPending:
+			report.Pending++
+		}
+		if health.Hidden {
+			report.Hidden++
+		}
+
+		if status == "" || health.Status == status {
+			report.Links = append(report.Links, health)
+		}
+	}
+
+	sort.SliceStable(report.Links, func(i, j int) bool {
+		return linkStatusOrder[report.Links[i].Status] < linkStatusOrder[report.Links[j].Status]
+	})
+	return report, nil
+}
+
+// BrokenLinks returns the links currently hidden from public responses, none when hiding is disabled
+func (s *linkHealthService) BrokenLinks() BrokenLinks {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.broken
+}
+
+// refreshBroken rebuilds the set of hidden links from the checks
+func (s *linkHealthService) refreshBroken(checks []models.LinkCheck) {
+	broken := BrokenLinks{}
+	if s.hideBroken {
+		for _, check := range checks {
+			if check.Status == models.LinkBroken && check.ConsecutiveFailures >= constants.LinkHealthHideAfterFailures {
+				broken[linkOwner{ownerType: check.OwnerType, ownerID: check.OwnerID}] = check.URL
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.broken = broken
+	s.mu.Unlock()
+}
+
+// RedactExperience clears the URL of the experience when it is hidden
+func (b BrokenLinks) RedactExperience(experience *models.Experience) {
+	if experience.URL != nil && b.Hides(models.LinkOwnerExperience, experience.ID, *experience.URL) {
+		experience.URL = nil
+	}
+}
+
+// RedactExperienceClient clears the URL of the experience client when it is hidden
+func (b BrokenLinks) RedactExperienceClient(client *models.ExperienceClient) {
+	if client.URL != nil && b.Hides(models.LinkOwnerExperienceClient, client.ID, *client.URL) {
+		client.URL = nil
+	}
+}
+
+// RedactProject clears the URL of the project when it is hidden
+func (b BrokenLinks) RedactProject(project *models.Project) {
+	if b.Hides(models.LinkOwnerProject, project.ID, project.URL) {
+		project.URL = ""
+	}
+}
+
+// RedactCertification clears the credential URL of the certification when it is hidden
+func (b BrokenLinks) RedactCertification(certification *models.CareerCertification) {
+	if certification.CredentialURL != nil && b.Hides(models.LinkOwnerCertification, certification.ID, *certification.CredentialURL) {
+		certification.CredentialURL = nil
+	}
+}
+
+// fingerprint lists the hidden links in a stable order, so content rendered without them can be cached by it
+func (b BrokenLinks) fingerprint() string {
+	entries := make([]string, 0, len(b))
+	for owner, url := range b {
+		entries = append(entries, fmt.Sprintf("%s:%d:%s", owner.ownerType, owner.ownerID, url))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, "|")
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// ErrLoginThrottled is matched by the LoginThrottledError of a login attempted too soon after failed ones
+var ErrLoginThrottled = errors.New("too many failed login attempts")
+
+// LoginThrottledError is returned instead of checking the credentials of an account or IP address that has to
+// wait before its next login attempt
+type LoginThrottledError struct {
+	RetryAfter time.Duration
+	Locked     bool
+}
+
+func (e *LoginThrottledError) Error() string {
+	if e.Locked {
+		return fmt.Sprintf("too many failed login attempts, locked out for %s", e.RetryAfter.Round(time.Second))
+	}
+	return fmt.Sprintf("too many failed login attempts, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return ErrLoginThrottled
+}
+
+// LoginGuard slows down and locks out the accounts and IP addresses with too many failed logins. The counters
+// are kept for any email, whether it belongs to an account or not, so they do not reveal which accounts exist.
+type LoginGuard interface {
+	Check(email, ip string) error
+	RecordFailure(email, ip string)
+	RecordSuccess(email, ip string)
+	List() ([]models.LoginThrottle, error)
+	Unlock(email, ip string) (int64, error)
+	UnlockAll() (int64, error)
+	CleanUp() error
+}
+
+type loginGuard struct {
+	repo             repository.LoginThrottleRepository
+	maxFailures      int
+	maxFailuresPerIP int
+	lockout          time.Duration
+}
+
+// NewLoginGuard creates a LoginGuard that locks an account out for lockout after maxFailures failed logins, and an
+// IP address after maxFailuresPerIP
+func NewLoginGuard(repo repository.LoginThrottleRepository, maxFailures, maxFailuresPerIP int, lockout time.Duration) LoginGuard {
+	return &loginGuard{
+		repo:             repo,
+		maxFailures:      maxFailures,
+		maxFailuresPerIP: maxFailuresPerIP,
+		lockout:          lockout,
+	}
+}
+
+// Check returns a LoginThrottledError when the account or the IP address has to wait before its next login
+// attempt, the longest wait of both
+func (g *loginGuard) Check(email, ip string) error {
+	var throttled *LoginThrottledError
+	for _, key := range g.keys(email, ip) {
+		throttle, err := g.repo.Find(key.scope, key.key)
+		if err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to check failed logins: %w", err)
+			}
+			continue
+		}
+
+		now := time.Now()
+		var wait *LoginThrottledError
+		switch {
+		case throttle.LockedUntil != nil && throttle.LockedUntil.After(now):
+			wait = &LoginThrottledError{RetryAfter: throttle.LockedUntil.Sub(now), Locked: true}
+		case throttle.LastFailureAt.After(now.Add(-constants.LoginFailureWindow)):
+			if until := throttle.LastFailureAt.Add(loginBackoff(throttle.Failures)); until.After(now) {
+				wait = &LoginThrottledError{RetryAfter: until.Sub(now)}
+			}
+		}
+
+		if wait != nil && (throttled == nil || wait.RetryAfter > throttled.RetryAfter) {
+			throttled = wait
+		}
+	}
+
+	if throttled != nil {
+		return throttled
+	}
+	return nil
+}
+
+// RecordFailure counts a failed login of the account and the IP address and locks out the ones that reached
+// their maximum. Failures are only logged, a login is not refused because it could not be counted.
+func (g *loginGuard) RecordFailure(email, ip string) {
+	resetBefore := time.Now().Add(-constants.LoginFailureWindow)
+	for _, key := range g.keys(email, ip) {
+		throttle, err := g.repo.RecordFailure(key.scope, key.key, resetBefore)
+		if err != nil {
+			logger.Error("Failed to record failed login of %s %s: %v", key.scope, key.key, err)
+			continue
+		}
+
+		if throttle.Failures < key.maxFailures {
+			continue
+		}
+
+		if err := g.repo.Lock(throttle.ID, time.Now().Add(g.lockout)); err != nil {
+			logger.Error("Failed to lock out %s %s: %v", key.scope, key.key, err)
+			continue
+		}
+		logger.Warn("Locked out %s %s for %s after %d failed logins", key.scope, key.key, g.lockout, throttle.Failures)
+	}
+}
+
+// RecordSuccess clears the failed logins of the account and the IP address
+func (g *loginGuard) RecordSuccess(email, ip string) {
+	for _, key := range g.keys(email, ip) {
+		if _, err := g.repo.Delete(key.scope, key.key); err != nil {
+			logger.Error("Failed to clear failed logins of %s %s: %v", key.scope, key.key, err)
+		}
+	}
+}
+
+// List returns the failed login counters, the locked out accounts and IP addresses included
+func (g *loginGuard) List() ([]models.LoginThrottle, error) {
+	throttles, err := g.repo.FindAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch failed logins: %w", err)
+	}
+	return throttles, nil
+}
+
+// Unlock clears the failed logins of the account and the IP address, either may be empty, and returns how many
+// counters were removed
+func (g *loginGuard) Unlock(email, ip string) (int64, error) {
+	var removed int64
+	for _, key := range g.keys(email, ip) {
+		count, err := g.repo.Delete(key.scope, key.key)
+		if err != nil {
+			return removed, fmt.Errorf("failed to unlock %s %s: %w", key.scope, key.key, err)
+		}
+		if count > 0 {
+			logger.Info("Unlocked %s %s", key.scope, key.key)
+		}
+		removed += count
+	}
+	return removed, nil
+}
+
+// UnlockAll clears every failed login counter and returns how many were removed
+func (g *loginGuard) UnlockAll() (int64, error) {
+	removed, err := g.repo.DeleteAll()
+	if err != nil {
+		return 0, fmt.Errorf("failed to unlock logins: %w", err)
+	}
+	logger.Info("Cleared %d failed login counters", removed)
+	return removed, nil
+}
+
+// CleanUp removes the counters whose failures are older than the failure window and that are not locked out
+func (g *loginGuard) CleanUp() error {
+	return g.repo.DeleteStale(time.Now().Add(-constants.LoginFailureWindow))
+}
+
+// throttleKey is a counter of failed logins and the failures that lock it out
+type throttleKey struct {
+	scope       string
+	key         string
+	maxFailures int
+}
+
+// keys returns the counters of the account and the IP address, leaving out the empty ones
+func (g *loginGuard) keys(email, ip string) []throttleKey {
+	var keys []throttleKey
+	if email = normalizeEmail(email); email != "" {
+		keys = append(keys, throttleKey{scope: models.LoginThrottleAccount, key: email, maxFailures: g.maxFailures})
+	}
+	if ip != "" {
+		keys = append(keys, throttleKey{scope: models.LoginThrottleIP, key: ip, maxFailures: g.maxFailuresPerIP})
+	}
+	return keys
+}
+
+// loginBackoff returns how long to wait after the given number of failed logins: nothing before
+// constants.LoginFreeFailures, then one second doubling with every failure up to constants.LoginMaxBackoff
+func loginBackoff(failures int) time.Duration {
+	if failures < constants.LoginFreeFailures {
+		return 0
+	}
+
+	doublings := failures - constants.LoginFreeFailures
+	if doublings >= 16 {
+		return constants.LoginMaxBackoff
+	}
+	return min(time.Second<<doublings, constants.LoginMaxBackoff)
+}
@@ -0,0 +1,49 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+)
+
+func TestLoginBackoff(t *testing.T) {
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{-1, 0},
+		{0, 0},
+		{constants.LoginFreeFailures - 1, 0},
+		{constants.LoginFreeFailures, time.Second},
+		{constants.LoginFreeFailures + 1, 2 * time.Second},
+		{constants.LoginFreeFailures + 8, 256 * time.Second},
+		// 512s is past the cap
+		{constants.LoginFreeFailures + 9, constants.LoginMaxBackoff},
+		{constants.LoginFreeFailures + 15, constants.LoginMaxBackoff},
+		// From 16 doublings on the shift is skipped, so it cannot overflow
+		{constants.LoginFreeFailures + 16, constants.LoginMaxBackoff},
+		{constants.LoginFreeFailures + 100, constants.LoginMaxBackoff},
+		{1 << 30, constants.LoginMaxBackoff},
+	}
+
+	for _, tt := range tests {
+		if got := loginBackoff(tt.failures); got != tt.want {
+			t.Errorf("loginBackoff(%d) = %s, want %s", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestLoginBackoffNeverDecreases(t *testing.T) {
+	previous := time.Duration(0)
+	for failures := 0; failures <= 100; failures++ {
+		backoff := loginBackoff(failures)
+		if backoff < previous {
+			t.Fatalf("loginBackoff(%d) = %s, shorter than after %d failures (%s)", failures, backoff, failures-1, previous)
+		}
+		if backoff > constants.LoginMaxBackoff {
+			t.Fatalf("loginBackoff(%d) = %s, over the cap of %s", failures, backoff, constants.LoginMaxBackoff)
+		}
+		previous = backoff
+	}
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime/multipart"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// ProfileService defines the interface for the profile ("basics") business logic
+type ProfileService interface {
+	GetProfile() (*models.Profile, error)
+	SaveProfile(profile *models.Profile) (*models.Profile, error)
+	UpdateAvatar(ctx context.Context, file *multipart.FileHeader) (*models.Profile, error)
+}
+
+// profileService implements ProfileService interface
+type profileService struct {
+	repo    repository.ProfileRepository
+	storage storage.Blob
+}
+
+// NewProfileService creates a new instance of ProfileService storing the profile pictures in the given blob storage
+func NewProfileService(repo repository.ProfileRepository, blob storage.Blob) ProfileService {
+	return &profileService{
+		repo:    repo,
+		storage: blob,
+	}
+}
+
+// GetProfile retrieves the profile with its social profiles
+func (s *profileService) GetProfile() (*models.Profile, error) {
+	logger.Debug("Fetching profile")
+	profile, err := s.repo.Find()
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Profile has not been created yet")
+			return nil, constants.ErrProfileNotFound
+		}
+		logger.Error("Failed to fetch profile: %v", err)
+		return nil, fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	logger.Info("Successfully fetched profile")
+	return profile, nil
+}
+
+// SaveProfile creates the profile on first use and replaces it afterwards, including the social profiles.
+// The avatar is kept, it is only changed through UpdateAvatar.
+func (s *profileService) SaveProfile(profile *models.Profile) (*models.Profile, error) {
+	logger.Info("Saving profile: %s", profile.Name)
+	if _, err := s.repo.Find(); err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Error("Failed to fetch profile: %v", err)
+			return nil, fmt.Errorf("failed to fetch profile: %w", err)
+		}
+
+		if err := s.repo.Create(profile); err != nil {
+			logger.Error("Failed to create profile: %v", err)
+			return nil, fmt.Errorf("failed to create profile: %w", err)
+		}
+		logger.Info("Successfully created profile")
+		return s.GetProfile()
+	}
+
+	if err := s.repo.Replace(profileColumns(profile), profile.SocialProfiles); err != nil {
+		logger.Error("Failed to update profile: %v", err)
+		return nil, fmt.Errorf("failed to update profile: %w", err)
+	}
+
+	logger.Info("Successfully updated profile")
+	return s.GetProfile()
+}
+
+// UpdateAvatar stores a new profile picture and removes the previous one
+func (s *profileService) UpdateAvatar(ctx context.Context, file *multipart.FileHeader) (*models.Profile, error) {
+	logger.Info("Uploading avatar: %s", file.Filename)
+	profile, err := s.GetProfile()
+	if err != nil {
+		return nil, err
+	}
+
+	ext, err := imageExtension(file.Filename)
+	if err != nil {
+		logger.Warn("Invalid avatar file type %s for %s", ext, file.Filename)
+		return nil, err
+	}
+
+	contentType, err := sniffUpload(file, ext)
+	if err != nil {
+		logger.Warn("Rejected avatar %s: %v", file.Filename, err)
+		return nil, err
+	}
+
+	filename := uniqueFileName(ext)
+	if err := s.putAvatar(ctx, filename, file, contentType); err != nil {
+		logger.Error("Failed to save avatar %s: %v", file.Filename, err)
+		return nil, err
+	}
+
+	if err := s.repo.UpdateAvatar(s.storage.URL(filename), filename); err != nil {
+		logger.Error("Failed to save avatar to database: %v", err)
+		if err := s.storage.Delete(ctx, filename); err != nil {
+			logger.Warn("Failed to delete stored avatar %s: %v", filename, err)
+		}
+		return nil, fmt.Errorf("failed to update avatar: %w", err)
+	}
+
+	if profile.AvatarFileName != nil {
+		if err := s.storage.Delete(ctx, *profile.AvatarFileName); err != nil {
+			logger.Warn("Failed to delete previous avatar %s: %v", *profile.AvatarFileName, err)
+		}
+	}
+
+	logger.Info("Successfully updated avatar: %s", filename)
+	return s.GetProfile()
+}
+
+// putAvatar writes the uploaded picture to the blob storage under the given key
+func (s *profileService) putAvatar(ctx context.Context, key string, file *multipart.FileHeader, contentType string) error {
+	src, err := file.Open()
+	if err != nil {
+		return fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer src.Close()
+
+	if err := s.storage.Put(ctx, key, src, file.Size, contentType); err != nil {
+		return fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+	return nil
+}
+
+// profileColumns lists every editable profile column, so a replace also clears omitted fields
+func profileColumns(profile *models.Profile) map[string]interface{} {
+	return map[string]interface{}{
+		"name":         profile.Name,
+		"label":        profile.Label,
+		"email":        profile.Email,
+		"phone":        profile.Phone,
+		"url":          profile.URL,
+		"summary":      profile.Summary,
+		"city":         profile.City,
+		"region":       profile.Region,
+		"country_code": profile.CountryCode,
+	}
+}
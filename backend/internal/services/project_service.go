@@ -12,13 +12,13 @@ import (
 )
 
 // ProjectService defines a contract for managing Project resources in the application.
-// GetAllProjects retrieves all projects from the data source.
+// GetAllProjects retrieves a filtered page of projects from the data source along with the total count.
 // GetProjectByID fetches a project by its unique identifier.
 // CreateProject adds a new project to the data source.
 // UpdateProject modifies an existing project specified by its identifier.
 // DeleteProject removes a project identified by its unique ID from the data source.
 type ProjectService interface {
-	GetAllProjects() ([]models.Project, error)
+	GetAllProjects(filter repository.ProjectFilter, opts repository.ListOptions) ([]models.Project, int64, error)
 	GetProjectByID(id uint) (*models.Project, error)
 	CreateProject(project *models.Project) error
 	UpdateProject(id uint, updates map[string]interface{}) error
@@ -35,19 +35,19 @@ func NewProjectService(repo repository.ProjectRepository) ProjectService {
 	return &projectService{repo: repo}
 }
 
-// GetAllProjects retrieves all projects from the repository.
-// Returns a slice of Project models and an error if any occurred.
-func (p *projectService) GetAllProjects() ([]models.Project, error) {
+// GetAllProjects retrieves the projects matching the filter from the repository.
+// Returns a slice of Project models, the total number of matching projects and an error if any occurred.
+func (p *projectService) GetAllProjects(filter repository.ProjectFilter, opts repository.ListOptions) ([]models.Project, int64, error) {
 	logger.Debug("Fetching all projects")
-	projects, err := p.repo.FindAll()
+	projects, total, err := p.repo.FindAll(filter, opts)
 
 	if err != nil {
 		logger.Error("Failed to fetch projects: %v", err)
-		return nil, fmt.Errorf("failed to fetch projects: %w", err)
+		return nil, 0, fmt.Errorf("failed to fetch projects: %w", err)
 	}
 
-	logger.Info("Successfully fetched %d projects", len(projects))
-	return projects, nil
+	logger.Info("Successfully fetched %d of %d projects", len(projects), total)
+	return projects, total, nil
 }
 
 // GetProjectByID retrieves a project by its unique identifier.
@@ -0,0 +1,448 @@
+package services
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+	"github.com/go-pdf/fpdf"
+)
+
+// PDF résumé templates
+const (
+	ResumeTemplateCompact  = "compact"
+	ResumeTemplateDetailed = "detailed"
+)
+
+var ErrUnknownResumeTemplate = errors.New("unknown resume template")
+
+// resumeTemplate controls how much of the portfolio data the PDF résumé includes
+type resumeTemplate struct {
+	fontSize          float64
+	lineHeight        float64
+	maxAchievements   int // 0 means every achievement
+	descriptions      bool
+	responsibilities  bool
+	credentialDetails bool
+}
+
+var resumeTemplates = map[string]resumeTemplate{
+	ResumeTemplateCompact: {
+		fontSize:        9,
+		lineHeight:      4.2,
+		maxAchievements: 3,
+	},
+	ResumeTemplateDetailed: {
+		fontSize:          10,
+		lineHeight:        5,
+		descriptions:      true,
+		responsibilities:  true,
+		credentialDetails: true,
+	},
+}
+
+// RenderPDF returns the path of the PDF résumé rendered with the given template, without the hidden links.
+// Rendered files are cached on disk and keyed by the data fingerprint and the hidden links, so any change to
+// the résumé data or to the links hidden produces a new file.
+func (s *resumeService) RenderPDF(template string, hidden BrokenLinks) (string, error) {
+	if template == "" {
+		template = ResumeTemplateDetailed
+	}
+	tpl, ok := resumeTemplates[template]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownResumeTemplate, template)
+	}
+
+	s.renderMu.Lock()
+	defer s.renderMu.Unlock()
+
+	fingerprint, err := s.repo.Fingerprint()
+	if err != nil {
+		logger.Error("Failed to compute resume fingerprint: %v", err)
+		return "", fmt.Errorf("failed to compute resume fingerprint: %w", err)
+	}
+
+	fingerprint += "|hidden:" + hidden.fingerprint()
+
+	path := filepath.Join(s.cacheDir, fmt.Sprintf("resume-%s-%x.pdf", template, sha256.Sum256([]byte(fingerprint))))
+	if _, err := os.Stat(path); err == nil {
+		logger.Debug("Serving cached %s resume: %s", template, path)
+		return path, nil
+	}
+
+	data, err := s.load()
+	if err != nil {
+		return "", err
+	}
+	data.redact(hidden)
+
+	content, err := renderResumePDF(data, tpl)
+	if err != nil {
+		logger.Error("Failed to render %s resume: %v", template, err)
+		return "", fmt.Errorf("failed to render resume: %w", err)
+	}
+
+	if err := s.writeCache(template, path, content); err != nil {
+		logger.Error("Failed to cache %s resume: %v", template, err)
+		return "", fmt.Errorf("failed to cache resume: %w", err)
+	}
+
+	logger.Info("Rendered %s resume (%d bytes)", template, len(content))
+	return path, nil
+}
+
+// writeCache atomically stores the rendered file and removes the stale renders of the same template
+func (s *resumeService) writeCache(template, path string, content []byte) error {
+	tmp, err := os.CreateTemp(s.cacheDir, "resume-*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(content); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return err
+	}
+
+	stale, _ := filepath.Glob(filepath.Join(s.cacheDir, fmt.Sprintf("resume-%s-*.pdf", template)))
+	for _, file := range stale {
+		if file != path {
+			if err := os.Remove(file); err != nil {
+				logger.Warn("Failed to remove stale resume %s: %v", file, err)
+			}
+		}
+	}
+	return nil
+}
+
+// resumeWriter wraps fpdf with the layout helpers shared by every section.
+// The core PDF fonts only cover cp1252, so all text goes through the unicode translator.
+type resumeWriter struct {
+	pdf   *fpdf.Fpdf
+	tpl   resumeTemplate
+	tr    func(string) string
+	width float64
+}
+
+// renderResumePDF lays out the résumé sections on A4 pages
+func renderResumePDF(data *resumeData, tpl resumeTemplate) ([]byte, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(18, 16, 18)
+	pdf.SetAutoPageBreak(true, 16)
+	pdf.AliasNbPages("")
+	if data.profile != nil {
+		pdf.SetTitle(data.profile.Name+" - Résumé", true)
+		pdf.SetAuthor(data.profile.Name, true)
+	} else {
+		pdf.SetTitle("Résumé", true)
+	}
+	pdf.SetCreator("personal-portfolio", true)
+
+	pageWidth, _ := pdf.GetPageSize()
+	left, _, right, _ := pdf.GetMargins()
+	w := &resumeWriter{
+		pdf:   pdf,
+		tpl:   tpl,
+		tr:    pdf.UnicodeTranslatorFromDescriptor(""),
+		width: pageWidth - left - right,
+	}
+
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-12)
+		pdf.SetFont("Helvetica", "", 8)
+		pdf.SetTextColor(120, 120, 120)
+		pdf.CellFormat(0, 5, fmt.Sprintf("Page %d of {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	w.header(data.profile)
+
+	clients := make(map[uint][]models.ExperienceClient)
+	for _, client := range data.clients {
+		clients[client.ExperienceID] = append(clients[client.ExperienceID], client)
+	}
+
+	if len(data.experiences) > 0 {
+		w.section("Experience")
+		for _, experience := range data.experiences {
+			w.experience(experience, clients[experience.ID])
+		}
+	}
+
+	if len(data.education) > 0 {
+		w.section("Education")
+		for _, education := range data.education {
+			w.education(education)
+		}
+	}
+
+	if len(data.projects) > 0 {
+		w.section("Projects")
+		for _, project := range data.projects {
+			w.project(project)
+		}
+	}
+
+	if len(data.certifications) > 0 {
+		w.section("Certifications")
+		for _, certification := range data.certifications {
+			w.certification(certification)
+		}
+	}
+
+	if len(data.skills) > 0 {
+		w.section("Skills")
+		w.skills(data.skills)
+	}
+
+	if len(data.languages) > 0 {
+		w.section("Languages")
+		for _, language := range data.languages {
+			w.paragraph(language.Name + " - " + language.Fluency)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// header writes the profile name, label, contact details and summary, or a generic title without a profile
+func (w *resumeWriter) header(profile *models.Profile) {
+	title := "Résumé"
+	if profile != nil {
+		title = profile.Name
+	}
+
+	w.pdf.SetFont("Helvetica", "B", 20)
+	w.pdf.SetTextColor(20, 20, 20)
+	w.pdf.CellFormat(0, 10, w.tr(title), "", 1, "L", false, 0, "")
+
+	if profile != nil {
+		if profile.Label != "" {
+			w.pdf.SetFont("Helvetica", "", w.tpl.fontSize+3)
+			w.pdf.SetTextColor(30, 64, 120)
+			w.pdf.CellFormat(0, 7, w.tr(profile.Label), "", 1, "L", false, 0, "")
+		}
+
+		location := joinNonEmpty(", ", profile.City, profile.Region, profile.CountryCode)
+		w.subtitle(joinNonEmpty(" · ", profile.Email, profile.Phone, profile.URL, location))
+
+		links := make([]string, 0, len(profile.SocialProfiles))
+		for _, social := range profile.SocialProfiles {
+			links = append(links, social.Network+": "+social.URL)
+		}
+		w.subtitle(strings.Join(links, " · "))
+
+		w.pdf.Ln(1)
+		w.paragraph(profile.Summary)
+	}
+}
+
+// section writes a section heading followed by a rule
+func (w *resumeWriter) section(title string) {
+	w.pdf.Ln(4)
+	w.pdf.SetFont("Helvetica", "B", w.tpl.fontSize+3)
+	w.pdf.SetTextColor(30, 64, 120)
+	w.pdf.CellFormat(0, 7, w.tr(strings.ToUpper(title)), "", 1, "L", false, 0, "")
+
+	x, y := w.pdf.GetXY()
+	w.pdf.SetDrawColor(30, 64, 120)
+	w.pdf.Line(x, y, x+w.width, y)
+	w.pdf.Ln(2)
+}
+
+// heading writes a bold title with a right-aligned period on the same line
+func (w *resumeWriter) heading(title, period string) {
+	w.pdf.SetFont("Helvetica", "", w.tpl.fontSize)
+	periodWidth := w.pdf.GetStringWidth(period) + 2
+
+	w.pdf.SetFont("Helvetica", "B", w.tpl.fontSize+1)
+	w.pdf.SetTextColor(20, 20, 20)
+	w.pdf.CellFormat(w.width-periodWidth, w.tpl.lineHeight+1, w.tr(title), "", 0, "L", false, 0, "")
+
+	w.pdf.SetFont("Helvetica", "", w.tpl.fontSize)
+	w.pdf.SetTextColor(90, 90, 90)
+	w.pdf.CellFormat(periodWidth, w.tpl.lineHeight+1, w.tr(period), "", 1, "R", false, 0, "")
+}
+
+// subtitle writes a single muted line
+func (w *resumeWriter) subtitle(text string) {
+	if text == "" {
+		return
+	}
+	w.pdf.SetFont("Helvetica", "I", w.tpl.fontSize)
+	w.pdf.SetTextColor(90, 90, 90)
+	w.pdf.MultiCell(0, w.tpl.lineHeight, w.tr(text), "", "L", false)
+}
+
+// paragraph writes wrapped body text
+func (w *resumeWriter) paragraph(text string) {
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	w.pdf.SetFont("Helvetica", "", w.tpl.fontSize)
+	w.pdf.SetTextColor(40, 40, 40)
+	w.pdf.MultiCell(0, w.tpl.lineHeight, w.tr(strings.TrimSpace(text)), "", "L", false)
+}
+
+// bullets writes an indented bulleted list, optionally preceded by a label
+func (w *resumeWriter) bullets(label string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+	if label != "" {
+		w.pdf.SetFont("Helvetica", "B", w.tpl.fontSize)
+		w.pdf.SetTextColor(40, 40, 40)
+		w.pdf.CellFormat(0, w.tpl.lineHeight, w.tr(label), "", 1, "L", false, 0, "")
+	}
+
+	w.pdf.SetFont("Helvetica", "", w.tpl.fontSize)
+	w.pdf.SetTextColor(40, 40, 40)
+	left, _, _, _ := w.pdf.GetMargins()
+	for _, item := range items {
+		w.pdf.SetX(left + 3)
+		w.pdf.CellFormat(4, w.tpl.lineHeight, w.tr("•"), "", 0, "L", false, 0, "")
+		w.pdf.MultiCell(w.width-7, w.tpl.lineHeight, w.tr(item), "", "L", false)
+	}
+}
+
+func (w *resumeWriter) experience(experience models.Experience, clients []models.ExperienceClient) {
+	w.pdf.Ln(2)
+	w.heading(experience.Title+" - "+experience.Company, formatPeriod(&experience.StartDate, experience.EndDate))
+	w.subtitle(joinNonEmpty(" · ", experience.Location, string(experience.Type)))
+	if w.tpl.descriptions {
+		w.paragraph(experience.Description)
+	}
+
+	for _, client := range clients {
+		w.pdf.Ln(1)
+		w.pdf.SetFont("Helvetica", "B", w.tpl.fontSize)
+		w.pdf.SetTextColor(40, 40, 40)
+		w.pdf.CellFormat(0, w.tpl.lineHeight, w.tr(client.Name+"  ("+formatPeriod(&client.StartDate, client.EndDate)+")"), "", 1, "L", false, 0, "")
+
+		if w.tpl.descriptions {
+			w.paragraph(client.Description)
+		}
+
+		achievements := []string(client.Achievements)
+		if w.tpl.maxAchievements > 0 && len(achievements) > w.tpl.maxAchievements {
+			achievements = achievements[:w.tpl.maxAchievements]
+		}
+		w.bullets("", achievements)
+
+		if w.tpl.responsibilities {
+			w.bullets("Responsibilities", client.Responsibilities)
+		}
+		if len(client.Technologies) > 0 {
+			w.subtitle("Technologies: " + strings.Join(client.Technologies, ", "))
+		}
+	}
+}
+
+func (w *resumeWriter) project(project models.Project) {
+	w.pdf.Ln(2)
+	w.heading(project.Name, formatPeriod(&project.StartDate, project.EndDate))
+	if technologies := splitTechnologies(project.Technologies); len(technologies) > 0 {
+		w.subtitle(strings.Join(technologies, ", "))
+	}
+	if w.tpl.descriptions {
+		w.paragraph(project.Description)
+		w.paragraph(project.URL)
+	}
+}
+
+func (w *resumeWriter) education(education models.Education) {
+	w.pdf.Ln(1)
+	w.heading(joinNonEmpty(", ", education.StudyType, education.Area), formatPeriod(&education.StartDate, education.EndDate))
+	w.subtitle(education.Institution)
+	if w.tpl.descriptions {
+		if education.Score != "" {
+			w.paragraph("Score: " + education.Score)
+		}
+		if len(education.Courses) > 0 {
+			w.paragraph("Courses: " + strings.Join(education.Courses, ", "))
+		}
+	}
+}
+
+// skills writes one line per category; the detailed template also shows the skill levels
+func (w *resumeWriter) skills(skills []models.Skill) {
+	var categories []string
+	byCategory := make(map[string][]string)
+	for _, skill := range skills {
+		category := skill.Category
+		if category == "" {
+			category = "Other"
+		}
+		if _, ok := byCategory[category]; !ok {
+			categories = append(categories, category)
+		}
+
+		name := skill.Name
+		if w.tpl.descriptions && skill.Level != "" {
+			name += " (" + string(skill.Level) + ")"
+		}
+		byCategory[category] = append(byCategory[category], name)
+	}
+
+	for _, category := range categories {
+		w.pdf.SetFont("Helvetica", "B", w.tpl.fontSize)
+		w.pdf.SetTextColor(40, 40, 40)
+		label := category + ": "
+		w.pdf.CellFormat(w.pdf.GetStringWidth(w.tr(label))+1, w.tpl.lineHeight, w.tr(label), "", 0, "L", false, 0, "")
+		w.pdf.SetFont("Helvetica", "", w.tpl.fontSize)
+		w.pdf.MultiCell(0, w.tpl.lineHeight, w.tr(strings.Join(byCategory[category], ", ")), "", "L", false)
+	}
+}
+
+func (w *resumeWriter) certification(certification models.CareerCertification) {
+	w.pdf.Ln(1)
+	w.heading(certification.Title, certification.IssueDate.Format("Jan 2006"))
+	w.subtitle(certification.Issuer)
+	if w.tpl.credentialDetails {
+		if certification.CredentialID != nil && *certification.CredentialID != "" {
+			w.paragraph("Credential ID: " + *certification.CredentialID)
+		}
+		w.paragraph(stringValue(certification.CredentialURL))
+	}
+}
+
+// formatPeriod renders a date range as "Jan 2020 - Mar 2022", open ranges end in "Present"
+func formatPeriod(start *utils.Date, end *utils.Date) string {
+	period := start.Format("Jan 2006") + " - "
+	if end == nil || end.IsZero() {
+		return period + "Present"
+	}
+	return period + end.Format("Jan 2006")
+}
+
+// joinNonEmpty joins the non-empty values with the separator
+func joinNonEmpty(sep string, values ...string) string {
+	var parts []string
+	for _, value := range values {
+		if strings.TrimSpace(value) != "" {
+			parts = append(parts, value)
+		}
+	}
+	return strings.Join(parts, sep)
+}
@@ -0,0 +1,723 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/utils"
+)
+
+// Résumé sections reported in the import diff
+const (
+	ResumeSectionWork         = "work"
+	ResumeSectionProjects     = "projects"
+	ResumeSectionCertificates = "certificates"
+)
+
+var ErrInvalidResume = errors.New("invalid resume")
+
+// ResumeService converts portfolio data to and from the JSON Resume format and renders the PDF résumé
+type ResumeService interface {
+	Export(hidden BrokenLinks) (*models.Resume, error)
+	Import(resume *models.Resume, dryRun bool) (*models.ResumeImportResult, error)
+	RenderPDF(template string, hidden BrokenLinks) (string, error)
+}
+
+type resumeService struct {
+	repo     repository.ResumeRepository
+	cacheDir string
+	// renderMu serializes PDF rendering so concurrent cache misses render only once
+	renderMu sync.Mutex
+}
+
+// NewResumeService creates a new instance of ResumeService.
+// It creates the PDF cache directory if it does not exist.
+func NewResumeService(repo repository.ResumeRepository) ResumeService {
+	service := &resumeService{
+		repo:     repo,
+		cacheDir: constants.ResumeCacheDir,
+	}
+
+	if err := os.MkdirAll(service.cacheDir, 0755); err != nil {
+		logger.Fatal("Failed to create resume cache directory: %v", err)
+		panic(err)
+	}
+
+	return service
+}
+
+// Export assembles a JSON Resume document from the profile, experiences, clients, projects, certifications,
+// education, skills and languages, leaving out the hidden links
+func (s *resumeService) Export(hidden BrokenLinks) (*models.Resume, error) {
+	logger.Debug("Exporting resume")
+	data, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	data.redact(hidden)
+
+	var lastModified time.Time
+	touch := func(t time.Time) {
+		if t.After(lastModified) {
+			lastModified = t
+		}
+	}
+
+	highlights := make(map[uint][]string)
+	for _, client := range data.clients {
+		highlights[client.ExperienceID] = append(highlights[client.ExperienceID], client.Achievements...)
+		touch(client.UpdatedAt)
+	}
+
+	resume := &models.Resume{
+		Schema:       models.JSONResumeSchemaURL,
+		Work:         make([]models.ResumeWork, 0, len(data.experiences)),
+		Projects:     make([]models.ResumeProject, 0, len(data.projects)),
+		Certificates: make([]models.ResumeCertificate, 0, len(data.certifications)),
+		Education:    make([]models.ResumeEducation, 0, len(data.education)),
+		Skills:       make([]models.ResumeSkill, 0, len(data.skills)),
+		Languages:    make([]models.ResumeLanguage, 0, len(data.languages)),
+	}
+
+	if data.profile != nil {
+		resume.Basics = toResumeBasics(data.profile)
+		touch(data.profile.UpdatedAt)
+	}
+
+	for _, experience := range data.experiences {
+		resume.Work = append(resume.Work, models.ResumeWork{
+			Name:       experience.Company,
+			Position:   experience.Title,
+			URL:        stringValue(experience.URL),
+			Location:   experience.Location,
+			WorkType:   string(experience.Type),
+			StartDate:  formatDate(experience.StartDate.Time),
+			EndDate:    formatDatePtr(experience.EndDate),
+			Summary:    experience.Description,
+			Highlights: highlights[experience.ID],
+		})
+		touch(experience.UpdatedAt)
+	}
+
+	for _, project := range data.projects {
+		resume.Projects = append(resume.Projects, models.ResumeProject{
+			Name:        project.Name,
+			Description: project.Description,
+			URL:         project.URL,
+			StartDate:   formatDate(project.StartDate.Time),
+			EndDate:     formatDatePtr(project.EndDate),
+			Keywords:    splitTechnologies(project.Technologies),
+		})
+		touch(project.UpdatedAt)
+	}
+
+	for _, certification := range data.certifications {
+		resume.Certificates = append(resume.Certificates, models.ResumeCertificate{
+			Name:   certification.Title,
+			Date:   formatDate(certification.IssueDate),
+			Issuer: certification.Issuer,
+			URL:    stringValue(certification.CredentialURL),
+		})
+		touch(certification.UpdatedAt)
+	}
+
+	for _, education := range data.education {
+		resume.Education = append(resume.Education, models.ResumeEducation{
+			Institution: education.Institution,
+			URL:         stringValue(education.URL),
+			Area:        education.Area,
+			StudyType:   education.StudyType,
+			StartDate:   formatDate(education.StartDate.Time),
+			EndDate:     formatDatePtr(education.EndDate),
+			Score:       education.Score,
+			Courses:     education.Courses,
+		})
+		touch(education.UpdatedAt)
+	}
+
+	for _, skill := range data.skills {
+		resume.Skills = append(resume.Skills, models.ResumeSkill{
+			Name:     skill.Name,
+			Level:    string(skill.Level),
+			Keywords: skill.Keywords,
+		})
+		touch(skill.UpdatedAt)
+	}
+
+	for _, language := range data.languages {
+		resume.Languages = append(resume.Languages, models.ResumeLanguage{
+			Language: language.Name,
+			Fluency:  language.Fluency,
+		})
+		touch(language.UpdatedAt)
+	}
+
+	resume.Meta = &models.ResumeMeta{Version: "v1.0.0"}
+	if !lastModified.IsZero() {
+		resume.Meta.LastModified = lastModified.UTC().Format(time.RFC3339)
+	}
+
+	logger.Info("Exported resume with %d work entries, %d projects, %d certificates and %d education entries",
+		len(resume.Work), len(resume.Projects), len(resume.Certificates), len(resume.Education))
+	return resume, nil
+}
+
+// Import upserts the work, projects and certificates of a JSON Resume document.
+// Entries are matched on company+position+startDate, project name and certificate name+issuer.
+// In dry-run mode the diff is computed but nothing is written.
+func (s *resumeService) Import(resume *models.Resume, dryRun bool) (*models.ResumeImportResult, error) {
+	if problems := validateResume(resume); len(problems) > 0 {
+		logger.Warn("Rejected resume import with %d problems", len(problems))
+		return nil, fmt.Errorf("%w: %s", ErrInvalidResume, strings.Join(problems, "; "))
+	}
+
+	data, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &importPlan{result: &models.ResumeImportResult{DryRun: dryRun, Entries: []models.ResumeEntryDiff{}}}
+	plan.addWork(resume.Work, data.experiences)
+	plan.addProjects(resume.Projects, data.projects)
+	plan.addCertificates(resume.Certificates, data.certifications)
+
+	result := plan.result
+	if dryRun || result.Created+result.Updated == 0 {
+		logger.Info("Resume import (dry run: %t): %d to create, %d to update, %d unchanged",
+			dryRun, result.Created, result.Updated, result.Unchanged)
+		return result, nil
+	}
+
+	if err := s.repo.ApplyImport(plan.changes); err != nil {
+		logger.Error("Failed to apply resume import: %v", err)
+		return nil, fmt.Errorf("failed to apply resume import: %w", err)
+	}
+
+	for _, created := range plan.created {
+		result.Entries[created.entry].ID = *created.id
+	}
+
+	logger.Info("Imported resume: %d created, %d updated, %d unchanged", result.Created, result.Updated, result.Unchanged)
+	return result, nil
+}
+
+// toResumeBasics converts the profile to the basics section
+func toResumeBasics(profile *models.Profile) *models.ResumeBasics {
+	basics := &models.ResumeBasics{
+		Name:    profile.Name,
+		Label:   profile.Label,
+		Image:   stringValue(profile.AvatarURL),
+		Email:   profile.Email,
+		Phone:   profile.Phone,
+		URL:     profile.URL,
+		Summary: profile.Summary,
+	}
+
+	if profile.City != "" || profile.Region != "" || profile.CountryCode != "" {
+		basics.Location = &models.ResumeLocation{
+			City:        profile.City,
+			Region:      profile.Region,
+			CountryCode: profile.CountryCode,
+		}
+	}
+
+	for _, social := range profile.SocialProfiles {
+		basics.Profiles = append(basics.Profiles, models.ResumeProfile{
+			Network:  social.Network,
+			Username: social.Username,
+			URL:      social.URL,
+		})
+	}
+
+	return basics
+}
+
+// resumeData holds every record that takes part in an export or import
+type resumeData struct {
+	profile        *models.Profile
+	experiences    []models.Experience
+	clients        []models.ExperienceClient
+	projects       []models.Project
+	certifications []models.CareerCertification
+	education      []models.Education
+	skills         []models.Skill
+	languages      []models.Language
+}
+
+// load fetches all the résumé-related records
+func (s *resumeService) load() (*resumeData, error) {
+	var data resumeData
+	var err error
+
+	if data.profile, err = s.repo.FindProfile(); err != nil {
+		logger.Error("Failed to fetch profile for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch profile: %w", err)
+	}
+
+	if data.experiences, err = s.repo.FindExperiences(); err != nil {
+		logger.Error("Failed to fetch experiences for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch experiences: %w", err)
+	}
+	if data.clients, err = s.repo.FindExperienceClients(); err != nil {
+		logger.Error("Failed to fetch experience clients for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch experience clients: %w", err)
+	}
+	if data.projects, err = s.repo.FindProjects(); err != nil {
+		logger.Error("Failed to fetch projects for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch projects: %w", err)
+	}
+	if data.certifications, err = s.repo.FindCertifications(); err != nil {
+		logger.Error("Failed to fetch certifications for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch certifications: %w", err)
+	}
+	if data.education, err = s.repo.FindEducation(); err != nil {
+		logger.Error("Failed to fetch education for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch education: %w", err)
+	}
+	if data.skills, err = s.repo.FindSkills(); err != nil {
+		logger.Error("Failed to fetch skills for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch skills: %w", err)
+	}
+	if data.languages, err = s.repo.FindLanguages(); err != nil {
+		logger.Error("Failed to fetch languages for resume: %v", err)
+		return nil, fmt.Errorf("failed to fetch languages: %w", err)
+	}
+
+	return &data, nil
+}
+
+// redact clears the hidden links of the records
+func (d *resumeData) redact(hidden BrokenLinks) {
+	for i := range d.experiences {
+		hidden.RedactExperience(&d.experiences[i])
+	}
+	for i := range d.clients {
+		hidden.RedactExperienceClient(&d.clients[i])
+	}
+	for i := range d.projects {
+		hidden.RedactProject(&d.projects[i])
+	}
+	for i := range d.certifications {
+		hidden.RedactCertification(&d.certifications[i])
+	}
+}
+
+// importPlan accumulates the diff and the database changes of an import
+type importPlan struct {
+	result  *models.ResumeImportResult
+	changes repository.ResumeChanges
+	created []createdRef
+}
+
+// createdRef links a diff entry to the ID of the record it creates, known once the import is applied
+type createdRef struct {
+	entry int
+	id    *uint
+}
+
+// importField is a column written by an import. Current and next are the comparable values shown
+// in the diff (current is ignored for new records), value is what gets written to the database.
+type importField struct {
+	column  string
+	current interface{}
+	next    interface{}
+	value   interface{}
+}
+
+func (p *importPlan) addWork(work []models.ResumeWork, experiences []models.Experience) {
+	existing := make(map[string]*models.Experience, len(experiences))
+	for i := range experiences {
+		e := &experiences[i]
+		existing[workKey(e.Company, e.Title, formatDate(e.StartDate.Time))] = e
+	}
+
+	for _, item := range work {
+		startDate, _ := parseResumeDate(item.StartDate)
+		endDate, _ := parseResumeDatePtr(item.EndDate)
+		workType := resolveWorkType(item.WorkType, item.Location)
+		key := workKey(item.Name, item.Position, formatDate(startDate))
+
+		current := existing[key]
+		if current == nil {
+			current = &models.Experience{}
+		}
+
+		fields := []importField{
+			{"title", current.Title, item.Position, item.Position},
+			{"company", current.Company, item.Name, item.Name},
+			{"url", nullable(stringValue(current.URL)), nullable(item.URL), stringPtr(item.URL)},
+			{"location", current.Location, item.Location, item.Location},
+			{"type", string(current.Type), string(workType), workType},
+			{"start_date", formatDate(current.StartDate.Time), formatDate(startDate), utils.Date{Time: startDate}},
+			{"end_date", nullable(formatDatePtr(current.EndDate)), nullable(formatDatePtr(endDate)), endDate},
+			{"description", current.Description, item.Summary, item.Summary},
+		}
+
+		label := fmt.Sprintf("%s - %s (%s)", item.Name, item.Position, formatDate(startDate))
+		if current.ID == 0 {
+			experience := &models.Experience{
+				Title:       item.Position,
+				Company:     item.Name,
+				URL:         stringPtr(item.URL),
+				Location:    item.Location,
+				Type:        workType,
+				StartDate:   utils.Date{Time: startDate},
+				EndDate:     endDate,
+				Description: item.Summary,
+			}
+			p.changes.NewExperiences = append(p.changes.NewExperiences, experience)
+			p.create(ResumeSectionWork, label, fields, &experience.ID)
+			continue
+		}
+
+		if updates := p.update(ResumeSectionWork, label, current.ID, fields); updates != nil {
+			p.changes.ExperienceUpdates = append(p.changes.ExperienceUpdates, repository.RecordUpdate{ID: current.ID, Updates: updates})
+		}
+	}
+}
+
+func (p *importPlan) addProjects(items []models.ResumeProject, projects []models.Project) {
+	existing := make(map[string]*models.Project, len(projects))
+	for i := range projects {
+		existing[normalizeKey(projects[i].Name)] = &projects[i]
+	}
+
+	for _, item := range items {
+		startDate, _ := parseResumeDate(item.StartDate)
+		endDate, _ := parseResumeDatePtr(item.EndDate)
+		technologies := strings.Join(item.Keywords, ", ")
+
+		current := existing[normalizeKey(item.Name)]
+		if current == nil {
+			current = &models.Project{}
+		}
+
+		fields := []importField{
+			{"name", current.Name, item.Name, item.Name},
+			{"description", current.Description, item.Description, item.Description},
+			{"url", current.URL, item.URL, item.URL},
+			{"start_date", formatDate(current.StartDate.Time), formatDate(startDate), utils.Date{Time: startDate}},
+			{"end_date", nullable(formatDatePtr(current.EndDate)), nullable(formatDatePtr(endDate)), endDate},
+			{"technologies", current.Technologies, technologies, technologies},
+		}
+
+		if current.ID == 0 {
+			project := &models.Project{
+				Name:         item.Name,
+				Description:  item.Description,
+				URL:          item.URL,
+				StartDate:    utils.Date{Time: startDate},
+				EndDate:      endDate,
+				Technologies: technologies,
+			}
+			p.changes.NewProjects = append(p.changes.NewProjects, project)
+			p.create(ResumeSectionProjects, item.Name, fields, &project.ID)
+			continue
+		}
+
+		if updates := p.update(ResumeSectionProjects, item.Name, current.ID, fields); updates != nil {
+			p.changes.ProjectUpdates = append(p.changes.ProjectUpdates, repository.RecordUpdate{ID: current.ID, Updates: updates})
+		}
+	}
+}
+
+// addCertificates upserts certificate metadata. New certificates are created without a file,
+// which can be attached later from the admin panel.
+func (p *importPlan) addCertificates(items []models.ResumeCertificate, certifications []models.CareerCertification) {
+	existing := make(map[string]*models.CareerCertification, len(certifications))
+	for i := range certifications {
+		c := &certifications[i]
+		existing[certificateKey(c.Title, c.Issuer)] = c
+	}
+
+	for _, item := range items {
+		issueDate, _ := parseResumeDate(item.Date)
+
+		current := existing[certificateKey(item.Name, item.Issuer)]
+		if current == nil {
+			current = &models.CareerCertification{}
+		}
+
+		fields := []importField{
+			{"title", current.Title, item.Name, item.Name},
+			{"issuer", current.Issuer, item.Issuer, item.Issuer},
+			{"issue_date", formatDate(current.IssueDate), formatDate(issueDate), issueDate},
+			{"credential_url", nullable(stringValue(current.CredentialURL)), nullable(item.URL), stringPtr(item.URL)},
+		}
+
+		label := fmt.Sprintf("%s (%s)", item.Name, item.Issuer)
+		if current.ID == 0 {
+			certification := &models.CareerCertification{
+				Title:         item.Name,
+				Issuer:        item.Issuer,
+				IssueDate:     issueDate,
+				CredentialURL: stringPtr(item.URL),
+			}
+			p.changes.NewCertifications = append(p.changes.NewCertifications, certification)
+			p.create(ResumeSectionCertificates, label, fields, &certification.ID)
+			continue
+		}
+
+		if updates := p.update(ResumeSectionCertificates, label, current.ID, fields); updates != nil {
+			p.changes.CertificationUpdates = append(p.changes.CertificationUpdates, repository.RecordUpdate{ID: current.ID, Updates: updates})
+		}
+	}
+}
+
+// create records a diff entry for a new record; every non-empty field is reported as a change
+func (p *importPlan) create(section, key string, fields []importField, id *uint) {
+	changes := make(map[string]models.ResumeFieldChange)
+	for _, field := range fields {
+		if field.next != nil && field.next != "" {
+			changes[field.column] = models.ResumeFieldChange{From: nil, To: field.next}
+		}
+	}
+
+	p.created = append(p.created, createdRef{entry: len(p.result.Entries), id: id})
+	p.result.Entries = append(p.result.Entries, models.ResumeEntryDiff{
+		Section: section,
+		Key:     key,
+		Action:  models.ResumeActionCreate,
+		Changes: changes,
+	})
+	p.result.Created++
+}
+
+// update records a diff entry for an existing record and returns the columns to update,
+// or nil when nothing changed
+func (p *importPlan) update(section, key string, id uint, fields []importField) map[string]interface{} {
+	changes := make(map[string]models.ResumeFieldChange)
+	updates := make(map[string]interface{})
+	for _, field := range fields {
+		if field.current != field.next {
+			changes[field.column] = models.ResumeFieldChange{From: field.current, To: field.next}
+			updates[field.column] = field.value
+		}
+	}
+
+	entry := models.ResumeEntryDiff{Section: section, Key: key, ID: id, Action: models.ResumeActionUnchanged}
+	if len(updates) == 0 {
+		p.result.Entries = append(p.result.Entries, entry)
+		p.result.Unchanged++
+		return nil
+	}
+
+	entry.Action = models.ResumeActionUpdate
+	entry.Changes = changes
+	p.result.Entries = append(p.result.Entries, entry)
+	p.result.Updated++
+	return updates
+}
+
+// validateResume checks the required fields, dates and duplicates of the importable sections
+func validateResume(resume *models.Resume) []string {
+	var problems []string
+	addProblem := func(format string, args ...interface{}) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	checkDates := func(path, start, end string, startRequired bool) {
+		startDate, err := parseResumeDate(start)
+		switch {
+		case start == "" && startRequired:
+			addProblem("%s.startDate is required", path)
+		case start != "" && err != nil:
+			addProblem("%s.startDate: %v", path, err)
+		}
+
+		endDate, err := parseResumeDate(end)
+		switch {
+		case end != "" && err != nil:
+			addProblem("%s.endDate: %v", path, err)
+		case end != "" && start != "" && endDate.Before(startDate):
+			addProblem("%s.endDate must be after startDate", path)
+		}
+	}
+
+	checkLength := func(path, value string, max int) {
+		if len(value) > max {
+			addProblem("%s must be at most %d characters", path, max)
+		}
+	}
+
+	seen := make(map[string]string)
+	checkDuplicate := func(path, key string) {
+		if previous, ok := seen[key]; ok {
+			addProblem("%s duplicates %s", path, previous)
+			return
+		}
+		seen[key] = path
+	}
+
+	for i, item := range resume.Work {
+		path := fmt.Sprintf("work[%d]", i)
+		if strings.TrimSpace(item.Name) == "" {
+			addProblem("%s.name is required", path)
+		}
+		if strings.TrimSpace(item.Position) == "" {
+			addProblem("%s.position is required", path)
+		}
+		if item.WorkType != "" && !isWorkType(item.WorkType) {
+			addProblem("%s.workType must be one of: Remote, On Site, Hybrid", path)
+		}
+		checkLength(path+".name", item.Name, 255)
+		checkLength(path+".position", item.Position, 255)
+		checkLength(path+".location", item.Location, 255)
+		checkLength(path+".url", item.URL, 500)
+		checkDates(path, item.StartDate, item.EndDate, true)
+
+		startDate, _ := parseResumeDate(item.StartDate)
+		checkDuplicate(path, ResumeSectionWork+"|"+workKey(item.Name, item.Position, formatDate(startDate)))
+	}
+
+	for i, item := range resume.Projects {
+		path := fmt.Sprintf("projects[%d]", i)
+		if strings.TrimSpace(item.Name) == "" {
+			addProblem("%s.name is required", path)
+		}
+		checkLength(path+".name", item.Name, 255)
+		checkLength(path+".description", item.Description, 500)
+		checkLength(path+".url", item.URL, 500)
+		checkLength(path+".keywords", strings.Join(item.Keywords, ", "), 500)
+		checkDates(path, item.StartDate, item.EndDate, true)
+		checkDuplicate(path, ResumeSectionProjects+"|"+normalizeKey(item.Name))
+	}
+
+	for i, item := range resume.Certificates {
+		path := fmt.Sprintf("certificates[%d]", i)
+		if strings.TrimSpace(item.Name) == "" {
+			addProblem("%s.name is required", path)
+		}
+		if strings.TrimSpace(item.Issuer) == "" {
+			addProblem("%s.issuer is required", path)
+		}
+		if item.Date == "" {
+			addProblem("%s.date is required", path)
+		} else if _, err := parseResumeDate(item.Date); err != nil {
+			addProblem("%s.date: %v", path, err)
+		}
+		checkLength(path+".name", item.Name, 255)
+		checkLength(path+".issuer", item.Issuer, 255)
+		checkLength(path+".url", item.URL, 500)
+		checkDuplicate(path, ResumeSectionCertificates+"|"+certificateKey(item.Name, item.Issuer))
+	}
+
+	return problems
+}
+
+// resumeDateFormats are the ISO 8601 date precisions allowed by the JSON Resume schema
+var resumeDateFormats = []string{"2006-01-02", "2006-01", "2006"}
+
+// parseResumeDate parses a JSON Resume date, a missing month or day defaults to the first one
+func parseResumeDate(value string) (time.Time, error) {
+	for _, format := range resumeDateFormats {
+		if t, err := time.Parse(format, strings.TrimSpace(value)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD, YYYY-MM or YYYY", value)
+}
+
+// parseResumeDatePtr parses an optional JSON Resume date, an empty value means no date
+func parseResumeDatePtr(value string) (*utils.Date, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+	t, err := parseResumeDate(value)
+	if err != nil {
+		return nil, err
+	}
+	return &utils.Date{Time: t}, nil
+}
+
+// resolveWorkType returns the explicit work type or infers it from the location, defaulting to On Site
+func resolveWorkType(workType, location string) models.WorkType {
+	if isWorkType(workType) {
+		return models.WorkType(workType)
+	}
+
+	switch location := strings.ToLower(location); {
+	case strings.Contains(location, "remote"):
+		return models.Remote
+	case strings.Contains(location, "hybrid"):
+		return models.Hybrid
+	default:
+		return models.OnSite
+	}
+}
+
+func isWorkType(value string) bool {
+	switch models.WorkType(value) {
+	case models.Remote, models.OnSite, models.Hybrid:
+		return true
+	}
+	return false
+}
+
+func workKey(company, position, startDate string) string {
+	return normalizeKey(company) + "|" + normalizeKey(position) + "|" + startDate
+}
+
+func certificateKey(title, issuer string) string {
+	return normalizeKey(title) + "|" + normalizeKey(issuer)
+}
+
+// normalizeKey makes matching case and whitespace insensitive
+func normalizeKey(value string) string {
+	return strings.ToLower(strings.Join(strings.Fields(value), " "))
+}
+
+// splitTechnologies turns the comma separated technologies column into JSON Resume keywords
+func splitTechnologies(technologies string) []string {
+	var keywords []string
+	for _, technology := range strings.Split(technologies, ",") {
+		if technology = strings.TrimSpace(technology); technology != "" {
+			keywords = append(keywords, technology)
+		}
+	}
+	return keywords
+}
+
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
+
+func formatDatePtr(d *utils.Date) string {
+	if d == nil {
+		return ""
+	}
+	return formatDate(d.Time)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func stringPtr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+// nullable maps an empty string to nil so optional fields show up as null in the diff
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+)
+
+// DefaultSearchLimit is the number of hits returned when no limit is requested
+const DefaultSearchLimit = 20
+
+var ErrEmptySearchQuery = errors.New("search query must contain at least one letter or digit")
+
+// SearchService performs full-text searches across projects, experiences, clients and certifications
+type SearchService interface {
+	Search(query string, kinds []string, limit int) ([]models.SearchHit, error)
+}
+
+type searchService struct {
+	repo repository.SearchRepository
+}
+
+// NewSearchService creates a new instance of SearchService
+func NewSearchService(repo repository.SearchRepository) SearchService {
+	return &searchService{repo: repo}
+}
+
+// Search converts free text into an FTS5 query and returns the ranked hits
+func (s *searchService) Search(query string, kinds []string, limit int) ([]models.SearchHit, error) {
+	match := buildMatchQuery(query)
+	if match == "" {
+		return nil, ErrEmptySearchQuery
+	}
+
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	logger.Debug("Searching for %q (match: %s)", query, match)
+	hits, err := s.repo.Search(match, kinds, limit)
+	if err != nil {
+		logger.Error("Failed to search for %q: %v", query, err)
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+
+	logger.Info("Search for %q returned %d hits", query, len(hits))
+	return hits, nil
+}
+
+// buildMatchQuery turns user input into a safe FTS5 expression: every word becomes a quoted
+// term (so FTS5 operators in the input are treated as text) and the last word is a prefix match
+// to support search-as-you-type. All terms must match.
+func buildMatchQuery(query string) string {
+	words := strings.FieldsFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+
+	terms := make([]string, len(words))
+	for i, word := range words {
+		terms[i] = `"` + word + `"`
+	}
+	terms[len(terms)-1] += "*"
+
+	return strings.Join(terms, " ")
+}
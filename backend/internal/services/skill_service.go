@@ -0,0 +1,140 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// SkillService defines the interface for skill business logic
+type SkillService interface {
+	GetAllSkills(filter repository.SkillFilter, opts repository.ListOptions) ([]models.Skill, int64, error)
+	GetSkillByID(id uint) (*models.Skill, error)
+	CreateSkill(skill *models.Skill, links repository.SkillLinks) error
+	UpdateSkill(id uint, updates map[string]interface{}, links repository.SkillLinks) error
+	DeleteSkill(id uint) error
+}
+
+// skillService implements SkillService interface
+type skillService struct {
+	repo repository.SkillRepository
+}
+
+// NewSkillService creates a new instance of SkillService
+func NewSkillService(repo repository.SkillRepository) SkillService {
+	return &skillService{repo: repo}
+}
+
+// GetAllSkills retrieves the skills matching the filter and the total count
+func (s *skillService) GetAllSkills(filter repository.SkillFilter, opts repository.ListOptions) ([]models.Skill, int64, error) {
+	logger.Debug("Fetching all skills")
+	skills, total, err := s.repo.FindAll(filter, opts)
+	if err != nil {
+		logger.Error("Failed to fetch skills: %v", err)
+		return nil, 0, fmt.Errorf("failed to fetch skills: %w", err)
+	}
+
+	logger.Info("Successfully fetched %d of %d skills", len(skills), total)
+	return skills, total, nil
+}
+
+// GetSkillByID retrieves a single skill by ID
+func (s *skillService) GetSkillByID(id uint) (*models.Skill, error) {
+	logger.Debug("Fetching skill with ID: %d", id)
+	skill, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Skill not found: %d", id)
+			return nil, constants.ErrSkillNotFound
+		}
+		logger.Error("Failed to fetch skill %d: %v", id, err)
+		return nil, fmt.Errorf("failed to fetch skill: %w", err)
+	}
+
+	logger.Info("Successfully fetched skill: %d", id)
+	return skill, nil
+}
+
+// CreateSkill creates a new skill linked to the given projects and experience clients
+func (s *skillService) CreateSkill(skill *models.Skill, links repository.SkillLinks) error {
+	logger.Info("Creating new skill: %s", skill.Name)
+	if err := s.ensureUniqueName(skill.Name, 0); err != nil {
+		return err
+	}
+
+	if err := s.repo.Create(skill, links); err != nil {
+		if errors.Is(err, repository.ErrLinkedRecordNotFound) {
+			logger.Warn("Skill %s links to missing records", skill.Name)
+			return constants.ErrSkillLinkNotFound
+		}
+		logger.Error("Failed to create skill: %v", err)
+		return fmt.Errorf("failed to create skill: %w", err)
+	}
+
+	logger.Info("Successfully created skill with ID: %d", skill.ID)
+	return nil
+}
+
+// UpdateSkill updates an existing skill and replaces the links that were provided
+func (s *skillService) UpdateSkill(id uint, updates map[string]interface{}, links repository.SkillLinks) error {
+	logger.Info("Updating skill with ID: %d", id)
+	if name, ok := updates["name"].(string); ok {
+		if err := s.ensureUniqueName(name, id); err != nil {
+			return err
+		}
+	}
+
+	if err := s.repo.Update(id, updates, links); err != nil {
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			logger.Warn("Skill not found for update: %d", id)
+			return constants.ErrSkillNotFound
+		case errors.Is(err, repository.ErrLinkedRecordNotFound):
+			logger.Warn("Skill %d links to missing records", id)
+			return constants.ErrSkillLinkNotFound
+		}
+		logger.Error("Failed to update skill %d: %v", id, err)
+		return fmt.Errorf("failed to update skill: %w", err)
+	}
+
+	logger.Info("Successfully updated skill: %d", id)
+	return nil
+}
+
+// DeleteSkill deletes a skill by ID
+func (s *skillService) DeleteSkill(id uint) error {
+	logger.Info("Deleting skill with ID: %d", id)
+	if err := s.repo.Delete(id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			logger.Warn("Skill not found for deletion: %d", id)
+			return constants.ErrSkillNotFound
+		}
+		logger.Error("Failed to delete skill %d: %v", id, err)
+		return fmt.Errorf("failed to delete skill: %w", err)
+	}
+
+	logger.Info("Successfully deleted skill: %d", id)
+	return nil
+}
+
+// ensureUniqueName fails when another skill (other than id) already uses the name
+func (s *skillService) ensureUniqueName(name string, id uint) error {
+	existing, err := s.repo.FindByName(name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to look up skill: %w", err)
+	}
+
+	if existing.ID != id {
+		logger.Warn("Skill already exists: %s", name)
+		return constants.ErrSkillAlreadyExists
+	}
+	return nil
+}
@@ -0,0 +1,217 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// orphanGracePeriod keeps the files written recently out of the orphans, an upload stores its files before
+// creating the certification
+const orphanGracePeriod = time.Hour
+
+// ReconcileOptions selects what Reconcile fixes besides reporting. Restore brings back the deleted
+// certifications whose files are all still stored; Purge deletes the orphaned files. The files of the deleted
+// certifications belong to the trash and are only deleted when they are purged from it.
+type ReconcileOptions struct {
+	Purge   bool
+	Restore bool
+}
+
+// OrphanedFile is a stored file no certification refers to, deleted or not
+type OrphanedFile struct {
+	Key          string
+	Size         int64
+	LastModified time.Time
+}
+
+// CertificationFiles lists the stored files of a certification involved in a reconciliation
+type CertificationFiles struct {
+	Certification models.CareerCertification
+	Keys          []string
+	Size          int64
+}
+
+// ReconcileReport is the outcome of comparing the certification files with the certifications table. Trashed
+// are the deleted certifications whose files are still stored, Missing the certifications whose files are not;
+// Recent counts the orphaned files within the grace period, left alone.
+type ReconcileReport struct {
+	Files          int
+	Certifications int
+	Orphaned       []OrphanedFile
+	Trashed        []CertificationFiles
+	Missing        []CertificationFiles
+	Recent         int
+	Restored       []CertificationFiles
+	Purged         int
+	PurgedBytes    int64
+}
+
+// StorageReconciler finds the certification files and rows that lost their counterpart: files left behind by
+// failed uploads or deletions, and certifications whose files are gone
+type StorageReconciler interface {
+	Start()
+	Reconcile(ctx context.Context, options ReconcileOptions) (*ReconcileReport, error)
+}
+
+type storageReconciler struct {
+	repo     repository.CareerCertificationRepository
+	storage  storage.Blob
+	interval time.Duration
+	purge    bool
+}
+
+// NewStorageReconciler creates a StorageReconciler that checks the storage every interval, purging the orphans
+// when purge is set and only reporting them otherwise
+func NewStorageReconciler(repo repository.CareerCertificationRepository, blob storage.Blob, interval time.Duration, purge bool) StorageReconciler {
+	return &storageReconciler{
+		repo:     repo,
+		storage:  blob,
+		interval: interval,
+		purge:    purge,
+	}
+}
+
+// Start runs a reconciliation right away and then every interval in the background. Deleted certifications
+// are never restored automatically.
+func (s *storageReconciler) Start() {
+	logger.Info("Reconciling certification storage every %s (purge: %t)", s.interval, s.purge)
+	go func() {
+		ticker := time.NewTicker(s.interval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.Reconcile(context.Background(), ReconcileOptions{Purge: s.purge}); err != nil {
+				logger.Error("Failed to reconcile certification storage: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// Reconcile compares the stored files with every certification, the deleted ones included, and applies the
+// options. Certifications imported from a résumé have no file and are not considered.
+func (s *storageReconciler) Reconcile(ctx context.Context, options ReconcileOptions) (*ReconcileReport, error) {
+	objects, err := s.storage.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list stored files: %w", err)
+	}
+
+	certifications, err := s.repo.FindAllWithDeleted()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch certifications: %w", err)
+	}
+
+	stored := make(map[string]storage.ObjectInfo, len(objects))
+	for _, object := range objects {
+		stored[object.Key] = object
+	}
+
+	report := &ReconcileReport{Files: len(objects), Certifications: len(certifications)}
+	referenced := make(map[string]bool)
+
+	for _, certification := range certifications {
+		if certification.FileName == "" {
+			continue
+		}
+
+		present := CertificationFiles{Certification: certification}
+		missing := CertificationFiles{Certification: certification}
+		for _, key := range certificationFiles(&certification) {
+			referenced[key] = true
+			if object, ok := stored[key]; ok {
+				present.Keys = append(present.Keys, key)
+				present.Size += object.Size
+			} else {
+				missing.Keys = append(missing.Keys, key)
+			}
+		}
+
+		switch {
+		case certification.DeletedAt.Valid && len(present.Keys) > 0:
+			report.Trashed = append(report.Trashed, present)
+		case !certification.DeletedAt.Valid && len(missing.Keys) > 0:
+			report.Missing = append(report.Missing, missing)
+		}
+	}
+
+	cutoff := time.Now().Add(-orphanGracePeriod)
+	for _, object := range objects {
+		if referenced[object.Key] {
+			continue
+		}
+		if object.LastModified.After(cutoff) {
+			report.Recent++
+			continue
+		}
+		report.Orphaned = append(report.Orphaned, OrphanedFile{Key: object.Key, Size: object.Size, LastModified: object.LastModified})
+	}
+
+	if options.Restore {
+		if err := s.restore(report); err != nil {
+			return nil, err
+		}
+	}
+	if options.Purge {
+		s.purgeFiles(ctx, report)
+	}
+
+	if len(report.Orphaned) > 0 || len(report.Missing) > 0 {
+		logger.Warn("Certification storage: %d orphaned files, %d certifications with missing files", len(report.Orphaned), len(report.Missing))
+	}
+	logger.Info("Reconciled %d files with %d certifications: %d restored, %d files purged", report.Files, report.Certifications, len(report.Restored), report.Purged)
+	return report, nil
+}
+
+// restore brings back the deleted certifications whose files are all stored, unless a certification that is not
+// deleted stores the same file. The restored ones leave report.Trashed.
+func (s *storageReconciler) restore(report *ReconcileReport) error {
+	var remaining []CertificationFiles
+	for _, trashed := range report.Trashed {
+		certification := trashed.Certification
+		if len(trashed.Keys) < len(certificationFiles(&certification)) {
+			remaining = append(remaining, trashed)
+			continue
+		}
+
+		if certification.ContentHash != nil {
+			existing, err := s.repo.FindByContentHash(*certification.ContentHash)
+			if err == nil {
+				logger.Warn("Not restoring certification %d, certification %d stores the same file", certification.ID, existing.ID)
+				remaining = append(remaining, trashed)
+				continue
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to check for duplicate certificates: %w", err)
+			}
+		}
+
+		if err := s.repo.Restore(certification.ID); err != nil {
+			return fmt.Errorf("failed to restore certification %d: %w", certification.ID, err)
+		}
+		logger.Info("Restored certification %d from its stored files", certification.ID)
+		report.Restored = append(report.Restored, trashed)
+	}
+
+	report.Trashed = remaining
+	return nil
+}
+
+// purgeFiles deletes the orphaned files, failures are only logged
+func (s *storageReconciler) purgeFiles(ctx context.Context, report *ReconcileReport) {
+	for _, orphan := range report.Orphaned {
+		if err := s.storage.Delete(ctx, orphan.Key); err != nil {
+			logger.Warn("Failed to delete stored file %s: %v", orphan.Key, err)
+			continue
+		}
+		report.Purged++
+		report.PurgedBytes += orphan.Size
+	}
+}
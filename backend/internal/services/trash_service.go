@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/storage"
+	"gorm.io/gorm"
+)
+
+// trashPurgeInterval is how often the records past the retention period are looked for
+const trashPurgeInterval = time.Hour
+
+// TrashService lists, restores and permanently deletes the deleted experiences, experience clients, projects
+// and certifications, and purges them once the retention period is over
+type TrashService interface {
+	Start()
+	List(itemType string) ([]models.TrashItem, error)
+	Restore(ctx context.Context, itemType string, id uint) error
+	Purge(ctx context.Context, itemType string, id uint) error
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+type trashService struct {
+	repo           repository.TrashRepository
+	certifications repository.CareerCertificationRepository
+	storage        storage.Blob
+	retentionDays  int
+}
+
+// NewTrashService creates a TrashService that keeps deleted records for retentionDays, or until purged by hand
+// when retentionDays is 0. The files of the certifications live in blob.
+func NewTrashService(repo repository.TrashRepository, certifications repository.CareerCertificationRepository, blob storage.Blob, retentionDays int) TrashService {
+	return &trashService{
+		repo:           repo,
+		certifications: certifications,
+		storage:        blob,
+		retentionDays:  retentionDays,
+	}
+}
+
+// Start purges the records past the retention period right away and then periodically in the background
+func (s *trashService) Start() {
+	if s.retentionDays == 0 {
+		logger.Info("Trash retention disabled: deleted records are kept until purged")
+		return
+	}
+
+	logger.Info("Purging deleted records after %d days", s.retentionDays)
+	go func() {
+		ticker := time.NewTicker(trashPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			if _, err := s.PurgeExpired(context.Background()); err != nil {
+				logger.Error("Failed to purge the trash: %v", err)
+			}
+			<-ticker.C
+		}
+	}()
+}
+
+// List returns the deleted records, all of them or those of the given type, most recently deleted first
+func (s *trashService) List(itemType string) ([]models.TrashItem, error) {
+	if itemType != "" && !isTrashType(itemType) {
+		return nil, constants.ErrInvalidTrashType
+	}
+
+	items, err := s.repo.FindAll(nil)
+	if err != nil {
+		logger.Error("Failed to fetch trash: %v", err)
+		return nil, fmt.Errorf("failed to fetch trash: %w", err)
+	}
+
+	filtered := make([]models.TrashItem, 0, len(items))
+	for _, item := range items {
+		if itemType != "" && item.Type != itemType {
+			continue
+		}
+		if s.retentionDays > 0 {
+			purgeAt := item.DeletedAt.AddDate(0, 0, s.retentionDays)
+			item.PurgeAt = &purgeAt
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered, nil
+}
+
+// Restore brings a deleted record back, an experience with the clients deleted along with it. A client cannot be
+// restored while its experience is deleted, nor a certification whose file is gone or is stored again by another
+// certification.
+func (s *trashService) Restore(ctx context.Context, itemType string, id uint) error {
+	item, err := s.find(itemType, id)
+	if err != nil {
+		return err
+	}
+
+	switch itemType {
+	case models.TrashExperienceClient:
+		if _, err := s.repo.FindByID(models.TrashExperience, *item.ParentID); err == nil {
+			return constants.ErrTrashParentDeleted
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("failed to fetch experience: %w", err)
+		}
+
+	case models.TrashCertification:
+		certification, err := s.certifications.FindByIDWithDeleted(id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch certification: %w", err)
+		}
+		if certification.FileName != "" {
+			if _, err := s.storage.Stat(ctx, certification.FileName); errors.Is(err, storage.ErrNotFound) {
+				return constants.ErrTrashFilesMissing
+			} else if err != nil {
+				return fmt.Errorf("failed to check certification file: %w", err)
+			}
+		}
+		if certification.ContentHash != nil {
+			existing, err := s.certifications.FindByContentHash(*certification.ContentHash)
+			if err == nil {
+				return &DuplicateCertificateError{Existing: existing}
+			}
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				return fmt.Errorf("failed to check for duplicate certificates: %w", err)
+			}
+		}
+	}
+
+	if err := s.repo.Restore(itemType, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrTrashItemNotFound
+		}
+		logger.Error("Failed to restore %s %d: %v", itemType, id, err)
+		return fmt.Errorf("failed to restore %s: %w", itemType, err)
+	}
+
+	logger.Info("Restored %s %d from the trash", itemType, id)
+	return nil
+}
+
+// Purge permanently deletes a deleted record, the clients of an experience and the files of a certification
+// included
+func (s *trashService) Purge(ctx context.Context, itemType string, id uint) error {
+	if _, err := s.find(itemType, id); err != nil {
+		return err
+	}
+
+	var files []string
+	if itemType == models.TrashCertification {
+		certification, err := s.certifications.FindByIDWithDeleted(id)
+		if err != nil {
+			return fmt.Errorf("failed to fetch certification: %w", err)
+		}
+		if certification.FileName != "" {
+			files = certificationFiles(certification)
+		}
+	}
+
+	if err := s.repo.Purge(itemType, id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return constants.ErrTrashItemNotFound
+		}
+		logger.Error("Failed to purge %s %d: %v", itemType, id, err)
+		return fmt.Errorf("failed to purge %s: %w", itemType, err)
+	}
+
+	// The record is gone already, a file that fails to delete is left for the storage reconciliation
+	for _, key := range files {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			logger.Warn("Failed to delete stored file %s: %v", key, err)
+		}
+	}
+
+	logger.Info("Purged %s %d from the trash", itemType, id)
+	return nil
+}
+
+// PurgeExpired permanently deletes the records deleted more than the retention period ago and returns how many
+// were purged
+func (s *trashService) PurgeExpired(ctx context.Context) (int, error) {
+	if s.retentionDays == 0 {
+		return 0, nil
+	}
+
+	cutoff := time.Now().AddDate(0, 0, -s.retentionDays)
+	items, err := s.repo.FindAll(&cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch trash: %w", err)
+	}
+
+	purged := 0
+	for _, item := range items {
+		err := s.Purge(ctx, item.Type, item.ID)
+		switch {
+		case errors.Is(err, constants.ErrTrashItemNotFound):
+			// A client purged along with its experience
+			continue
+		case err != nil:
+			return purged, err
+		}
+		purged++
+	}
+
+	if purged > 0 {
+		logger.Info("Purged %d records deleted more than %d days ago", purged, s.retentionDays)
+	}
+	return purged, nil
+}
+
+// find returns the deleted record, ErrInvalidTrashType or ErrTrashItemNotFound
+func (s *trashService) find(itemType string, id uint) (*models.TrashItem, error) {
+	if !isTrashType(itemType) {
+		return nil, constants.ErrInvalidTrashType
+	}
+
+	item, err := s.repo.FindByID(itemType, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrTrashItemNotFound
+		}
+		logger.Error("Failed to fetch %s %d from the trash: %v", itemType, id, err)
+		return nil, fmt.Errorf("failed to fetch %s: %w", itemType, err)
+	}
+	return item, nil
+}
+
+// isTrashType reports whether the records of the given type go to the trash
+func isTrashType(itemType string) bool {
+	switch itemType {
+	case models.TrashExperience, models.TrashExperienceClient, models.TrashProject, models.TrashCertification:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,481 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/handlers/dto"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"gorm.io/gorm"
+)
+
+var (
+	// ErrUploadQueueFull is returned when too many upload jobs are already waiting
+	ErrUploadQueueFull = errors.New("too many uploads in progress, try again later")
+	// errUploadFileMissing is the error of a job file whose staged copy is gone, e.g. after a volume was lost
+	errUploadFileMissing = errors.New("the uploaded file is no longer available")
+)
+
+const (
+	// maxQueuedUploadJobs bounds the jobs waiting for a runner
+	maxQueuedUploadJobs = 100
+	// uploadJobRunners is the number of jobs processed at the same time, each with its own worker pool
+	uploadJobRunners = 2
+	// uploadJobTimeoutPerFile bounds how long a job may run, per file left to process
+	uploadJobTimeoutPerFile = time.Minute
+	// uploadJobEventBuffer is the number of events kept for a slow subscriber before they are dropped
+	uploadJobEventBuffer = 256
+)
+
+// UploadJobOptions tunes how an upload job processes its files
+type UploadJobOptions struct {
+	// Workers is the number of files processed concurrently, DefaultMaxWorkers when zero
+	Workers int
+	// Atomic stores every file or none of them
+	Atomic bool
+}
+
+// UploadJobService runs certification batch uploads in the background and streams their progress
+type UploadJobService interface {
+	Start()
+	Enqueue(files []*multipart.FileHeader, metadata []dto.CertificationMetadata, opts UploadJobOptions) (*models.UploadJob, error)
+	GetByID(id uint) (*models.UploadJob, error)
+	Subscribe(id uint) (<-chan models.UploadJobEvent, func())
+}
+
+// uploadJobService stages the uploaded files on disk and feeds them to the certification worker pool
+type uploadJobService struct {
+	repo           repository.UploadJobRepository
+	certifications CareerCertificationService
+	stagingDir     string
+	queue          chan uint
+
+	mu          sync.Mutex
+	subscribers map[uint]map[chan models.UploadJobEvent]struct{}
+}
+
+// NewUploadJobService creates a new instance of UploadJobService. Files are staged under stagingDir until their
+// job finishes; call Start to process the queue.
+func NewUploadJobService(repo repository.UploadJobRepository, certifications CareerCertificationService, stagingDir string) UploadJobService {
+	return &uploadJobService{
+		repo:           repo,
+		certifications: certifications,
+		stagingDir:     stagingDir,
+		queue:          make(chan uint, maxQueuedUploadJobs),
+		subscribers:    make(map[uint]map[chan models.UploadJobEvent]struct{}),
+	}
+}
+
+// Start launches the job runners and queues again the jobs left unfinished by a previous run.
+// Files already stored by an interrupted job are not processed twice.
+func (s *uploadJobService) Start() {
+	for i := 0; i < uploadJobRunners; i++ {
+		go s.runner()
+	}
+
+	jobs, err := s.repo.FindUnfinished()
+	if err != nil {
+		logger.Error("Failed to load unfinished upload jobs: %v", err)
+		return
+	}
+	if len(jobs) == 0 {
+		return
+	}
+
+	logger.Info("Resuming %d unfinished upload jobs", len(jobs))
+	go func() {
+		for _, job := range jobs {
+			s.queue <- job.ID
+		}
+	}()
+}
+
+// Enqueue stages the uploaded files and queues a job storing them with their metadata, in the same order.
+// The job is returned as soon as it is queued.
+func (s *uploadJobService) Enqueue(files []*multipart.FileHeader, metadata []dto.CertificationMetadata, opts UploadJobOptions) (*models.UploadJob, error) {
+	if len(metadata) != len(files) {
+		return nil, fmt.Errorf("got metadata for %d files, expected %d", len(metadata), len(files))
+	}
+
+	workers := opts.Workers
+	if workers <= 0 {
+		workers = DefaultMaxWorkers
+	}
+
+	rawMetadata, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload metadata: %w", err)
+	}
+
+	jobFiles := make(models.UploadJobFiles, len(files))
+	for i, file := range files {
+		jobFiles[i] = models.UploadJobFile{
+			Index:        i,
+			OriginalName: file.Filename,
+			Size:         file.Size,
+			Status:       models.UploadFilePending,
+		}
+	}
+
+	job := &models.UploadJob{
+		Status:   models.UploadJobQueued,
+		Atomic:   opts.Atomic,
+		Workers:  workers,
+		Total:    len(files),
+		Files:    jobFiles,
+		Metadata: string(rawMetadata),
+	}
+	if err := s.repo.Create(job); err != nil {
+		logger.Error("Failed to create upload job: %v", err)
+		return nil, fmt.Errorf("failed to create upload job: %w", err)
+	}
+
+	if err := s.stage(job.ID, files); err != nil {
+		logger.Error("Failed to stage files of upload job %d: %v", job.ID, err)
+		s.abort(job, err)
+		return nil, fmt.Errorf("failed to stage uploaded files: %w", err)
+	}
+
+	select {
+	case s.queue <- job.ID:
+	default:
+		logger.Warn("Upload queue is full, rejecting job %d", job.ID)
+		s.abort(job, ErrUploadQueueFull)
+		return nil, ErrUploadQueueFull
+	}
+
+	logger.Info("Queued upload job %d with %d files", job.ID, job.Total)
+	return job, nil
+}
+
+// GetByID retrieves an upload job with the progress of each file
+func (s *uploadJobService) GetByID(id uint) (*models.UploadJob, error) {
+	job, err := s.repo.FindByID(id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUploadJobNotFound
+		}
+		logger.Error("Failed to fetch upload job %d: %v", id, err)
+		return nil, fmt.Errorf("failed to fetch upload job: %w", err)
+	}
+	return job, nil
+}
+
+// Subscribe returns a channel receiving the progress events of a job, closed once the job finishes,
+// and a function to stop listening. Subscribe before reading the job so no update is missed.
+func (s *uploadJobService) Subscribe(id uint) (<-chan models.UploadJobEvent, func()) {
+	events := make(chan models.UploadJobEvent, uploadJobEventBuffer)
+
+	s.mu.Lock()
+	if s.subscribers[id] == nil {
+		s.subscribers[id] = make(map[chan models.UploadJobEvent]struct{})
+	}
+	s.subscribers[id][events] = struct{}{}
+	s.mu.Unlock()
+
+	unsubscribe := func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		delete(s.subscribers[id], events)
+		if len(s.subscribers[id]) == 0 {
+			delete(s.subscribers, id)
+		}
+	}
+
+	return events, unsubscribe
+}
+
+// runner processes queued jobs one at a time
+func (s *uploadJobService) runner() {
+	for id := range s.queue {
+		s.run(id)
+	}
+}
+
+// run stores the pending files of a job through the certification worker pool, recording each result as it comes
+func (s *uploadJobService) run(id uint) {
+	job, err := s.repo.FindByID(id)
+	if err != nil {
+		logger.Error("Failed to load upload job %d: %v", id, err)
+		return
+	}
+	if job.Finished() {
+		return
+	}
+
+	var metadata []dto.CertificationMetadata
+	if err := json.Unmarshal([]byte(job.Metadata), &metadata); err != nil || len(metadata) != len(job.Files) {
+		logger.Error("Upload job %d has invalid metadata: %v", id, err)
+		s.abort(job, errors.New("the job metadata is invalid"))
+		return
+	}
+
+	if job.Atomic {
+		// Nothing of an interrupted atomic job was saved, start it over
+		resetJobFiles(job)
+	}
+
+	now := time.Now()
+	if job.StartedAt == nil {
+		job.StartedAt = &now
+	}
+	job.Status = models.UploadJobRunning
+	if err := s.repo.Update(id, map[string]interface{}{"status": job.Status, "started_at": job.StartedAt}); err != nil {
+		logger.Error("Failed to start upload job %d: %v", id, err)
+		return
+	}
+
+	pending := make([]FileWithMetadata, 0, len(job.Files))
+	var missing []UploadResult
+	for i, file := range job.Files {
+		if file.Status != models.UploadFilePending {
+			continue
+		}
+
+		path := s.stagedPath(id, i)
+		if _, err := os.Stat(path); err != nil {
+			missing = append(missing, UploadResult{Index: i, OriginalName: file.OriginalName, Error: errUploadFileMissing})
+			continue
+		}
+
+		pending = append(pending, FileWithMetadata{
+			Index:    i,
+			File:     newStagedFile(path, file.OriginalName, file.Size),
+			Metadata: &metadata[i],
+		})
+	}
+
+	if job.Atomic && len(missing) > 0 {
+		// The batch cannot be complete, fail every file without storing any
+		cause := fmt.Errorf("%w: %s is no longer available", ErrBatchRolledBack, missing[0].OriginalName)
+		for _, file := range pending {
+			missing = append(missing, UploadResult{Index: file.Index, OriginalName: file.File.Filename, Error: cause})
+		}
+		s.settle(job, missing)
+		s.finish(job)
+		return
+	}
+	for _, result := range missing {
+		s.record(job, result, false)
+	}
+
+	logger.Info("Running upload job %d: %d of %d files left", id, len(pending), job.Total)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(len(pending))*uploadJobTimeoutPerFile)
+	defer cancel()
+
+	if job.Atomic {
+		results := s.certifications.StoreBatchAtomic(ctx, pending, job.Workers, func(result UploadResult) {
+			s.record(job, result, true)
+		})
+		s.settle(job, results)
+	} else {
+		s.certifications.StoreBatch(ctx, pending, job.Workers, func(result UploadResult) {
+			s.record(job, result, false)
+		})
+	}
+
+	s.finish(job)
+}
+
+// record saves the outcome of one file and notifies the subscribers. Files of atomic jobs are only staged
+// until the outcome of the whole batch is known; a duplicate is linked to the certification already storing it.
+func (s *uploadJobService) record(job *models.UploadJob, result UploadResult, staged bool) {
+	file := &job.Files[result.Index]
+	job.Processed++
+	var duplicate *DuplicateCertificateError
+	switch {
+	case errors.As(result.Error, &duplicate):
+		file.Status = models.UploadFileDuplicate
+		file.CertificationID = &duplicate.Existing.ID
+		file.Error = result.Error.Error()
+		job.Duplicates++
+	case result.Success && staged:
+		file.Status = models.UploadFileStaged
+	case result.Success:
+		file.Status = models.UploadFileSucceeded
+		file.CertificationID = &result.Certification.ID
+		job.Succeeded++
+	default:
+		file.Status = models.UploadFileFailed
+		file.Error = result.Error.Error()
+		job.Failed++
+	}
+
+	s.saveProgress(job, *file)
+}
+
+// settle applies the final results of an atomic batch: every file succeeded or was a duplicate, or every file failed
+func (s *uploadJobService) settle(job *models.UploadJob, results []UploadResult) {
+	for _, result := range results {
+		file := &job.Files[result.Index]
+		var duplicate *DuplicateCertificateError
+		switch {
+		case result.Success:
+			file.Status = models.UploadFileSucceeded
+			file.CertificationID = &result.Certification.ID
+			file.Error = ""
+		case errors.As(result.Error, &duplicate):
+			file.Status = models.UploadFileDuplicate
+			file.CertificationID = &duplicate.Existing.ID
+			file.Error = result.Error.Error()
+		default:
+			file.Status = models.UploadFileFailed
+			file.CertificationID = nil
+			file.Error = result.Error.Error()
+		}
+	}
+
+	job.Processed, job.Succeeded, job.Failed, job.Duplicates = 0, 0, 0, 0
+	for _, file := range job.Files {
+		switch file.Status {
+		case models.UploadFileSucceeded:
+			job.Succeeded++
+		case models.UploadFileFailed:
+			job.Failed++
+		case models.UploadFileDuplicate:
+			job.Duplicates++
+		}
+		if file.Status != models.UploadFilePending {
+			job.Processed++
+		}
+	}
+
+	for _, result := range results {
+		s.saveProgress(job, job.Files[result.Index])
+	}
+}
+
+// resetJobFiles marks every file of a job as pending again
+func resetJobFiles(job *models.UploadJob) {
+	for i := range job.Files {
+		job.Files[i].Status = models.UploadFilePending
+		job.Files[i].CertificationID = nil
+		job.Files[i].Error = ""
+	}
+	job.Processed, job.Succeeded, job.Failed, job.Duplicates = 0, 0, 0, 0
+}
+
+// saveProgress persists the files and counters of a job and sends the file that changed to the subscribers
+func (s *uploadJobService) saveProgress(job *models.UploadJob, file models.UploadJobFile) {
+	err := s.repo.Update(job.ID, map[string]interface{}{
+		"files":      job.Files,
+		"processed":  job.Processed,
+		"succeeded":  job.Succeeded,
+		"failed":     job.Failed,
+		"duplicates": job.Duplicates,
+	})
+	if err != nil {
+		logger.Error("Failed to save progress of upload job %d: %v", job.ID, err)
+	}
+
+	s.publish(job.ID, models.UploadJobEvent{
+		Type: "file",
+		Data: models.UploadJobProgress{
+			JobID:      job.ID,
+			Status:     job.Status,
+			Total:      job.Total,
+			Processed:  job.Processed,
+			Succeeded:  job.Succeeded,
+			Failed:     job.Failed,
+			Duplicates: job.Duplicates,
+			File:       file,
+		},
+	})
+}
+
+// finish marks the job as done, removes its staged files and closes the subscriptions.
+// A job where no file could be stored is failed, like the synchronous upload it replaces, unless its files
+// were already stored.
+func (s *uploadJobService) finish(job *models.UploadJob) {
+	now := time.Now()
+	job.FinishedAt = &now
+	job.Status = models.UploadJobCompleted
+	if job.Succeeded == 0 && job.Duplicates == 0 && job.Total > 0 {
+		job.Status = models.UploadJobFailed
+	}
+
+	if err := s.repo.Update(job.ID, map[string]interface{}{"status": job.Status, "finished_at": job.FinishedAt}); err != nil {
+		logger.Error("Failed to finish upload job %d: %v", job.ID, err)
+	}
+
+	logger.Info("Finished upload job %d: %d/%d successful, %d duplicates", job.ID, job.Succeeded, job.Total, job.Duplicates)
+	s.cleanUp(job.ID)
+}
+
+// abort fails a job that cannot run at all
+func (s *uploadJobService) abort(job *models.UploadJob, cause error) {
+	now := time.Now()
+	job.Status = models.UploadJobFailed
+	job.Error = cause.Error()
+	job.FinishedAt = &now
+
+	err := s.repo.Update(job.ID, map[string]interface{}{"status": job.Status, "error": job.Error, "finished_at": job.FinishedAt})
+	if err != nil {
+		logger.Error("Failed to fail upload job %d: %v", job.ID, err)
+	}
+
+	s.cleanUp(job.ID)
+}
+
+// cleanUp removes the staged files of a finished job and closes its subscriptions
+func (s *uploadJobService) cleanUp(id uint) {
+	if err := os.RemoveAll(s.jobDir(id)); err != nil {
+		logger.Warn("Failed to remove staged files of upload job %d: %v", id, err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for events := range s.subscribers[id] {
+		close(events)
+	}
+	delete(s.subscribers, id)
+}
+
+// publish sends an event to the subscribers of a job without blocking the job
+func (s *uploadJobService) publish(id uint, event models.UploadJobEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for events := range s.subscribers[id] {
+		select {
+		case events <- event:
+		default:
+			logger.Warn("Dropping %s event of upload job %d for a slow subscriber", event.Type, id)
+		}
+	}
+}
+
+// stage copies the uploaded files to the job directory, named after their index
+func (s *uploadJobService) stage(id uint, files []*multipart.FileHeader) error {
+	if err := os.MkdirAll(s.jobDir(id), 0755); err != nil {
+		return err
+	}
+
+	for i, file := range files {
+		if err := saveFile(file, s.stagedPath(id, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// jobDir is the directory holding the staged files of a job
+func (s *uploadJobService) jobDir(id uint) string {
+	return filepath.Join(s.stagingDir, strconv.FormatUint(uint64(id), 10))
+}
+
+// stagedPath is the path of the staged file at the given index of a job
+func (s *uploadJobService) stagedPath(id uint, index int) string {
+	return filepath.Join(s.jobDir(id), strconv.Itoa(index))
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/logger"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+// MinPasswordLength is the minimum number of characters accepted for an admin password
+const MinPasswordLength = 12
+
+var (
+	ErrUserNotFound      = errors.New("user not found")
+	ErrUserAlreadyExists = errors.New("a user with that email already exists")
+	ErrPasswordTooShort  = fmt.Errorf("password must be at least %d characters", MinPasswordLength)
+)
+
+// UserService manages admin user accounts. It is used by the admin CLI to provision
+// users, rotate passwords and revoke sessions.
+type UserService interface {
+	CreateUser(email, password string) (*models.User, error)
+	ResetPassword(email, password string) error
+	ListUsers() ([]models.User, error)
+	RevokeSessions(email string) (int64, error)
+	DisableTwoFactor(email string) error
+}
+
+type userService struct {
+	repo repository.AuthRepository
+}
+
+// NewUserService creates a new instance of UserService
+func NewUserService(repo repository.AuthRepository) UserService {
+	return &userService{repo: repo}
+}
+
+// CreateUser hashes the password and stores a new user, rejecting duplicated emails
+func (s *userService) CreateUser(email, password string) (*models.User, error) {
+	email = normalizeEmail(email)
+
+	if _, err := s.repo.FindUserByEmail(email); err == nil {
+		return nil, ErrUserAlreadyExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &models.User{Email: email, Password: hash}
+	if err := s.repo.CreateUser(user); err != nil {
+		return nil, fmt.Errorf("failed to create user: %w", err)
+	}
+
+	logger.Info("Created user %s with ID: %d", user.Email, user.ID)
+	return user, nil
+}
+
+// ResetPassword replaces the password of an existing user and revokes all of their sessions and pending login
+// challenges, so a login that got past the old password cannot be completed
+func (s *userService) ResetPassword(email, password string) error {
+	user, err := s.findUser(email)
+	if err != nil {
+		return err
+	}
+
+	hash, err := hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateUserPassword(user.ID, hash); err != nil {
+		return fmt.Errorf("failed to update password: %w", err)
+	}
+
+	if _, err := s.revokeSessions(user.ID); err != nil {
+		return err
+	}
+
+	logger.Info("Reset password for user %s", user.Email)
+	return nil
+}
+
+// ListUsers returns every registered user
+func (s *userService) ListUsers() ([]models.User, error) {
+	users, err := s.repo.FindAllUsers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list users: %w", err)
+	}
+	return users, nil
+}
+
+// RevokeSessions deletes all sessions and pending login challenges of a user and returns how many sessions were
+// removed
+func (s *userService) RevokeSessions(email string) (int64, error) {
+	user, err := s.findUser(email)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := s.revokeSessions(user.ID)
+	if err != nil {
+		return 0, err
+	}
+
+	logger.Info("Revoked %d sessions for user %s", count, user.Email)
+	return count, nil
+}
+
+// revokeSessions deletes the sessions and the pending login challenges of a user and returns how many sessions
+// were removed
+func (s *userService) revokeSessions(userID uint) (int64, error) {
+	count, err := s.repo.DeleteSessionsByUserID(userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+
+	if err := s.repo.DeleteLoginChallengesByUserID(userID); err != nil {
+		return 0, fmt.Errorf("failed to revoke login challenges: %w", err)
+	}
+	return count, nil
+}
+
+// DisableTwoFactor turns two-factor authentication off for a user who lost their authenticator and recovery codes
+func (s *userService) DisableTwoFactor(email string) error {
+	user, err := s.findUser(email)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DisableTwoFactor(user.ID); err != nil {
+		return fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	logger.Info("Disabled two-factor authentication for user %s", user.Email)
+	return nil
+}
+
+// findUser looks up a user by email and maps a missing record to ErrUserNotFound
+func (s *userService) findUser(email string) (*models.User, error) {
+	user, err := s.repo.FindUserByEmail(normalizeEmail(email))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to look up user: %w", err)
+	}
+	return user, nil
+}
+
+// hashPassword validates the password length and returns its bcrypt hash
+func hashPassword(password string) (string, error) {
+	if len(password) < MinPasswordLength {
+		return "", ErrPasswordTooShort
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+	return string(hash), nil
+}
+
+// normalizeEmail trims and lower-cases an email address so lookups are case-insensitive
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/models"
+	"github.com/JuanPabloCano/personal-portfolio/backend/internal/repository"
+)
+
+func TestRevokingSessionsDropsLoginChallenges(t *testing.T) {
+	revocations := map[string]func(service UserService) error{
+		"ResetPassword": func(service UserService) error {
+			return service.ResetPassword("admin@example.com", "another long password")
+		},
+		"RevokeSessions": func(service UserService) error {
+			_, err := service.RevokeSessions("admin@example.com")
+			return err
+		},
+	}
+
+	for name, revoke := range revocations {
+		t.Run(name, func(t *testing.T) {
+			db := newTestDB(t, &models.User{}, &models.Session{}, &models.LoginChallenge{})
+			service := NewUserService(repository.NewAuthRepository(db))
+
+			user := &models.User{Email: "admin@example.com", Password: "hash"}
+			other := &models.User{Email: "other@example.com", Password: "hash"}
+			if err := db.Create([]*models.User{user, other}).Error; err != nil {
+				t.Fatalf("failed to create users: %v", err)
+			}
+			for _, challenge := range []models.LoginChallenge{
+				{ID: "admin-challenge", UserID: user.ID, ExpiresAt: time.Now().Add(time.Minute), CreatedAt: time.Now()},
+				{ID: "other-challenge", UserID: other.ID, ExpiresAt: time.Now().Add(time.Minute), CreatedAt: time.Now()},
+			} {
+				if err := db.Create(&challenge).Error; err != nil {
+					t.Fatalf("failed to create challenge: %v", err)
+				}
+			}
+
+			if err := revoke(service); err != nil {
+				t.Fatalf("%s returned an error: %v", name, err)
+			}
+
+			var remaining []models.LoginChallenge
+			if err := db.Find(&remaining).Error; err != nil {
+				t.Fatalf("failed to read challenges: %v", err)
+			}
+			if len(remaining) != 1 || remaining[0].ID != "other-challenge" {
+				t.Errorf("challenges left = %+v, want only the one of the other user", remaining)
+			}
+		})
+	}
+}
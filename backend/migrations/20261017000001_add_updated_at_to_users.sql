@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN updated_at DATETIME;
+
+UPDATE users SET updated_at = created_at WHERE updated_at IS NULL;
+
+-- Emails are compared case-insensitively: users whose emails only differ by case or surrounding spaces have to be
+-- merged or deleted by hand before migrating, the migration is aborted with a message instead of failing on the
+-- index. They are listed by:
+--   SELECT lower(trim(email)), count(*) FROM users WHERE deleted_at IS NULL
+--   GROUP BY lower(trim(email)) HAVING count(*) > 1;
+CREATE TEMP TABLE duplicate_user_emails (email TEXT);
+CREATE TEMP TRIGGER abort_on_duplicate_user_emails BEFORE INSERT ON duplicate_user_emails
+BEGIN
+    SELECT RAISE(ABORT, 'several users share the same email regardless of case, merge or delete them before migrating');
+END;
+INSERT INTO duplicate_user_emails
+SELECT lower(trim(email)) FROM users WHERE deleted_at IS NULL
+GROUP BY lower(trim(email)) HAVING count(*) > 1;
+DROP TABLE duplicate_user_emails;
+
+UPDATE users SET email = lower(trim(email));
+
+-- Deleted users keep their email, so it can be used again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_email ON users (lower(email)) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_users_deleted_at ON users (deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_users_deleted_at;
+DROP INDEX IF EXISTS idx_users_email;
+ALTER TABLE users DROP COLUMN updated_at;
+-- +goose StatementEnd
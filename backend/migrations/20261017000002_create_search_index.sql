@@ -0,0 +1,183 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Unified full-text index over projects, experiences, experience clients and certifications.
+-- Rows are kept in sync by the triggers below; soft-deleted rows are removed from the index.
+CREATE VIRTUAL TABLE IF NOT EXISTS search_index USING fts5
+(
+    kind UNINDEXED,
+    ref_id UNINDEXED,
+    parent_id UNINDEXED,
+    title,
+    subtitle,
+    body,
+    tokenize = 'porter unicode61 remove_diacritics 2'
+);
+
+CREATE TRIGGER IF NOT EXISTS search_index_projects_ai
+    AFTER INSERT ON projects
+    FOR EACH ROW
+    WHEN NEW.deleted_at IS NULL
+BEGIN
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    VALUES ('project', NEW.id, NULL, NEW.name, COALESCE(NEW.technologies, ''), COALESCE(NEW.description, ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_projects_au
+    AFTER UPDATE ON projects
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'project' AND ref_id = OLD.id;
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    SELECT 'project', NEW.id, NULL, NEW.name, COALESCE(NEW.technologies, ''), COALESCE(NEW.description, '')
+    WHERE NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_projects_ad
+    AFTER DELETE ON projects
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'project' AND ref_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experiences_ai
+    AFTER INSERT ON experiences
+    FOR EACH ROW
+    WHEN NEW.deleted_at IS NULL
+BEGIN
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    VALUES ('experience', NEW.id, NULL, NEW.title, NEW.company, COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.location, ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experiences_au
+    AFTER UPDATE ON experiences
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'experience' AND ref_id = OLD.id;
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    SELECT 'experience', NEW.id, NULL, NEW.title, NEW.company, COALESCE(NEW.description, '') || ' ' || COALESCE(NEW.location, '')
+    WHERE NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experiences_ad
+    AFTER DELETE ON experiences
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'experience' AND ref_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experience_clients_ai
+    AFTER INSERT ON experience_clients
+    FOR EACH ROW
+    WHEN NEW.deleted_at IS NULL
+BEGIN
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    VALUES (
+        'client',
+        NEW.id,
+        NEW.experience_id,
+        NEW.name,
+        (SELECT COALESCE(group_concat(value, ', '), '') FROM json_each(CASE WHEN json_valid(NEW.technologies) THEN NEW.technologies ELSE '[]' END)),
+        COALESCE(NEW.description, '')
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(NEW.achievements) THEN NEW.achievements ELSE '[]' END))
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(NEW.responsibilities) THEN NEW.responsibilities ELSE '[]' END))
+    );
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experience_clients_au
+    AFTER UPDATE ON experience_clients
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'client' AND ref_id = OLD.id;
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    SELECT
+        'client',
+        NEW.id,
+        NEW.experience_id,
+        NEW.name,
+        (SELECT COALESCE(group_concat(value, ', '), '') FROM json_each(CASE WHEN json_valid(NEW.technologies) THEN NEW.technologies ELSE '[]' END)),
+        COALESCE(NEW.description, '')
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(NEW.achievements) THEN NEW.achievements ELSE '[]' END))
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(NEW.responsibilities) THEN NEW.responsibilities ELSE '[]' END))
+    WHERE NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_experience_clients_ad
+    AFTER DELETE ON experience_clients
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'client' AND ref_id = OLD.id;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_career_certifications_ai
+    AFTER INSERT ON career_certifications
+    FOR EACH ROW
+    WHEN NEW.deleted_at IS NULL
+BEGIN
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    VALUES ('certification', NEW.id, NULL, NEW.title, NEW.issuer, COALESCE(NEW.description, ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_career_certifications_au
+    AFTER UPDATE ON career_certifications
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'certification' AND ref_id = OLD.id;
+    INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+    SELECT 'certification', NEW.id, NULL, NEW.title, NEW.issuer, COALESCE(NEW.description, '')
+    WHERE NEW.deleted_at IS NULL;
+END;
+
+CREATE TRIGGER IF NOT EXISTS search_index_career_certifications_ad
+    AFTER DELETE ON career_certifications
+    FOR EACH ROW
+BEGIN
+    DELETE FROM search_index WHERE kind = 'certification' AND ref_id = OLD.id;
+END;
+
+-- Backfill existing rows
+INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+SELECT 'project', projects.id, NULL, projects.name, COALESCE(projects.technologies, ''), COALESCE(projects.description, '')
+FROM projects
+WHERE projects.deleted_at IS NULL;
+
+INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+SELECT 'experience', experiences.id, NULL, experiences.title, experiences.company, COALESCE(experiences.description, '') || ' ' || COALESCE(experiences.location, '')
+FROM experiences
+WHERE experiences.deleted_at IS NULL;
+
+INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+SELECT
+        'client',
+        experience_clients.id,
+        experience_clients.experience_id,
+        experience_clients.name,
+        (SELECT COALESCE(group_concat(value, ', '), '') FROM json_each(CASE WHEN json_valid(experience_clients.technologies) THEN experience_clients.technologies ELSE '[]' END)),
+        COALESCE(experience_clients.description, '')
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(experience_clients.achievements) THEN experience_clients.achievements ELSE '[]' END))
+            || ' ' || (SELECT COALESCE(group_concat(value, '. '), '') FROM json_each(CASE WHEN json_valid(experience_clients.responsibilities) THEN experience_clients.responsibilities ELSE '[]' END))
+FROM experience_clients
+WHERE experience_clients.deleted_at IS NULL;
+
+INSERT INTO search_index (kind, ref_id, parent_id, title, subtitle, body)
+SELECT 'certification', career_certifications.id, NULL, career_certifications.title, career_certifications.issuer, COALESCE(career_certifications.description, '')
+FROM career_certifications
+WHERE career_certifications.deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS search_index_projects_ai;
+DROP TRIGGER IF EXISTS search_index_projects_au;
+DROP TRIGGER IF EXISTS search_index_projects_ad;
+DROP TRIGGER IF EXISTS search_index_experiences_ai;
+DROP TRIGGER IF EXISTS search_index_experiences_au;
+DROP TRIGGER IF EXISTS search_index_experiences_ad;
+DROP TRIGGER IF EXISTS search_index_experience_clients_ai;
+DROP TRIGGER IF EXISTS search_index_experience_clients_au;
+DROP TRIGGER IF EXISTS search_index_experience_clients_ad;
+DROP TRIGGER IF EXISTS search_index_career_certifications_ai;
+DROP TRIGGER IF EXISTS search_index_career_certifications_au;
+DROP TRIGGER IF EXISTS search_index_career_certifications_ad;
+DROP TABLE IF EXISTS search_index;
+-- +goose StatementEnd
+
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS education (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    institution VARCHAR(255) NOT NULL,
+    url VARCHAR(500),
+    area VARCHAR(255),
+    study_type VARCHAR(255),
+    start_date DATE NOT NULL,
+    end_date DATE,
+    score VARCHAR(50),
+    courses TEXT DEFAULT '[]',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TRIGGER IF NOT EXISTS update_education_updated_at
+    AFTER UPDATE ON education
+    FOR EACH ROW
+BEGIN
+    UPDATE education SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE INDEX IF NOT EXISTS idx_education_start_date ON education(start_date);
+CREATE INDEX IF NOT EXISTS idx_education_deleted_at ON education(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_education_updated_at;
+DROP INDEX IF EXISTS idx_education_deleted_at;
+DROP INDEX IF EXISTS idx_education_start_date;
+DROP TABLE IF EXISTS education;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS skills (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    category VARCHAR(100),
+    level VARCHAR(50),
+    keywords TEXT DEFAULT '[]',
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TRIGGER IF NOT EXISTS update_skills_updated_at
+    AFTER UPDATE ON skills
+    FOR EACH ROW
+BEGIN
+    UPDATE skills SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+-- Skill names are unique (case-insensitive) among the skills that are not deleted
+CREATE UNIQUE INDEX IF NOT EXISTS idx_skills_name ON skills(name COLLATE NOCASE) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_skills_category ON skills(category);
+CREATE INDEX IF NOT EXISTS idx_skills_deleted_at ON skills(deleted_at);
+
+CREATE TABLE IF NOT EXISTS project_skills (
+    skill_id INTEGER NOT NULL,
+    project_id INTEGER NOT NULL,
+    PRIMARY KEY (skill_id, project_id),
+    FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE,
+    FOREIGN KEY (project_id) REFERENCES projects(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_project_skills_project_id ON project_skills(project_id);
+
+CREATE TABLE IF NOT EXISTS experience_client_skills (
+    skill_id INTEGER NOT NULL,
+    experience_client_id INTEGER NOT NULL,
+    PRIMARY KEY (skill_id, experience_client_id),
+    FOREIGN KEY (skill_id) REFERENCES skills(id) ON DELETE CASCADE,
+    FOREIGN KEY (experience_client_id) REFERENCES experience_clients(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_experience_client_skills_client_id ON experience_client_skills(experience_client_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_experience_client_skills_client_id;
+DROP TABLE IF EXISTS experience_client_skills;
+DROP INDEX IF EXISTS idx_project_skills_project_id;
+DROP TABLE IF EXISTS project_skills;
+DROP TRIGGER IF EXISTS update_skills_updated_at;
+DROP INDEX IF EXISTS idx_skills_deleted_at;
+DROP INDEX IF EXISTS idx_skills_category;
+DROP INDEX IF EXISTS idx_skills_name;
+DROP TABLE IF EXISTS skills;
+-- +goose StatementEnd
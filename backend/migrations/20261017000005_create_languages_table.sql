@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS languages (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    name VARCHAR(100) NOT NULL,
+    fluency VARCHAR(100) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TRIGGER IF NOT EXISTS update_languages_updated_at
+    AFTER UPDATE ON languages
+    FOR EACH ROW
+BEGIN
+    UPDATE languages SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_languages_name ON languages(name COLLATE NOCASE) WHERE deleted_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_languages_deleted_at ON languages(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_languages_updated_at;
+DROP INDEX IF EXISTS idx_languages_deleted_at;
+DROP INDEX IF EXISTS idx_languages_name;
+DROP TABLE IF EXISTS languages;
+-- +goose StatementEnd
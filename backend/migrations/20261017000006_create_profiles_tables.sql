@@ -0,0 +1,61 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS profiles (
+    id INTEGER PRIMARY KEY CHECK (id = 1),
+    name VARCHAR(255) NOT NULL,
+    label VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(50),
+    url VARCHAR(500),
+    summary TEXT,
+    city VARCHAR(100),
+    region VARCHAR(100),
+    country_code VARCHAR(2),
+    avatar_url VARCHAR(500),
+    avatar_file_name VARCHAR(255),
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME
+);
+
+CREATE TRIGGER IF NOT EXISTS update_profiles_updated_at
+    AFTER UPDATE ON profiles
+    FOR EACH ROW
+BEGIN
+    UPDATE profiles SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE TABLE IF NOT EXISTS social_profiles (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    profile_id INTEGER NOT NULL,
+    network VARCHAR(100) NOT NULL,
+    username VARCHAR(255),
+    url VARCHAR(500) NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    deleted_at DATETIME,
+    FOREIGN KEY (profile_id) REFERENCES profiles(id) ON DELETE CASCADE
+);
+
+CREATE TRIGGER IF NOT EXISTS update_social_profiles_updated_at
+    AFTER UPDATE ON social_profiles
+    FOR EACH ROW
+BEGIN
+    UPDATE social_profiles SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE INDEX IF NOT EXISTS idx_profiles_deleted_at ON profiles(deleted_at);
+CREATE INDEX IF NOT EXISTS idx_social_profiles_profile_id ON social_profiles(profile_id);
+CREATE INDEX IF NOT EXISTS idx_social_profiles_deleted_at ON social_profiles(deleted_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_social_profiles_updated_at;
+DROP INDEX IF EXISTS idx_social_profiles_deleted_at;
+DROP INDEX IF EXISTS idx_social_profiles_profile_id;
+DROP TABLE IF EXISTS social_profiles;
+DROP TRIGGER IF EXISTS update_profiles_updated_at;
+DROP INDEX IF EXISTS idx_profiles_deleted_at;
+DROP TABLE IF EXISTS profiles;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE career_certifications
+    ADD COLUMN width INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE career_certifications
+    ADD COLUMN height INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE career_certifications
+    ADD COLUMN blurhash VARCHAR(100);
+ALTER TABLE career_certifications
+    ADD COLUMN renditions TEXT NOT NULL DEFAULT '[]';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE career_certifications DROP COLUMN renditions;
+ALTER TABLE career_certifications DROP COLUMN blurhash;
+ALTER TABLE career_certifications DROP COLUMN height;
+ALTER TABLE career_certifications DROP COLUMN width;
+-- +goose StatementEnd
@@ -0,0 +1,35 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS upload_jobs (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    workers INTEGER NOT NULL DEFAULT 0,
+    total INTEGER NOT NULL DEFAULT 0,
+    processed INTEGER NOT NULL DEFAULT 0,
+    succeeded INTEGER NOT NULL DEFAULT 0,
+    failed INTEGER NOT NULL DEFAULT 0,
+    files TEXT NOT NULL DEFAULT '[]',
+    metadata TEXT NOT NULL DEFAULT '[]',
+    error TEXT,
+    started_at DATETIME,
+    finished_at DATETIME,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS update_upload_jobs_updated_at
+    AFTER UPDATE ON upload_jobs
+    FOR EACH ROW
+BEGIN
+    UPDATE upload_jobs SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE INDEX IF NOT EXISTS idx_upload_jobs_status ON upload_jobs(status);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_upload_jobs_updated_at;
+DROP INDEX IF EXISTS idx_upload_jobs_status;
+DROP TABLE IF EXISTS upload_jobs;
+-- +goose StatementEnd
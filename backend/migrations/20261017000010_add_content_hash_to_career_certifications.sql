@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE career_certifications
+    ADD COLUMN content_hash VARCHAR(64);
+
+-- Soft-deleted certifications do not block uploading their file again
+CREATE UNIQUE INDEX IF NOT EXISTS idx_career_certifications_content_hash
+    ON career_certifications(content_hash) WHERE deleted_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_career_certifications_content_hash;
+ALTER TABLE career_certifications DROP COLUMN content_hash;
+-- +goose StatementEnd
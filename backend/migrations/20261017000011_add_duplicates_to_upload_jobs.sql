@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE upload_jobs
+    ADD COLUMN duplicates INTEGER NOT NULL DEFAULT 0;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE upload_jobs DROP COLUMN duplicates;
+-- +goose StatementEnd
@@ -0,0 +1,13 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE career_certifications
+    ADD COLUMN expiry_notified_at DATETIME;
+
+CREATE INDEX IF NOT EXISTS idx_career_certifications_expiry_date ON career_certifications(expiry_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_career_certifications_expiry_date;
+ALTER TABLE career_certifications DROP COLUMN expiry_notified_at;
+-- +goose StatementEnd
@@ -0,0 +1,32 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS link_checks (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner_type VARCHAR(30) NOT NULL,
+    owner_id INTEGER NOT NULL,
+    url VARCHAR(500) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    status_code INTEGER NOT NULL DEFAULT 0,
+    error TEXT,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0,
+    checked_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TRIGGER IF NOT EXISTS update_link_checks_updated_at
+    AFTER UPDATE ON link_checks
+    FOR EACH ROW
+BEGIN
+    UPDATE link_checks SET updated_at = CURRENT_TIMESTAMP WHERE id = OLD.id;
+END;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_link_checks_owner ON link_checks(owner_type, owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TRIGGER IF EXISTS update_link_checks_updated_at;
+DROP INDEX IF EXISTS idx_link_checks_owner;
+DROP TABLE IF EXISTS link_checks;
+-- +goose StatementEnd
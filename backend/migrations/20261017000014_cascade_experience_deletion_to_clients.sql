@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Clients of an experience deleted before deletions cascaded are deleted along with it, at the same time, so
+-- restoring the experience brings them back
+UPDATE experience_clients
+SET deleted_at = (SELECT experiences.deleted_at FROM experiences WHERE experiences.id = experience_clients.experience_id)
+WHERE deleted_at IS NULL
+  AND experience_id IN (SELECT id FROM experiences WHERE deleted_at IS NOT NULL);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The clients deleted by the migration cannot be told apart from those deleted along with their experience since
+-- deletions cascade, nor from those deleted on their own at the same time, so they are left deleted
+SELECT 1;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE sessions ADD COLUMN user_agent VARCHAR(512) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN ip_address VARCHAR(45) NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN last_seen_at DATETIME;
+
+UPDATE sessions SET last_seen_at = created_at;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_id ON sessions (user_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS idx_sessions_user_id;
+ALTER TABLE sessions DROP COLUMN last_seen_at;
+ALTER TABLE sessions DROP COLUMN ip_address;
+ALTER TABLE sessions DROP COLUMN user_agent;
+-- +goose StatementEnd
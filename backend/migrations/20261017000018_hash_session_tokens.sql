@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Sessions are now stored under the SHA-256 of their token. The existing rows hold the tokens in clear and cannot
+-- be looked up anymore, so they are invalidated: everyone signs in again.
+DELETE FROM sessions;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The invalidated sessions cannot be restored
+-- +goose StatementEnd
@@ -3,16 +3,52 @@ package constants
 import (
 	"errors"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var (
 	ErrExperienceNotFound       = errors.New("experience not found")
 	ErrProjectNotFound          = errors.New("project not found")
 	ErrExperienceClientNotFound = errors.New("experience client not found")
+	ErrEducationNotFound        = errors.New("education not found")
+	ErrSkillNotFound            = errors.New("skill not found")
+	ErrSkillAlreadyExists       = errors.New("a skill with that name already exists")
+	ErrSkillLinkNotFound        = errors.New("one or more linked projects or experience clients do not exist")
+	ErrLanguageNotFound         = errors.New("language not found")
+	ErrLanguageAlreadyExists    = errors.New("a language with that name already exists")
+	ErrProfileNotFound          = errors.New("profile not found")
+	ErrCertificationNotFound    = errors.New("certification not found")
+	ErrUploadJobNotFound        = errors.New("upload job not found")
+	ErrInvalidTrashType         = errors.New("invalid trash item type")
+	ErrTrashItemNotFound        = errors.New("item not found in the trash")
+	ErrTrashParentDeleted       = errors.New("the experience of this client is deleted, restore it first")
+	ErrTrashFilesMissing        = errors.New("the files of this certification are no longer stored")
 )
 
 const CareerCertificationsDir = "pkg/assets/career-certifications"
 
+// CertificationStagingDir holds the files of the atomic certification batches until their records are committed.
+// Unlike CareerCertificationsDir it is not served.
+const CertificationStagingDir = "pkg/assets/certification-staging"
+
+// AvatarsDir holds the uploaded profile pictures
+const AvatarsDir = "pkg/assets/avatars"
+
+// Upload request limits, the whole multipart body including every file and form field
+const (
+	MaxCertificateUploadRequestSize = 100 << 20
+	MaxAvatarUploadRequestSize      = 6 << 20
+	MaxCertificateFileRequestSize   = 21 << 20
+)
+
+// UploadJobsDir holds the files of the upload jobs until they are processed, one directory per job
+const UploadJobsDir = "pkg/assets/upload-jobs"
+
+// ResumeCacheDir holds the rendered PDF résumés, one file per template and data fingerprint
+const ResumeCacheDir = "pkg/assets/resume-cache"
+
 // DefaultSessionCookieName is the default name for the session cookie
 const DefaultSessionCookieName = "portfolio_session"
 
@@ -24,3 +60,168 @@ func GetSessionCookieName() string {
 	}
 	return name
 }
+
+// CSRF protection of the cookie-authenticated write requests: the token of the CSRF cookie, readable by the
+// frontend, has to be sent back in the CSRF header
+const (
+	CSRFCookieName = "portfolio_csrf"
+	CSRFHeaderName = "X-CSRF-Token"
+)
+
+// GetAllowedOrigins returns the origins of the frontends allowed to call the API, from the comma-separated
+// ALLOWED_ORIGINS
+func GetAllowedOrigins() []string {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("ALLOWED_ORIGINS"), ",") {
+		if origin = strings.TrimRight(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+// GetTrustedProxies returns the addresses or CIDR ranges of the reverse proxies whose X-Forwarded-For header is
+// trusted for the client IP, from the comma-separated TRUSTED_PROXIES; none by default, so the client IP is the
+// address of the connection and cannot be spoofed
+func GetTrustedProxies() []string {
+	var proxies []string
+	for _, proxy := range strings.Split(os.Getenv("TRUSTED_PROXIES"), ",") {
+		if proxy = strings.TrimSpace(proxy); proxy != "" {
+			proxies = append(proxies, proxy)
+		}
+	}
+	return proxies
+}
+
+// GetTOTPEncryptionKey returns the key the TOTP secrets of two-factor authentication are encrypted with, empty
+// when two-factor authentication is not configured
+func GetTOTPEncryptionKey() string {
+	return os.Getenv("TOTP_ENCRYPTION_KEY")
+}
+
+// Login protection defaults. From LoginFreeFailures failed logins in LoginFailureWindow, an account or IP address
+// waits before its next attempt, twice as long after every failure up to LoginMaxBackoff; once it reaches its
+// maximum failures it is locked out for the lockout duration.
+const (
+	LoginFreeFailures            = 3
+	LoginMaxBackoff              = 5 * time.Minute
+	LoginFailureWindow           = 24 * time.Hour
+	DefaultLoginMaxFailures      = 10
+	DefaultLoginMaxFailuresPerIP = 30
+	DefaultLoginLockoutDuration  = 15 * time.Minute
+)
+
+// GetLoginMaxFailures returns how many failed logins lock an account out, from LOGIN_MAX_FAILURES or the default
+func GetLoginMaxFailures() int {
+	failures, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES"))
+	if err != nil || failures <= LoginFreeFailures {
+		return DefaultLoginMaxFailures
+	}
+	return failures
+}
+
+// GetLoginMaxFailuresPerIP returns how many failed logins lock an IP address out, from LOGIN_MAX_FAILURES_PER_IP
+// or the default
+func GetLoginMaxFailuresPerIP() int {
+	failures, err := strconv.Atoi(os.Getenv("LOGIN_MAX_FAILURES_PER_IP"))
+	if err != nil || failures <= LoginFreeFailures {
+		return DefaultLoginMaxFailuresPerIP
+	}
+	return failures
+}
+
+// GetLoginLockoutDuration returns how long an account or IP address stays locked out, from LOGIN_LOCKOUT_DURATION
+// (a Go duration such as "30m") or the default
+func GetLoginLockoutDuration() time.Duration {
+	duration, err := time.ParseDuration(os.Getenv("LOGIN_LOCKOUT_DURATION"))
+	if err != nil || duration <= 0 {
+		return DefaultLoginLockoutDuration
+	}
+	return duration
+}
+
+// Certification expiry notification defaults
+const (
+	DefaultCertificationExpiryNoticeDays    = 30
+	DefaultCertificationExpiryCheckInterval = 24 * time.Hour
+)
+
+// GetCertificationExpiryNoticeDays returns how many days before its expiry a certification is reported as
+// expiring and notified, from CERT_EXPIRY_NOTICE_DAYS or the default
+func GetCertificationExpiryNoticeDays() int {
+	days, err := strconv.Atoi(os.Getenv("CERT_EXPIRY_NOTICE_DAYS"))
+	if err != nil || days < 0 {
+		return DefaultCertificationExpiryNoticeDays
+	}
+	return days
+}
+
+// GetCertificationExpiryCheckInterval returns how often expiring certifications are looked for, from
+// CERT_EXPIRY_CHECK_INTERVAL (a Go duration such as "12h") or the default
+func GetCertificationExpiryCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("CERT_EXPIRY_CHECK_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultCertificationExpiryCheckInterval
+	}
+	return interval
+}
+
+// Link health check defaults. A broken link is only hidden from public responses once it failed
+// LinkHealthHideAfterFailures checks in a row, so a site that is briefly down keeps its link.
+const (
+	DefaultLinkCheckInterval    = 24 * time.Hour
+	LinkHealthHideAfterFailures = 2
+)
+
+// GetLinkCheckInterval returns how often the links of the portfolio are checked, from LINK_CHECK_INTERVAL (a
+// Go duration such as "12h") or the default
+func GetLinkCheckInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("LINK_CHECK_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultLinkCheckInterval
+	}
+	return interval
+}
+
+// GetLinkHealthHideBroken reports whether broken links are left out of public responses, from
+// LINK_HEALTH_HIDE_BROKEN, enabled unless set to false
+func GetLinkHealthHideBroken() bool {
+	hide, err := strconv.ParseBool(os.Getenv("LINK_HEALTH_HIDE_BROKEN"))
+	if err != nil {
+		return true
+	}
+	return hide
+}
+
+// DefaultStorageReconcileInterval is how often the certification files are reconciled with the database by default
+const DefaultStorageReconcileInterval = 24 * time.Hour
+
+// GetStorageReconcileInterval returns how often the certification files are reconciled with the database, from
+// STORAGE_RECONCILE_INTERVAL (a Go duration such as "12h") or the default
+func GetStorageReconcileInterval() time.Duration {
+	interval, err := time.ParseDuration(os.Getenv("STORAGE_RECONCILE_INTERVAL"))
+	if err != nil || interval <= 0 {
+		return DefaultStorageReconcileInterval
+	}
+	return interval
+}
+
+// GetStorageReconcilePurge reports whether the scheduled reconciliation deletes the orphaned certification files,
+// from STORAGE_RECONCILE_PURGE; disabled by default so the orphans are only reported
+func GetStorageReconcilePurge() bool {
+	purge, _ := strconv.ParseBool(os.Getenv("STORAGE_RECONCILE_PURGE"))
+	return purge
+}
+
+// DefaultTrashRetentionDays is how many days deleted records stay in the trash by default
+const DefaultTrashRetentionDays = 30
+
+// GetTrashRetentionDays returns how many days deleted records stay in the trash before they are deleted for good,
+// from TRASH_RETENTION_DAYS or the default; 0 keeps them until they are purged by hand
+func GetTrashRetentionDays() int {
+	days, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS"))
+	if err != nil || days < 0 {
+		return DefaultTrashRetentionDays
+	}
+	return days
+}
@@ -0,0 +1,46 @@
+package database
+
+import (
+	"fmt"
+	"os"
+)
+
+// ConfigFromEnv builds a Config from environment variables, falling back to a local SQLite database.
+// It is shared by every binary that needs a database connection (API server, admin CLI).
+func ConfigFromEnv() (Config, error) {
+	dbDriver := os.Getenv("DB_DRIVER")
+	if dbDriver == "" {
+		dbDriver = "sqlite"
+	}
+
+	config := Config{
+		Driver:        dbDriver,
+		MigrationsDir: "migrations",
+	}
+
+	switch dbDriver {
+	case "turso":
+		tursoURL := os.Getenv("TURSO_DATABASE_URL")
+		tursoToken := os.Getenv("TURSO_AUTH_TOKEN")
+
+		if tursoURL == "" || tursoToken == "" {
+			return Config{}, fmt.Errorf("TURSO_DATABASE_URL and TURSO_AUTH_TOKEN must be set when using turso driver")
+		}
+
+		config.TursoURL = tursoURL
+		config.TursoToken = tursoToken
+
+	case "sqlite":
+		dbPath := os.Getenv("DATABASE_PATH")
+		if dbPath == "" {
+			dbPath = "portfolio.db"
+		}
+
+		config.SQLitePath = dbPath
+
+	default:
+		return Config{}, fmt.Errorf("invalid DB_DRIVER: %s (use 'sqlite' or 'turso')", dbDriver)
+	}
+
+	return config, nil
+}
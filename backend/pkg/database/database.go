@@ -22,12 +22,18 @@ type Config struct {
 	TursoURL      string // Turso database URL
 	TursoToken    string // Turso auth token
 	MigrationsDir string
+	LogLevel      logger.LogLevel // GORM log level, defaults to logger.Info
 }
 
 // InitDB initializes the database connection based on the driver type
 func InitDB(config Config) error {
 	var err error
 
+	logLevel := config.LogLevel
+	if logLevel == 0 {
+		logLevel = logger.Info
+	}
+
 	switch config.Driver {
 	case "turso":
 		log.Printf("Connecting to Turso database...")
@@ -49,7 +55,7 @@ func InitDB(config Config) error {
 		DB, err = gorm.Open(sqlite.Dialector{
 			Conn: sqlDB,
 		}, &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Info),
+			Logger: logger.Default.LogMode(logLevel),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to connect to turso database with gorm: %w", err)
@@ -58,7 +64,7 @@ func InitDB(config Config) error {
 	case "sqlite":
 		log.Printf("Connecting to SQLite database: %s", config.SQLitePath)
 		DB, err = gorm.Open(sqlite.Open(config.SQLitePath), &gorm.Config{
-			Logger: logger.Default.LogMode(logger.Info),
+			Logger: logger.Default.LogMode(logLevel),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to connect to sqlite database: %w", err)
@@ -0,0 +1,195 @@
+// Package imaging prepares uploaded images for the web: it applies the EXIF orientation, strips every
+// metadata block by re-encoding the pixels, and renders smaller WebP versions with a blurhash placeholder.
+// PDF documents get the same treatment from a rasterised preview of their first page.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"slices"
+
+	"github.com/buckket/go-blurhash"
+	"github.com/chai2010/webp"
+	"golang.org/x/image/draw"
+)
+
+// MaxPixels bounds the decoded size of an upload, so a small file cannot expand into gigabytes of memory
+const MaxPixels = 40_000_000
+
+const (
+	originalQuality  = 90
+	renditionQuality = 80
+)
+
+var (
+	// ErrUnsupportedImage is returned when the data is not a JPEG, PNG or WebP image
+	ErrUnsupportedImage = errors.New("the file is not a valid JPEG, PNG or WEBP image")
+	// ErrImageTooLarge is returned when the image has more than MaxPixels pixels
+	ErrImageTooLarge = fmt.Errorf("the image exceeds %d megapixels", MaxPixels/1_000_000)
+)
+
+// RenditionSpec describes a resized WebP version of an image
+type RenditionSpec struct {
+	Name     string
+	MaxWidth int
+}
+
+// DefaultRenditions are generated for every uploaded certificate
+var DefaultRenditions = []RenditionSpec{
+	{Name: "thumbnail", MaxWidth: 320},
+	{Name: "medium", MaxWidth: 1024},
+}
+
+// Encoded is an encoded image ready to be stored
+type Encoded struct {
+	Name        string
+	Data        []byte
+	ContentType string
+	Extension   string
+	Width       int
+	Height      int
+}
+
+// Result is the outcome of processing an uploaded image
+type Result struct {
+	// Original is the upload re-encoded in its own format, upright and without metadata
+	Original   Encoded
+	Renditions []Encoded
+	Width      int
+	Height     int
+	Blurhash   string
+}
+
+// Process decodes an uploaded JPEG, PNG or WebP image and produces the sanitized original and the renditions,
+// ordered from the largest to the smallest spec
+func Process(data []byte, specs []RenditionSpec) (*Result, error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+	if config.Width*config.Height > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedImage
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	result, err := process(img, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	if result.Original, err = encodeOriginal(img, format); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// process renders the WebP renditions and the blurhash of a decoded image
+func process(img image.Image, specs []RenditionSpec) (*Result, error) {
+	bounds := img.Bounds()
+	result := &Result{
+		Width:  bounds.Dx(),
+		Height: bounds.Dy(),
+	}
+
+	// Render from the largest spec down, each one resized from the previous one to keep it fast
+	source := img
+	for _, spec := range sortedSpecs(specs) {
+		resized := resize(source, spec.MaxWidth)
+		encoded, err := encodeWebP(spec.Name, resized, renditionQuality)
+		if err != nil {
+			return nil, err
+		}
+		result.Renditions = append(result.Renditions, encoded)
+		source = resized
+	}
+
+	hash, err := blurhash.Encode(4, 3, resize(source, 64))
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute blurhash: %w", err)
+	}
+	result.Blurhash = hash
+
+	return result, nil
+}
+
+// encodeOriginal re-encodes the image in its uploaded format, which drops EXIF, XMP and any other metadata
+func encodeOriginal(img image.Image, format string) (Encoded, error) {
+	var buf bytes.Buffer
+	encoded := Encoded{Name: "original", Width: img.Bounds().Dx(), Height: img.Bounds().Dy()}
+
+	switch format {
+	case "jpeg":
+		if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: originalQuality}); err != nil {
+			return Encoded{}, fmt.Errorf("failed to encode JPEG: %w", err)
+		}
+		encoded.ContentType, encoded.Extension = "image/jpeg", ".jpg"
+	case "png":
+		if err := png.Encode(&buf, img); err != nil {
+			return Encoded{}, fmt.Errorf("failed to encode PNG: %w", err)
+		}
+		encoded.ContentType, encoded.Extension = "image/png", ".png"
+	case "webp":
+		return encodeWebP("original", img, originalQuality)
+	default:
+		return Encoded{}, ErrUnsupportedImage
+	}
+
+	encoded.Data = buf.Bytes()
+	return encoded, nil
+}
+
+// encodeWebP encodes a lossy WebP image
+func encodeWebP(name string, img image.Image, quality float32) (Encoded, error) {
+	var buf bytes.Buffer
+	if err := webp.Encode(&buf, img, &webp.Options{Quality: quality}); err != nil {
+		return Encoded{}, fmt.Errorf("failed to encode WebP: %w", err)
+	}
+
+	return Encoded{
+		Name:        name,
+		Data:        buf.Bytes(),
+		ContentType: "image/webp",
+		Extension:   ".webp",
+		Width:       img.Bounds().Dx(),
+		Height:      img.Bounds().Dy(),
+	}, nil
+}
+
+// resize scales the image down to maxWidth keeping the aspect ratio, smaller images are returned unchanged
+func resize(img image.Image, maxWidth int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxWidth {
+		return img
+	}
+
+	height := bounds.Dy() * maxWidth / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, bounds, draw.Src, nil)
+	return dst
+}
+
+// sortedSpecs returns the specs ordered by decreasing width
+func sortedSpecs(specs []RenditionSpec) []RenditionSpec {
+	ordered := slices.Clone(specs)
+	slices.SortFunc(ordered, func(a, b RenditionSpec) int {
+		return b.MaxWidth - a.MaxWidth
+	})
+	return ordered
+}
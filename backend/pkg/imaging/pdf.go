@@ -0,0 +1,142 @@
+package imaging
+
+import (
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/klippa-app/go-pdfium"
+	"github.com/klippa-app/go-pdfium/requests"
+	"github.com/klippa-app/go-pdfium/webassembly"
+	"github.com/tetratelabs/wazero"
+)
+
+// ErrInvalidPDF is returned when the data cannot be opened as a PDF document
+var ErrInvalidPDF = errors.New("the file is not a valid PDF document")
+
+const (
+	// previewMaxWidth bounds the width of the rasterised first page
+	previewMaxWidth = 1600
+	// previewMaxDPI is the resolution used for pages small enough to stay under previewMaxWidth
+	previewMaxDPI = 150
+	// pdfInstanceTimeout is how long a render waits for a free PDFium instance
+	pdfInstanceTimeout = 30 * time.Second
+)
+
+// pdfRenderer lazily starts PDFium (compiled to WebAssembly, so no system library is needed) on the first PDF,
+// since compiling the module takes a few seconds
+var pdfRenderer struct {
+	once sync.Once
+	pool pdfium.Pool
+	err  error
+}
+
+// pdfPool returns the shared PDFium instance pool. The sandbox gets no filesystem access.
+func pdfPool() (pdfium.Pool, error) {
+	pdfRenderer.once.Do(func() {
+		pdfRenderer.pool, pdfRenderer.err = webassembly.Init(webassembly.Config{
+			MinIdle:  1,
+			MaxIdle:  1,
+			MaxTotal: 2,
+			FSConfig: wazero.NewFSConfig(),
+		})
+	})
+	return pdfRenderer.pool, pdfRenderer.err
+}
+
+// ProcessPDF rasterises the first page of a PDF document and renders it like an uploaded image: a "preview"
+// WebP of the whole page followed by the given renditions. The original document is stored unchanged.
+func ProcessPDF(data []byte, specs []RenditionSpec) (*Result, error) {
+	page, err := renderFirstPage(data)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := process(page, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := encodeWebP("preview", page, renditionQuality)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Original = Encoded{
+		Name:        "original",
+		Data:        data,
+		ContentType: "application/pdf",
+		Extension:   ".pdf",
+		Width:       result.Width,
+		Height:      result.Height,
+	}
+	result.Renditions = append([]Encoded{preview}, result.Renditions...)
+	return result, nil
+}
+
+// renderFirstPage opens the document in PDFium and draws its first page, at most previewMaxWidth pixels wide
+func renderFirstPage(data []byte) (image.Image, error) {
+	pool, err := pdfPool()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start PDF renderer: %w", err)
+	}
+
+	instance, err := pool.GetInstance(pdfInstanceTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get PDF renderer: %w", err)
+	}
+	defer instance.Close()
+
+	doc, err := instance.OpenDocument(&requests.OpenDocument{File: &data})
+	if err != nil {
+		return nil, ErrInvalidPDF
+	}
+	defer instance.FPDF_CloseDocument(&requests.FPDF_CloseDocument{Document: doc.Document})
+
+	pages, err := instance.FPDF_GetPageCount(&requests.FPDF_GetPageCount{Document: doc.Document})
+	if err != nil || pages.PageCount == 0 {
+		return nil, ErrInvalidPDF
+	}
+
+	firstPage := requests.Page{ByIndex: &requests.PageByIndex{Document: doc.Document, Index: 0}}
+	size, err := instance.GetPageSize(&requests.GetPageSize{Page: firstPage})
+	if err != nil || size.Width <= 0 || size.Height <= 0 {
+		return nil, ErrInvalidPDF
+	}
+
+	// Page sizes are in points (1/72 inch)
+	dpi := previewMaxDPI
+	if size.Width*float64(dpi)/72 > previewMaxWidth {
+		dpi = int(previewMaxWidth * 72 / size.Width)
+	}
+	if dpi < 1 {
+		dpi = 1
+	}
+
+	// PDFium allocates the whole bitmap, so an overly tall page is refused before it is rendered
+	if pagePixels(size.Width, size.Height, dpi) > MaxPixels {
+		return nil, ErrImageTooLarge
+	}
+
+	rendered, err := instance.RenderPageInDPI(&requests.RenderPageInDPI{Page: firstPage, DPI: dpi})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render PDF page: %w", err)
+	}
+	defer rendered.Cleanup()
+
+	// The rendered bitmap is released by Cleanup, keep a copy
+	bounds := rendered.Result.Image.Bounds()
+	page := image.NewRGBA(bounds)
+	draw.Draw(page, bounds, rendered.Result.Image, bounds.Min, draw.Src)
+	return page, nil
+}
+
+// pagePixels returns the number of pixels of a page of the given size in points rendered at dpi, rounded up
+func pagePixels(width, height float64, dpi int) float64 {
+	scale := float64(dpi) / 72
+	return math.Ceil(width*scale) * math.Ceil(height*scale)
+}
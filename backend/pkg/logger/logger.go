@@ -218,31 +218,31 @@ func (cl *ContextLogger) formatFields() string {
 // Debug logs a debug message with context
 func (cl *ContextLogger) Debug(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...) + cl.formatFields()
-	cl.logger.Debug(message)
+	cl.logger.Debug("%s", message)
 }
 
 // Info logs an info message with context
 func (cl *ContextLogger) Info(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...) + cl.formatFields()
-	cl.logger.Info(message)
+	cl.logger.Info("%s", message)
 }
 
 // Warn logs a warning message with context
 func (cl *ContextLogger) Warn(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...) + cl.formatFields()
-	cl.logger.Warn(message)
+	cl.logger.Warn("%s", message)
 }
 
 // Error logs an error message with context
 func (cl *ContextLogger) Error(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...) + cl.formatFields()
-	cl.logger.Error(message)
+	cl.logger.Error("%s", message)
 }
 
 // Fatal logs a fatal message with context and exits
 func (cl *ContextLogger) Fatal(format string, args ...interface{}) {
 	message := fmt.Sprintf(format, args...) + cl.formatFields()
-	cl.logger.Fatal(message)
+	cl.logger.Fatal("%s", message)
 }
 
 // Package-level convenience functions that use the default logger
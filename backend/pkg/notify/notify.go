@@ -0,0 +1,108 @@
+// Package notify delivers notifications to the site admin by email (any SMTP server, including local stand-ins
+// such as MailHog or Mailpit) and/or by posting them to a webhook.
+package notify
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// Notification is a message for the admin. Subject and Body are the plain-text email; Event and Data are sent
+// as JSON to webhooks so they can be processed without parsing the text.
+type Notification struct {
+	Event   string
+	Subject string
+	Body    string
+	Data    interface{}
+}
+
+// Sender delivers notifications
+type Sender interface {
+	Send(ctx context.Context, notification Notification) error
+}
+
+// Config selects the senders to use, a sender is enabled when its destination is set
+type Config struct {
+	// SMTP settings, Username and Password are optional for servers that accept unauthenticated mail
+	SMTPHost     string
+	SMTPPort     int
+	SMTPUsername string
+	SMTPPassword string
+	SMTPFrom     string
+	EmailTo      []string
+
+	// WebhookURL receives the notifications as JSON, signed with WebhookSecret when it is set
+	WebhookURL    string
+	WebhookSecret string
+}
+
+// ConfigFromEnv builds a Config from the SMTP_* and NOTIFY_* environment variables.
+// NOTIFY_EMAIL_TO is a comma-separated list of recipients; SMTP_PORT defaults to 587.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		SMTPHost:      os.Getenv("SMTP_HOST"),
+		SMTPPort:      587,
+		SMTPUsername:  os.Getenv("SMTP_USERNAME"),
+		SMTPPassword:  os.Getenv("SMTP_PASSWORD"),
+		SMTPFrom:      os.Getenv("SMTP_FROM"),
+		WebhookURL:    os.Getenv("NOTIFY_WEBHOOK_URL"),
+		WebhookSecret: os.Getenv("NOTIFY_WEBHOOK_SECRET"),
+	}
+
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		value, err := strconv.Atoi(port)
+		if err != nil || value <= 0 {
+			return Config{}, fmt.Errorf("invalid SMTP_PORT: %s", port)
+		}
+		config.SMTPPort = value
+	}
+
+	for _, address := range strings.Split(os.Getenv("NOTIFY_EMAIL_TO"), ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			config.EmailTo = append(config.EmailTo, address)
+		}
+	}
+
+	if len(config.EmailTo) > 0 && (config.SMTPHost == "" || config.SMTPFrom == "") {
+		return Config{}, errors.New("SMTP_HOST and SMTP_FROM must be set when NOTIFY_EMAIL_TO is set")
+	}
+
+	return config, nil
+}
+
+// New creates a Sender delivering to every destination of the config, or nil when none is configured
+func New(config Config) Sender {
+	var senders []Sender
+	if len(config.EmailTo) > 0 {
+		senders = append(senders, NewSMTPSender(config))
+	}
+	if config.WebhookURL != "" {
+		senders = append(senders, NewWebhookSender(config.WebhookURL, config.WebhookSecret))
+	}
+
+	switch len(senders) {
+	case 0:
+		return nil
+	case 1:
+		return senders[0]
+	default:
+		return multiSender(senders)
+	}
+}
+
+// multiSender delivers to several senders, a failing one does not prevent the others from being tried
+type multiSender []Sender
+
+func (m multiSender) Send(ctx context.Context, notification Notification) error {
+	var errs []error
+	for _, sender := range m {
+		if err := sender.Send(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
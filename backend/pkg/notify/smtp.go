@@ -0,0 +1,76 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SMTPSender emails notifications. STARTTLS is used when the server offers it, and the credentials, when set,
+// are only sent over TLS or to a local server.
+type SMTPSender struct {
+	addr string
+	host string
+	auth smtp.Auth
+	from string
+	to   []string
+}
+
+// NewSMTPSender creates an SMTPSender from the SMTP settings of the config
+func NewSMTPSender(config Config) *SMTPSender {
+	sender := &SMTPSender{
+		addr: net.JoinHostPort(config.SMTPHost, strconv.Itoa(config.SMTPPort)),
+		host: config.SMTPHost,
+		from: config.SMTPFrom,
+		to:   config.EmailTo,
+	}
+	if config.SMTPUsername != "" {
+		sender.auth = smtp.PlainAuth("", config.SMTPUsername, config.SMTPPassword, config.SMTPHost)
+	}
+	return sender
+}
+
+// Send emails the subject and body of the notification to every recipient
+func (s *SMTPSender) Send(ctx context.Context, notification Notification) error {
+	message := s.message(notification)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- smtp.SendMail(s.addr, s.auth, s.from, s.to, message)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to send email via %s: %w", s.addr, err)
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to send email via %s: %w", s.addr, ctx.Err())
+	}
+}
+
+// message builds a plain-text RFC 5322 message with CRLF line endings
+func (s *SMTPSender) message(notification Notification) []byte {
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: %s\r\n", s.from)
+	fmt.Fprintf(&b, "To: %s\r\n", strings.Join(s.to, ", "))
+	fmt.Fprintf(&b, "Subject: %s\r\n", sanitizeHeader(notification.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(notification.Body, "\r\n", "\n"), "\n", "\r\n"))
+	b.WriteString("\r\n")
+	return b.Bytes()
+}
+
+// sanitizeHeader keeps a header value on a single line, so the subject cannot inject other headers
+func sanitizeHeader(value string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(value)
+}
@@ -0,0 +1,183 @@
+package notify
+
+import (
+	"bufio"
+	"context"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// smtpMessage is a message received by the SMTP stand-in
+type smtpMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+// startSMTPServer starts a minimal SMTP server on a free local port, without STARTTLS or AUTH, and returns its
+// port and the channel receiving the delivered messages
+func startSMTPServer(t *testing.T) (int, <-chan smtpMessage) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	messages := make(chan smtpMessage, 1)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveSMTP(conn, messages)
+		}
+	}()
+
+	return listener.Addr().(*net.TCPAddr).Port, messages
+}
+
+// serveSMTP speaks just enough SMTP for net/smtp.SendMail
+func serveSMTP(conn net.Conn, messages chan<- smtpMessage) {
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) {
+		conn.Write([]byte(line + "\r\n"))
+	}
+
+	var message smtpMessage
+	reply("220 localhost ESMTP")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		line = strings.TrimRight(line, "\r\n")
+		command := strings.ToUpper(line)
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"), strings.HasPrefix(command, "HELO"):
+			reply("250 localhost")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			message.from = strings.Trim(line[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			message.to = append(message.to, strings.Trim(line[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			message.data = data.String()
+			messages <- message
+			reply("250 OK")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+func TestSMTPSenderSend(t *testing.T) {
+	port, messages := startSMTPServer(t)
+
+	sender := NewSMTPSender(Config{
+		SMTPHost: "127.0.0.1",
+		SMTPPort: port,
+		SMTPFrom: "portfolio@example.com",
+		EmailTo:  []string{"admin@example.com", "backup@example.com"},
+	})
+
+	err := sender.Send(context.Background(), Notification{
+		Subject: "Certificate expiring\r\nBcc: attacker@example.com",
+		Body:    "First line\nSecond line",
+	})
+	if err != nil {
+		t.Fatalf("Send returned an error: %v", err)
+	}
+
+	var message smtpMessage
+	select {
+	case message = <-messages:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the SMTP server received no message")
+	}
+
+	if message.from != "portfolio@example.com" {
+		t.Errorf("envelope sender = %q, want portfolio@example.com", message.from)
+	}
+	if got := strings.Join(message.to, ","); got != "admin@example.com,backup@example.com" {
+		t.Errorf("envelope recipients = %q, want both recipients", got)
+	}
+
+	headers, body, found := strings.Cut(message.data, "\r\n\r\n")
+	if !found {
+		t.Fatalf("message has no header/body separator: %q", message.data)
+	}
+	headers += "\r\n"
+
+	for _, want := range []string{
+		"From: portfolio@example.com",
+		"To: admin@example.com, backup@example.com",
+		"Subject: Certificate expiring  Bcc: attacker@example.com",
+		"MIME-Version: 1.0",
+		"Content-Type: text/plain; charset=UTF-8",
+	} {
+		if !strings.Contains(headers, want+"\r\n") {
+			t.Errorf("headers do not contain %q:\n%s", want, headers)
+		}
+	}
+	if strings.Contains(headers, "\r\nBcc:") {
+		t.Errorf("the subject injected a header:\n%s", headers)
+	}
+	if !strings.Contains(headers, "\r\nDate: ") {
+		t.Errorf("headers have no Date:\n%s", headers)
+	}
+
+	if body != "First line\r\nSecond line\r\n" {
+		t.Errorf("body = %q, want CRLF line endings", body)
+	}
+}
+
+func TestSMTPSenderSendUnreachable(t *testing.T) {
+	// A port nothing listens on once the listener is closed
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	port := listener.Addr().(*net.TCPAddr).Port
+	listener.Close()
+
+	sender := NewSMTPSender(Config{
+		SMTPHost: "127.0.0.1",
+		SMTPPort: port,
+		SMTPFrom: "portfolio@example.com",
+		EmailTo:  []string{"admin@example.com"},
+	})
+
+	err = sender.Send(context.Background(), Notification{Subject: "Subject", Body: "Body"})
+	if err == nil {
+		t.Fatal("Send to a closed port returned no error")
+	}
+	if !strings.Contains(err.Error(), net.JoinHostPort("127.0.0.1", strconv.Itoa(port))) {
+		t.Errorf("error %q does not name the server", err)
+	}
+}
@@ -0,0 +1,79 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// SignatureHeader carries the hex HMAC-SHA256 of the webhook body, keyed with the webhook secret
+const SignatureHeader = "X-Portfolio-Signature"
+
+// WebhookSender posts notifications as JSON to a URL
+type WebhookSender struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// webhookPayload is the JSON body posted to the webhook
+type webhookPayload struct {
+	Event   string      `json:"event"`
+	Subject string      `json:"subject"`
+	Body    string      `json:"body"`
+	Data    interface{} `json:"data,omitempty"`
+	SentAt  time.Time   `json:"sent_at"`
+}
+
+// NewWebhookSender creates a WebhookSender, requests are signed when secret is not empty
+func NewWebhookSender(url, secret string) *WebhookSender {
+	return &WebhookSender{
+		url:    url,
+		secret: secret,
+		client: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Send posts the notification, any response other than 2xx is an error
+func (w *WebhookSender) Send(ctx context.Context, notification Notification) error {
+	body, err := json.Marshal(webhookPayload{
+		Event:   notification.Event,
+		Subject: notification.Subject,
+		Body:    notification.Body,
+		Data:    notification.Data,
+		SentAt:  time.Now().UTC(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != "" {
+		mac := hmac.New(sha256.New, []byte(w.secret))
+		mac.Write(body)
+		req.Header.Set(SignatureHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<20))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// LocalBlob keeps the objects as files in a directory served by the API itself
+type LocalBlob struct {
+	dir       string
+	publicURL string
+}
+
+// NewLocalBlob creates a LocalBlob rooted at dir, creating the directory if it does not exist
+func NewLocalBlob(dir, publicURL string) (*LocalBlob, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create storage directory: %w", err)
+	}
+	return &LocalBlob{dir: dir, publicURL: strings.TrimSuffix(publicURL, "/")}, nil
+}
+
+// Put writes the object to a temporary file and renames it, so readers never see a partial file
+func (b *LocalBlob) Put(_ context.Context, key string, r io.Reader, _ int64, _ string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(b.dir, ".upload-*")
+	if err != nil {
+		return fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := io.Copy(tmp, r); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to copy uploaded file: %w", err)
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to set file permissions: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write destination file: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to move uploaded file: %w", err)
+	}
+	return nil
+}
+
+// Get opens the file stored under key
+func (b *LocalBlob) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return file, nil
+}
+
+// Delete removes the file stored under key
+func (b *LocalBlob) Delete(_ context.Context, key string) error {
+	path, err := b.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// URL returns the URL the API serves the file from
+func (b *LocalBlob) URL(key string) string {
+	return b.publicURL + "/" + key
+}
+
+// Stat returns the size and modification time of the file stored under key.
+// The content type is derived from the extension.
+func (b *LocalBlob) Stat(_ context.Context, key string) (*ObjectInfo, error) {
+	path, err := b.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size(),
+		ContentType:  mime.TypeByExtension(filepath.Ext(key)),
+		LastModified: info.ModTime(),
+	}, nil
+}
+
+// List returns the files of the storage directory, leaving out subdirectories and the hidden temporary files
+// of uploads in progress
+func (b *LocalBlob) List(_ context.Context) ([]ObjectInfo, error) {
+	entries, err := os.ReadDir(b.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read storage directory: %w", err)
+	}
+
+	objects := make([]ObjectInfo, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+
+		objects = append(objects, ObjectInfo{
+			Key:          entry.Name(),
+			Size:         info.Size(),
+			ContentType:  mime.TypeByExtension(filepath.Ext(entry.Name())),
+			LastModified: info.ModTime(),
+		})
+	}
+	return objects, nil
+}
+
+// path maps a key to a file inside the storage directory, rejecting keys that would escape it
+func (b *LocalBlob) path(key string) (string, error) {
+	if key == "" || key != filepath.Base(key) || strings.HasPrefix(key, ".") {
+		return "", fmt.Errorf("invalid storage key: %q", key)
+	}
+	return filepath.Join(b.dir, key), nil
+}
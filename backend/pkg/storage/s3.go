@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Blob keeps the objects in an S3-compatible bucket. The bucket (or the prefix) must allow public reads,
+// or S3_PUBLIC_URL must point to a CDN in front of it, for the returned URLs to be reachable.
+type S3Blob struct {
+	client    *minio.Client
+	bucket    string
+	prefix    string
+	publicURL string
+}
+
+// NewS3Blob connects to the bucket described by the config and checks that it exists
+func NewS3Blob(config Config) (*S3Blob, error) {
+	client, err := minio.New(config.S3Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(config.S3AccessKey, config.S3SecretKey, ""),
+		Secure: config.S3UseSSL,
+		Region: config.S3Region,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 client: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	exists, err := client.BucketExists(ctx, config.S3Bucket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach S3 bucket %s: %w", config.S3Bucket, err)
+	}
+	if !exists {
+		return nil, fmt.Errorf("S3 bucket %s does not exist", config.S3Bucket)
+	}
+
+	return &S3Blob{
+		client:    client,
+		bucket:    config.S3Bucket,
+		prefix:    config.S3Prefix,
+		publicURL: config.PublicURL,
+	}, nil
+}
+
+// Put uploads the object
+func (b *S3Blob) Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error {
+	_, err := b.client.PutObject(ctx, b.bucket, b.prefix+key, r, size, minio.PutObjectOptions{ContentType: contentType})
+	if err != nil {
+		return fmt.Errorf("failed to upload object: %w", err)
+	}
+	return nil
+}
+
+// Get downloads the object
+func (b *S3Blob) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := b.client.GetObject(ctx, b.bucket, b.prefix+key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, mapS3Error(err)
+	}
+
+	// GetObject is lazy, stat it so a missing key fails here instead of on the first read
+	if _, err := object.Stat(); err != nil {
+		object.Close()
+		return nil, mapS3Error(err)
+	}
+	return object, nil
+}
+
+// Delete removes the object
+func (b *S3Blob) Delete(ctx context.Context, key string) error {
+	if err := b.client.RemoveObject(ctx, b.bucket, b.prefix+key, minio.RemoveObjectOptions{}); err != nil {
+		return mapS3Error(err)
+	}
+	return nil
+}
+
+// URL returns the public URL of the object
+func (b *S3Blob) URL(key string) string {
+	return b.publicURL + "/" + (&url.URL{Path: b.prefix + key}).EscapedPath()
+}
+
+// Stat returns the metadata of the object
+func (b *S3Blob) Stat(ctx context.Context, key string) (*ObjectInfo, error) {
+	info, err := b.client.StatObject(ctx, b.bucket, b.prefix+key, minio.StatObjectOptions{})
+	if err != nil {
+		return nil, mapS3Error(err)
+	}
+
+	return &ObjectInfo{
+		Key:          key,
+		Size:         info.Size,
+		ContentType:  info.ContentType,
+		LastModified: info.LastModified,
+	}, nil
+}
+
+// List returns the objects under the prefix, leaving out the ones in nested "directories" since keys are flat
+func (b *S3Blob) List(ctx context.Context) ([]ObjectInfo, error) {
+	var objects []ObjectInfo
+	for object := range b.client.ListObjects(ctx, b.bucket, minio.ListObjectsOptions{Prefix: b.prefix, Recursive: true}) {
+		if object.Err != nil {
+			return nil, fmt.Errorf("failed to list objects: %w", object.Err)
+		}
+
+		key := strings.TrimPrefix(object.Key, b.prefix)
+		if key == "" || strings.Contains(key, "/") {
+			continue
+		}
+
+		objects = append(objects, ObjectInfo{
+			Key:          key,
+			Size:         object.Size,
+			ContentType:  object.ContentType,
+			LastModified: object.LastModified,
+		})
+	}
+	return objects, nil
+}
+
+// mapS3Error turns the "no such key" responses into ErrNotFound
+func mapS3Error(err error) error {
+	if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+		return ErrNotFound
+	}
+	return err
+}
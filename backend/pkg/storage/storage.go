@@ -0,0 +1,126 @@
+// Package storage abstracts where uploaded files live, so the API can keep them on the local disk during
+// development and in an S3-compatible bucket (AWS S3, DigitalOcean Spaces, MinIO) in production.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned when a key does not exist in the store
+var ErrNotFound = errors.New("object not found")
+
+// Blob stores files under flat keys such as "1700000000-uuid.png"
+type Blob interface {
+	// Put writes the content of r under key, replacing any existing object
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	// Get opens the object stored under key; the caller must close it
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes the object stored under key, deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+	// URL returns the public URL the object is served from
+	URL(key string) string
+	// Stat returns the metadata of the object stored under key
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	// List returns the metadata of every object in the store
+	List(ctx context.Context) ([]ObjectInfo, error)
+}
+
+// ObjectInfo describes a stored object
+type ObjectInfo struct {
+	Key          string
+	Size         int64
+	ContentType  string
+	LastModified time.Time
+}
+
+// Config selects and configures a Blob implementation
+type Config struct {
+	Driver string
+	// PublicURL is the base URL objects are served from, without a trailing slash
+	PublicURL string
+
+	// LocalDir is the directory used by the local driver
+	LocalDir string
+
+	// S3 settings, Prefix is prepended to every key so several stores can share a bucket
+	S3Endpoint  string
+	S3Region    string
+	S3Bucket    string
+	S3AccessKey string
+	S3SecretKey string
+	S3UseSSL    bool
+	S3Prefix    string
+}
+
+// ConfigFromEnv builds a Config for the files kept in localDir and served under publicPath (e.g. "/certifications").
+// STORAGE_DRIVER selects "local" (default) or "s3". Local files are served by the API under PUBLIC_BASE_URL
+// (http://localhost:SERVER_PORT when unset); with s3 the files are stored under publicPath inside the bucket.
+func ConfigFromEnv(localDir, publicPath string) (Config, error) {
+	driver := os.Getenv("STORAGE_DRIVER")
+	if driver == "" {
+		driver = "local"
+	}
+
+	config := Config{Driver: driver}
+
+	switch driver {
+	case "local":
+		baseURL := strings.TrimSuffix(os.Getenv("PUBLIC_BASE_URL"), "/")
+		if baseURL == "" {
+			port := os.Getenv("SERVER_PORT")
+			if port == "" {
+				port = "8080"
+			}
+			baseURL = "http://localhost:" + port
+		}
+
+		config.LocalDir = localDir
+		config.PublicURL = baseURL + publicPath
+
+	case "s3":
+		config.S3Endpoint = os.Getenv("S3_ENDPOINT")
+		config.S3Region = os.Getenv("S3_REGION")
+		config.S3Bucket = os.Getenv("S3_BUCKET")
+		config.S3AccessKey = os.Getenv("S3_ACCESS_KEY_ID")
+		config.S3SecretKey = os.Getenv("S3_SECRET_ACCESS_KEY")
+		config.S3UseSSL = os.Getenv("S3_USE_SSL") != "false"
+		config.S3Prefix = strings.Trim(publicPath, "/") + "/"
+
+		if config.S3Endpoint == "" || config.S3Bucket == "" || config.S3AccessKey == "" || config.S3SecretKey == "" {
+			return Config{}, fmt.Errorf("S3_ENDPOINT, S3_BUCKET, S3_ACCESS_KEY_ID and S3_SECRET_ACCESS_KEY must be set when using s3 driver")
+		}
+
+		publicURL := strings.TrimSuffix(os.Getenv("S3_PUBLIC_URL"), "/")
+		if publicURL == "" {
+			scheme := "https"
+			if !config.S3UseSSL {
+				scheme = "http"
+			}
+			publicURL = fmt.Sprintf("%s://%s/%s", scheme, config.S3Endpoint, config.S3Bucket)
+		}
+		config.PublicURL = publicURL
+
+	default:
+		return Config{}, fmt.Errorf("invalid STORAGE_DRIVER: %s (use 'local' or 's3')", driver)
+	}
+
+	return config, nil
+}
+
+// New creates the Blob selected by the config
+func New(config Config) (Blob, error) {
+	switch config.Driver {
+	case "local":
+		return NewLocalBlob(config.LocalDir, config.PublicURL)
+	case "s3":
+		return NewS3Blob(config)
+	default:
+		return nil, fmt.Errorf("unknown storage driver: %s", config.Driver)
+	}
+}
@@ -0,0 +1,66 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/JuanPabloCano/personal-portfolio/backend/pkg/constants"
+	"github.com/gin-gonic/gin"
+)
+
+// SetSessionCookie sets the session cookie until the session expires, with the security settings of the
+// environment, along with the CSRF cookie of the session
+func SetSessionCookie(c *gin.Context, token string, expiresAt time.Time) {
+	maxAge := int(time.Until(expiresAt).Seconds())
+	setCookie(c, constants.GetSessionCookieName(), token, maxAge, true)
+	setCookie(c, constants.CSRFCookieName, CSRFToken(token), maxAge, false)
+}
+
+// ClearSessionCookie clears the session cookie and the CSRF cookie
+func ClearSessionCookie(c *gin.Context) {
+	setCookie(c, constants.GetSessionCookieName(), "", -1, true)
+	setCookie(c, constants.CSRFCookieName, "", -1, false)
+}
+
+// CSRFToken returns the CSRF token of a session token. It is derived from the session token, which never leaves
+// its HttpOnly cookie, so it cannot be guessed and nothing needs to be stored.
+func CSRFToken(sessionToken string) string {
+	mac := hmac.New(sha256.New, []byte(sessionToken))
+	mac.Write([]byte("csrf"))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidCSRFToken reports whether token is the CSRF token of the session token, in constant time
+func ValidCSRFToken(sessionToken, token string) bool {
+	return token != "" && hmac.Equal([]byte(token), []byte(CSRFToken(sessionToken)))
+}
+
+// setCookie sets a cookie of the session with the security settings of the environment. The CSRF cookie is not
+// HttpOnly, the frontend reads it to send the token back in a header.
+func setCookie(c *gin.Context, name, value string, maxAge int, httpOnly bool) {
+	secure := os.Getenv("COOKIE_SECURE") == "true"
+	domain := os.Getenv("COOKIE_DOMAIN")
+	sameSite := http.SameSiteLaxMode
+
+	switch os.Getenv("COOKIE_SAME_SITE") {
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		sameSite = http.SameSiteNoneMode
+	}
+
+	c.SetSameSite(sameSite)
+	c.SetCookie(
+		name,
+		value,
+		maxAge,
+		"/",
+		domain,
+		secure,
+		httpOnly,
+	)
+}
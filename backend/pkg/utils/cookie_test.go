@@ -0,0 +1,39 @@
+package utils
+
+import "testing"
+
+func TestValidCSRFToken(t *testing.T) {
+	const sessionToken = "session-token"
+	valid := CSRFToken(sessionToken)
+
+	tests := []struct {
+		name         string
+		sessionToken string
+		token        string
+		want         bool
+	}{
+		{"token of the session", sessionToken, valid, true},
+		{"empty token", sessionToken, "", false},
+		{"token of another session", sessionToken, CSRFToken("other-session-token"), false},
+		{"truncated token", sessionToken, valid[:len(valid)-1], false},
+		{"session token itself", sessionToken, sessionToken, false},
+		{"empty session and token", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ValidCSRFToken(tt.sessionToken, tt.token); got != tt.want {
+				t.Errorf("ValidCSRFToken(%q, %q) = %t, want %t", tt.sessionToken, tt.token, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCSRFTokenIsStable(t *testing.T) {
+	if CSRFToken("session-token") != CSRFToken("session-token") {
+		t.Error("CSRFToken returned different tokens for the same session")
+	}
+	if CSRFToken("session-token") == CSRFToken("other-session-token") {
+		t.Error("CSRFToken returned the same token for different sessions")
+	}
+}
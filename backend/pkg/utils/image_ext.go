@@ -7,4 +7,18 @@ var (
 		".png":  true,
 		".webp": true,
 	}
+
+	// AllowedDocumentExtensions are the non-image files accepted as certificates
+	AllowedDocumentExtensions = map[string]bool{
+		".pdf": true,
+	}
+
+	// ExtensionContentTypes maps every accepted extension to the content type sniffed from the file bytes
+	ExtensionContentTypes = map[string]string{
+		".jpg":  "image/jpeg",
+		".jpeg": "image/jpeg",
+		".png":  "image/png",
+		".webp": "image/webp",
+		".pdf":  "application/pdf",
+	}
 )
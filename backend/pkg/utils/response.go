@@ -3,6 +3,7 @@ package utils
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -15,6 +16,18 @@ type ErrorResponse struct {
 type SuccessResponse struct {
 	Data    interface{} `json:"data"`
 	Message string      `json:"message,omitempty"`
+	Meta    *Pagination `json:"meta,omitempty"`
+}
+
+// Pagination describes the page returned by a list endpoint and links to its neighbours.
+// A Limit of zero means the whole collection was returned in a single page.
+type Pagination struct {
+	Page       int    `json:"page"`
+	Limit      int    `json:"limit"`
+	Total      int64  `json:"total"`
+	TotalPages int    `json:"total_pages"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
 }
 
 // RespondWithError sends an error response to the client with the provided status code, message, and optional error details.
@@ -43,3 +56,51 @@ func RespondWithSuccess(c *gin.Context, statusCode int, data interface{}, messag
 
 	c.JSON(statusCode, response)
 }
+
+// RespondWithPage sends a JSON success response for a list endpoint including its pagination metadata.
+func RespondWithPage(c *gin.Context, data interface{}, pagination *Pagination) {
+	c.JSON(http.StatusOK, SuccessResponse{
+		Data: data,
+		Meta: pagination,
+	})
+}
+
+// NewPagination builds the pagination metadata for the current request. The next and prev links keep
+// every query parameter of the request (filters, sort, limit) and only replace the page number.
+func NewPagination(c *gin.Context, page, limit int, total int64) *Pagination {
+	if page < 1 {
+		page = 1
+	}
+
+	pagination := &Pagination{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: 1,
+	}
+
+	if limit <= 0 {
+		return pagination
+	}
+
+	pagination.TotalPages = int((total + int64(limit) - 1) / int64(limit))
+	if pagination.TotalPages == 0 {
+		pagination.TotalPages = 1
+	}
+
+	if page < pagination.TotalPages {
+		pagination.Next = pageLink(c, page+1)
+	}
+	if page > 1 {
+		pagination.Prev = pageLink(c, min(page-1, pagination.TotalPages))
+	}
+
+	return pagination
+}
+
+// pageLink returns the current request path with the page query parameter replaced.
+func pageLink(c *gin.Context, page int) string {
+	query := c.Request.URL.Query()
+	query.Set("page", strconv.Itoa(page))
+	return fmt.Sprintf("%s?%s", c.Request.URL.Path, query.Encode())
+}
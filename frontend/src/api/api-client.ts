@@ -1,6 +1,12 @@
 import { PORTFOLIO_BACKEND_URL } from "astro:env/server";
-import type { AuthResponse, LoginRequest, User } from "@/types/auth";
+import type {
+  AuthResponse,
+  LoginRequest,
+  User,
+  VerifyLoginRequest,
+} from "@/types/auth";
 import { ApiError } from "@/types/exceptions.ts";
+import { CSRF } from "@/utils/constants";
 
 export class ApiClient {
   private static instance: ApiClient;
@@ -51,17 +57,39 @@ export class ApiClient {
 
   /**
    * Login with email and password.
-   * The backend will set an HttpOnly cookie with the session ID.
-   * Returns both the auth response and the Set-Cookie header for SSR forwarding.
+   * The backend will set an HttpOnly cookie with the session token and a cookie with its CSRF token.
+   * Returns both the auth response and the Set-Cookie headers for SSR forwarding.
    */
   async login(
     credentials: LoginRequest
-  ): Promise<{ data: AuthResponse; setCookie: string | null }> {
-    const url = `${PORTFOLIO_BACKEND_URL}/auth/login`;
+  ): Promise<{ data: AuthResponse; setCookies: string[] }> {
+    return this.authenticate("auth/login", credentials);
+  }
+
+  /**
+   * Completes a login with two-factor authentication, using the challenge returned by login
+   * and a code from the authenticator app or a recovery code.
+   * Returns both the auth response and the Set-Cookie headers for SSR forwarding.
+   */
+  async verifyLogin(
+    verification: VerifyLoginRequest
+  ): Promise<{ data: AuthResponse; setCookies: string[] }> {
+    return this.authenticate("auth/login/verify", verification);
+  }
+
+  /**
+   * Posts a login step and returns the auth response with the Set-Cookie headers, none when the
+   * login waits for the second step.
+   */
+  private async authenticate(
+    endpoint: string,
+    body: LoginRequest | VerifyLoginRequest
+  ): Promise<{ data: AuthResponse; setCookies: string[] }> {
+    const url = `${PORTFOLIO_BACKEND_URL}/${endpoint}`;
 
     const response = await fetch(url, {
       method: "POST",
-      body: JSON.stringify(credentials),
+      body: JSON.stringify(body),
       credentials: "include",
       headers: {
         "Content-Type": "application/json",
@@ -74,9 +102,10 @@ export class ApiClient {
     }
 
     const result = await response.json();
-    const setCookie = response.headers.get("set-cookie");
+    // Each cookie has its own header, they cannot be joined into one
+    const setCookies = response.headers.getSetCookie();
 
-    return { data: result.data, setCookie };
+    return { data: result.data, setCookies };
   }
 
   /**
@@ -96,27 +125,28 @@ export class ApiClient {
    * Logout the current user.
    * The backend will clear the session cookie.
    * @param cookie - Optional cookie header for SSR requests
+   * @param csrfToken - CSRF token of the session, the backend refuses the logout without it
    */
-  async logout(cookie?: string): Promise<void> {
-    const headers: HeadersInit = {};
-    if (cookie) {
-      headers["Cookie"] = cookie;
-    }
+  async logout(cookie?: string, csrfToken?: string): Promise<void> {
     await this.request<{ message: string }>("auth/logout", {
       method: "POST",
-      headers,
+      headers: this.buildHeaders(cookie, csrfToken),
     });
   }
 
   /**
-   * Builds headers object with optional cookie for SSR requests.
+   * Builds headers object with optional cookie and CSRF token for SSR requests.
    * @param cookie - Optional cookie header for SSR requests
+   * @param csrfToken - Optional CSRF token sent by the browser, required by write requests
    */
-  private buildHeaders(cookie?: string): HeadersInit {
+  private buildHeaders(cookie?: string, csrfToken?: string): HeadersInit {
     const headers: HeadersInit = {};
     if (cookie) {
       headers["Cookie"] = cookie;
     }
+    if (csrfToken) {
+      headers[CSRF.HEADER] = csrfToken;
+    }
     return headers;
   }
 
@@ -137,13 +167,19 @@ export class ApiClient {
    * @param {string} endpoint - The endpoint to which the POST request is sent.
    * @param {unknown} data - The data to include in the body of the POST request.
    * @param {string} [cookie] - Optional cookie header for SSR requests.
+   * @param {string} [csrfToken] - CSRF token sent by the browser.
    * @return {Promise<T>} A promise that resolves to the response of the request as the specified type.
    */
-  async post<T>(endpoint: string, data: unknown, cookie?: string): Promise<T> {
+  async post<T>(
+    endpoint: string,
+    data: unknown,
+    cookie?: string,
+    csrfToken?: string
+  ): Promise<T> {
     return this.request<T>(endpoint, {
       method: "POST",
       body: JSON.stringify(data),
-      headers: this.buildHeaders(cookie),
+      headers: this.buildHeaders(cookie, csrfToken),
     });
   }
 
@@ -153,13 +189,19 @@ export class ApiClient {
    * @param {string} endpoint The API endpoint to which the PATCH request will be sent.
    * @param {unknown} data The data to be sent in the body of the PATCH request.
    * @param {string} [cookie] - Optional cookie header for SSR requests.
+   * @param {string} [csrfToken] - CSRF token sent by the browser.
    * @return {Promise<T>} A promise that resolves to the server's response data of type T.
    */
-  async patch<T>(endpoint: string, data: unknown, cookie?: string): Promise<T> {
+  async patch<T>(
+    endpoint: string,
+    data: unknown,
+    cookie?: string,
+    csrfToken?: string
+  ): Promise<T> {
     return this.request<T>(endpoint, {
       method: "PATCH",
       body: JSON.stringify(data),
-      headers: this.buildHeaders(cookie),
+      headers: this.buildHeaders(cookie, csrfToken),
     });
   }
 
@@ -168,12 +210,17 @@ export class ApiClient {
    *
    * @param {string} endpoint - The API endpoint to send the DELETE request to.
    * @param {string} [cookie] - Optional cookie header for SSR requests.
+   * @param {string} [csrfToken] - CSRF token sent by the browser.
    * @return {Promise<T>} A promise that resolves with the response data of type T.
    */
-  async delete<T>(endpoint: string, cookie?: string): Promise<T> {
+  async delete<T>(
+    endpoint: string,
+    cookie?: string,
+    csrfToken?: string
+  ): Promise<T> {
     return this.request<T>(endpoint, {
       method: "DELETE",
-      headers: this.buildHeaders(cookie),
+      headers: this.buildHeaders(cookie, csrfToken),
     });
   }
 
@@ -183,15 +230,17 @@ export class ApiClient {
    * @param {string} endpoint - The endpoint to which the POST request is sent.
    * @param {FormData} formData - The form data to send.
    * @param {string} [cookie] - Optional cookie header for SSR requests.
+   * @param {string} [csrfToken] - CSRF token sent by the browser.
    * @return {Promise<T>} A promise that resolves to the response of the request.
    */
   async postFormData<T>(
     endpoint: string,
     formData: FormData,
-    cookie?: string
+    cookie?: string,
+    csrfToken?: string
   ): Promise<T> {
     const url = `${PORTFOLIO_BACKEND_URL}/${endpoint}`;
-    const headers: HeadersInit = this.buildHeaders(cookie);
+    const headers: HeadersInit = this.buildHeaders(cookie, csrfToken);
 
     const response = await fetch(url, {
       method: "POST",
@@ -208,6 +257,39 @@ export class ApiClient {
     const result = await response.json();
     return result.data;
   }
+
+  /**
+   * Opens a Server-Sent Events stream and returns the raw response, so it can be piped to the browser.
+   *
+   * @param {string} endpoint - The endpoint of the event stream.
+   * @param {string} [cookie] - Optional cookie header for SSR requests.
+   * @param {AbortSignal} [signal] - Aborts the stream, e.g. when the browser disconnects.
+   * @return {Promise<Response>} A promise that resolves to the streaming response.
+   */
+  async stream(
+    endpoint: string,
+    cookie?: string,
+    signal?: AbortSignal
+  ): Promise<Response> {
+    const url = `${PORTFOLIO_BACKEND_URL}/${endpoint}`;
+    const headers: HeadersInit = {
+      ...this.buildHeaders(cookie),
+      Accept: "text/event-stream",
+    };
+
+    const response = await fetch(url, {
+      headers,
+      credentials: "include",
+      signal,
+    });
+
+    if (!response.ok) {
+      const error = await response.json();
+      throw new ApiError(error.message || error.error, response.status);
+    }
+
+    return response;
+  }
 }
 
 export const api = ApiClient.getInstance();
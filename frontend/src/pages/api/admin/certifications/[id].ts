@@ -1,6 +1,6 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
-import { API_PATHS } from "@/utils/constants";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 export const GET: APIRoute = async ({ params, request }) => {
@@ -30,9 +30,10 @@ export const GET: APIRoute = async ({ params, request }) => {
 export const DELETE: APIRoute = async ({ params, request }) => {
   const { id } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
 
   const [_, error] = await asyncThrowable(() =>
-    api.delete(`${API_PATHS.UPLOAD_CERTIFICATES}/${id}`, cookie)
+    api.delete(`${API_PATHS.UPLOAD_CERTIFICATES}/${id}`, cookie, csrfToken)
   );
 
   if (error) {
@@ -1,7 +1,7 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
-import type { CareerCertifications, UploadResponse } from "@/types/types";
-import { API_PATHS } from "@/utils/constants";
+import type { CareerCertifications, UploadJob } from "@/types/types";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 export const GET: APIRoute = async ({ request }) => {
@@ -29,13 +29,15 @@ export const GET: APIRoute = async ({ request }) => {
 
 export const POST: APIRoute = async ({ request }) => {
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
   const formData = await request.formData();
 
-  const [data, error] = await asyncThrowable<UploadResponse>(() =>
-    api.postFormData<UploadResponse>(
+  const [data, error] = await asyncThrowable<UploadJob>(() =>
+    api.postFormData<UploadJob>(
       API_PATHS.UPLOAD_CERTIFICATES,
       formData,
-      cookie
+      cookie,
+      csrfToken
     )
   );
 
@@ -51,8 +53,9 @@ export const POST: APIRoute = async ({ request }) => {
     });
   }
 
+  // The upload runs in the background, follow it on /api/admin/jobs/:id/events
   return new Response(JSON.stringify({ data }), {
-    status: 201,
+    status: 202,
     headers: { "Content-Type": "application/json" },
   });
 };
@@ -1,7 +1,7 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
 import type { ExperienceClient } from "@/types/types";
-import { API_PATHS } from "@/utils/constants";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 const clientPath = (id: string, clientId: string): string =>
@@ -34,13 +34,15 @@ export const GET: APIRoute = async ({ params, request }) => {
 export const PATCH: APIRoute = async ({ params, request }) => {
   const { id, clientId } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
   const body = await request.json();
 
   const [data, error] = await asyncThrowable<ExperienceClient>(() =>
     api.patch<ExperienceClient>(
       clientPath(id ?? "", clientId ?? ""),
       body,
-      cookie
+      cookie,
+      csrfToken
     )
   );
 
@@ -63,9 +65,10 @@ export const PATCH: APIRoute = async ({ params, request }) => {
 export const DELETE: APIRoute = async ({ params, request }) => {
   const { id, clientId } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
 
   const [_, error] = await asyncThrowable(() =>
-    api.delete(clientPath(id ?? "", clientId ?? ""), cookie)
+    api.delete(clientPath(id ?? "", clientId ?? ""), cookie, csrfToken)
   );
 
   if (error) {
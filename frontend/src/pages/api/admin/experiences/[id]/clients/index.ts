@@ -1,7 +1,7 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
 import type { ExperienceClient, ExperienceClients } from "@/types/types";
-import { API_PATHS } from "@/utils/constants";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 export const GET: APIRoute = async ({ params, request }) => {
@@ -31,13 +31,15 @@ export const GET: APIRoute = async ({ params, request }) => {
 export const POST: APIRoute = async ({ params, request }) => {
   const { id } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
   const body = await request.json();
 
   const [data, error] = await asyncThrowable<ExperienceClient>(() =>
     api.post<ExperienceClient>(
       API_PATHS.EXPERIENCE_CLIENTS(id ?? ""),
       body,
-      cookie
+      cookie,
+      csrfToken
     )
   );
 
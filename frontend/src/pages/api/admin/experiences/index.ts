@@ -1,7 +1,7 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
 import type { Experience, Experiences } from "@/types/types";
-import { API_PATHS } from "@/utils/constants";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 export const GET: APIRoute = async ({ request }) => {
@@ -29,10 +29,11 @@ export const GET: APIRoute = async ({ request }) => {
 
 export const POST: APIRoute = async ({ request }) => {
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
   const body = await request.json();
 
   const [data, error] = await asyncThrowable<Experience>(() =>
-    api.post<Experience>(API_PATHS.EXPERIENCES, body, cookie)
+    api.post<Experience>(API_PATHS.EXPERIENCES, body, cookie, csrfToken)
   );
 
   if (error) {
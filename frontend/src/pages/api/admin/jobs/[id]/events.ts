@@ -0,0 +1,33 @@
+import type { APIRoute } from "astro";
+import { api } from "@/api/api-client";
+import { API_PATHS } from "@/utils/constants";
+import { asyncThrowable } from "@/utils/utils.ts";
+
+export const GET: APIRoute = async ({ params, request }) => {
+  const { id } = params;
+  const cookie = request.headers.get("cookie") || "";
+
+  // Pipe the backend event stream to the browser, closing it when the browser goes away
+  const [response, error] = await asyncThrowable<Response>(() =>
+    api.stream(API_PATHS.JOB_EVENTS(id!), cookie, request.signal)
+  );
+
+  if (error || !response?.body) {
+    const message =
+      error instanceof Error ? error.message : "Failed to follow upload job";
+    const status = (error as { statusCode?: number })?.statusCode || 500;
+    return new Response(JSON.stringify({ error: message }), {
+      status,
+      headers: { "Content-Type": "application/json" },
+    });
+  }
+
+  return new Response(response.body, {
+    status: 200,
+    headers: {
+      "Content-Type": "text/event-stream",
+      "Cache-Control": "no-cache",
+      "X-Accel-Buffering": "no",
+    },
+  });
+};
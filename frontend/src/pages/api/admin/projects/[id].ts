@@ -1,7 +1,7 @@
 import type { APIRoute } from "astro";
 import { api } from "@/api/api-client";
 import type { Project } from "@/types/types";
-import { API_PATHS } from "@/utils/constants";
+import { API_PATHS, CSRF } from "@/utils/constants";
 import { asyncThrowable } from "@/utils/utils.ts";
 
 export const GET: APIRoute = async ({ params, request }) => {
@@ -31,10 +31,11 @@ export const GET: APIRoute = async ({ params, request }) => {
 export const PATCH: APIRoute = async ({ params, request }) => {
   const { id } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
   const body = await request.json();
 
   const [data, error] = await asyncThrowable<Project>(() =>
-    api.patch<Project>(`${API_PATHS.PROJECTS}/${id}`, body, cookie)
+    api.patch<Project>(`${API_PATHS.PROJECTS}/${id}`, body, cookie, csrfToken)
   );
 
   if (error) {
@@ -56,9 +57,10 @@ export const PATCH: APIRoute = async ({ params, request }) => {
 export const DELETE: APIRoute = async ({ params, request }) => {
   const { id } = params;
   const cookie = request.headers.get("cookie") || "";
+  const csrfToken = request.headers.get(CSRF.HEADER) || "";
 
   const [_, error] = await asyncThrowable(() =>
-    api.delete(`${API_PATHS.PROJECTS}/${id}`, cookie)
+    api.delete(`${API_PATHS.PROJECTS}/${id}`, cookie, csrfToken)
   );
 
   if (error) {
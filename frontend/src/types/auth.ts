@@ -3,10 +3,14 @@ import { z } from "zod";
 export const userSchema = z.object({
   id: z.number().positive(),
   email: z.email(),
+  two_factor_enabled: z.boolean(),
 });
 
 export const authResponseSchema = z.object({
-  user: userSchema,
+  user: userSchema.optional(),
+  two_factor_required: z.boolean().optional(),
+  challenge: z.string().optional(),
+  challenge_expires_at: z.string().optional(),
   message: z.string(),
 });
 
@@ -15,6 +19,12 @@ export const loginRequestSchema = z.object({
   password: z.string().min(1, "Password is required"),
 });
 
+export const verifyLoginRequestSchema = z.object({
+  challenge: z.string().min(1),
+  code: z.string().min(1, "Code is required"),
+});
+
 export type User = z.infer<typeof userSchema>;
 export type AuthResponse = z.infer<typeof authResponseSchema>;
 export type LoginRequest = z.infer<typeof loginRequestSchema>;
+export type VerifyLoginRequest = z.infer<typeof verifyLoginRequestSchema>;
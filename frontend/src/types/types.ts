@@ -55,31 +55,69 @@ export const projectSchema = z.object({
   updatedAt: z.date(),
 });
 
+export const renditionSchema = z.object({
+  name: z.string(),
+  url: z.url(),
+  file_name: z.string(),
+  width: z.number(),
+  height: z.number(),
+  size: z.number(),
+});
+
 export const uploadedFileSchema = z.object({
   id: z.number().positive(),
   title: z.string(),
   issuer: z.string(),
   issue_date: z.coerce.date(),
+  expiry_date: z.coerce.date().optional(),
+  status: z.enum(["valid", "expiring", "expired"]).optional(),
   file_url: z.url(),
   file_name: z.string(),
   original_name: z.string(),
   file_size: z.number(),
   mime_type: z.string(),
+  renditions: z.array(renditionSchema).optional(),
+  content_hash: z.string().optional(),
   created_at: z.coerce.date(),
   updated_at: z.coerce.date(),
 });
 
-export const uploadErrorSchema = z.object({
-  original: z.string(),
-  error: z.string(),
+export const uploadJobFileSchema = z.object({
+  index: z.number(),
+  original_name: z.string(),
+  size: z.number(),
+  status: z.enum(["pending", "staged", "succeeded", "failed", "duplicate"]),
+  certification_id: z.number().optional(),
+  error: z.string().optional(),
+});
+
+export const uploadJobSchema = z.object({
+  id: z.number().positive(),
+  status: z.enum(["queued", "running", "completed", "failed"]),
+  atomic: z.boolean(),
+  workers: z.number(),
+  total: z.number(),
+  processed: z.number(),
+  succeeded: z.number(),
+  failed: z.number(),
+  duplicates: z.number(),
+  files: z.array(uploadJobFileSchema),
+  error: z.string().optional(),
+  started_at: z.coerce.date().optional(),
+  finished_at: z.coerce.date().optional(),
+  created_at: z.coerce.date(),
+  updated_at: z.coerce.date(),
 });
 
-export const uploadResponseSchema = z.object({
+export const uploadJobProgressSchema = z.object({
+  job_id: z.number(),
+  status: uploadJobSchema.shape.status,
   total: z.number(),
-  successful: z.number(),
+  processed: z.number(),
+  succeeded: z.number(),
   failed: z.number(),
-  files: z.array(uploadedFileSchema),
-  errors: z.array(uploadErrorSchema),
+  duplicates: z.number(),
+  file: uploadJobFileSchema,
 });
 
 export type Experience = z.infer<typeof experienceSchema>;
@@ -93,7 +131,8 @@ export type Project = z.infer<typeof projectSchema>;
 export type Projects = Array<Project>;
 
 export type CareerCertifications = Array<z.infer<typeof uploadedFileSchema>>;
-export type UploadResponse = z.infer<typeof uploadResponseSchema>;
+export type UploadJob = z.infer<typeof uploadJobSchema>;
+export type UploadJobProgress = z.infer<typeof uploadJobProgressSchema>;
 
 /**
  * A TypeScript type that represents the result of an asynchronous operation that can either resolve with data
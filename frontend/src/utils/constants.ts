@@ -5,9 +5,21 @@ export const API_PATHS = {
     `experiences/${experienceId}/clients`,
   PROJECTS: "projects",
   UPLOAD_CERTIFICATES: "upload-certificates",
+  // Background upload jobs and their Server-Sent Events stream.
+  JOB: (jobId: number | string) => `jobs/${jobId}`,
+  JOB_EVENTS: (jobId: number | string) => `jobs/${jobId}/events`,
   AUTH: {
     LOGIN: "auth/login",
     LOGOUT: "auth/logout",
     ME: "auth/me",
   },
 };
+
+// CSRF protection: the backend sets the token in a cookie the browser can read, and every
+// write request to the admin API sends it back in this header. Plain HTML forms, such as the
+// logout one, carry it in a hidden field instead.
+export const CSRF = {
+  COOKIE: "portfolio_csrf",
+  HEADER: "X-CSRF-Token",
+  FIELD: "csrf_token",
+};
@@ -0,0 +1,18 @@
+import { CSRF } from "@/utils/constants";
+
+/**
+ * Returns the header carrying the CSRF token of the session, read in the browser from the
+ * CSRF cookie set at login. Write requests to the admin API are refused without it.
+ *
+ * @returns {Record<string, string>} The CSRF header, empty when not signed in.
+ */
+export const csrfHeaders = (): Record<string, string> => {
+  const prefix = `${CSRF.COOKIE}=`;
+  const cookie = document.cookie
+    .split("; ")
+    .find((entry) => entry.startsWith(prefix));
+
+  return cookie
+    ? { [CSRF.HEADER]: decodeURIComponent(cookie.slice(prefix.length)) }
+    : {};
+};